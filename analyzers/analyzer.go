@@ -0,0 +1,475 @@
+package analyzers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzer defines default gopium analyzer instance
+// that could be used directly by analysis drivers
+var Analyzer = NewAnalyzer()
+
+// analyzer defines go analysis analyzer flags holder
+// that applies gopium strategies pipeline to package structs
+type analyzer struct {
+	compiler string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	arch     string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	caches   string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	regex    string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	stgs     string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [48]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
+
+// NewAnalyzer creates new go analysis analyzer
+// that runs configurable gopium strategies pipeline
+// on each package struct and reports suggested fixes
+// for structs which layout differs from the result
+func NewAnalyzer() *analysis.Analyzer {
+	a := &analyzer{}
+	an := &analysis.Analyzer{
+		Name: gopium.NAME,
+		Doc: `gopium applies strategies pipeline to package structs
+
+Gopium analyzer reports each struct which layout differs
+from the layout produced by provided gopium strategies pipeline
+and suggests fix that rewrites the struct accordingly.`,
+		URL: gopium.PKG,
+		Run: a.run,
+	}
+	// set up analyzer flags
+	an.Flags.StringVar(
+		&a.compiler,
+		"target_compiler",
+		"gc",
+		"Gopium target platform compiler, possible values are: gc or gccgo.",
+	)
+	an.Flags.StringVar(
+		&a.arch,
+		"target_architecture",
+		"amd64",
+		"Gopium target platform architecture, possible values are: 386, arm, arm64, amd64, mips, etc.",
+	)
+	an.Flags.StringVar(
+		&a.caches,
+		"target_cpu_cache_lines_sizes",
+		"64,64,64",
+		"Gopium target platform CPU cache line sizes in bytes, comma separated list l1,l2,l3,... is expected.",
+	)
+	an.Flags.StringVar(
+		&a.regex,
+		"walker_regexp",
+		".*",
+		"Gopium walker regexp, regexp that defines which structures are subjects for visiting.",
+	)
+	an.Flags.StringVar(
+		&a.stgs,
+		"strategies",
+		fmt.Sprintf("%s,%s", strategies.FPad, strategies.Pack),
+		"Gopium strategies, comma separated list of strategies which is applied one by one.",
+	)
+	return an
+}
+
+// run applies gopium strategies pipeline
+// to all package structs and reports
+// diagnostic with suggested fix for each
+// struct which layout has been changed
+func (a *analyzer) run(pass *analysis.Pass) (interface{}, error) {
+	// set up maven, strategy and regex
+	m, stg, regex, err := a.setup()
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	// detect structs which layout
+	// is relied on by package code
+	loc := typepkg.NewLocator(pass.Fset)
+	typepkg.Pin(pass.Pkg, pass.TypesInfo, pass.Files, loc)
+	// go through all package files
+	// and visit all structs decls
+	for _, file := range pass.Files {
+		var verr error
+		ast.Inspect(file, func(node ast.Node) bool {
+			// stop inspection on any error
+			if verr != nil {
+				return false
+			}
+			ts, ok := node.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			// skip non struct type specs
+			// and struct names that don't match regex
+			tst, ok := ts.Type.(*ast.StructType)
+			if !ok || !regex.MatchString(ts.Name.Name) {
+				return true
+			}
+			tn, ok := pass.TypesInfo.Defs[ts.Name].(*types.TypeName)
+			if !ok {
+				return true
+			}
+			// skip generic structs as their
+			// type parameters fields have
+			// no size until instantiation
+			if named, ok := tn.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+				return true
+			}
+			st, ok := tn.Type().Underlying().(*types.Struct)
+			if !ok {
+				return true
+			}
+			// convert original struct to inner gopium
+			// format and apply strategy to it
+			o := enum(m, qualifier(pass.Pkg, file), ts.Name.Name, st)
+			r, err := stg.Apply(ctx, o)
+			if err != nil {
+				verr = err
+				return false
+			}
+			// keep pinned structs intact
+			if _, ok := loc.Pin(tn.Pos(), ""); ok && moved(o, r) {
+				return true
+			}
+			// skip structs which layout hasn't been changed
+			if reflect.DeepEqual(layout(o), layout(r)) {
+				return true
+			}
+			verr = a.report(pass, file, ts, tst, o, r)
+			return verr == nil
+		})
+		if verr != nil {
+			return nil, verr
+		}
+	}
+	return nil, nil
+}
+
+// setup builds maven, strategy and regex
+// from analyzer flags or returns error
+func (a *analyzer) setup() (gopium.Maven, gopium.Strategy, *regexp.Regexp, error) {
+	// parse caches list
+	var caches []int64
+	for _, cache := range strings.Split(a.caches, ",") {
+		if cache = strings.TrimSpace(cache); cache == "" {
+			continue
+		}
+		size, err := strconv.ParseInt(cache, 10, 64)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("can't parse cache line size %q %v", cache, err)
+		}
+		caches = append(caches, size)
+	}
+	// set up maven
+	m, err := typepkg.NewMavenGoTypes(a.compiler, a.arch, caches...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("can't set up maven %v", err)
+	}
+	// cast strategies strings to strategy names
+	var snames []gopium.StrategyName
+	for _, name := range strings.Split(a.stgs, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			snames = append(snames, gopium.StrategyName(name))
+		}
+	}
+	// build strategy
+	stg, err := strategies.Builder{Curator: m}.Build(snames...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("can't build such strategy %v %v", snames, err)
+	}
+	// compile regexp
+	regex, err := regexp.Compile(a.regex)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("can't compile such regexp %v", err)
+	}
+	return m, stg, regex, nil
+}
+
+// report renders resulted struct to go code
+// and reports it as diagnostic suggested fix
+func (a *analyzer) report(
+	pass *analysis.Pass,
+	file *ast.File,
+	ts *ast.TypeSpec,
+	tst *ast.StructType,
+	o gopium.Struct,
+	r gopium.Struct,
+) error {
+	// render resulted struct type
+	src, err := render(pass.Fset, file, ts, tst, r)
+	if err != nil {
+		return err
+	}
+	// replace struct type and
+	// its trailing comment if any
+	edits := []analysis.TextEdit{{Pos: tst.Pos(), End: tst.End(), NewText: src}}
+	if len(r.Comment) > 0 {
+		if com := trailing(pass.Fset, file, tst.End()); com != nil {
+			edits[0].End = com.End()
+		}
+	}
+	// insert struct docs before declaration line
+	if len(r.Doc) > 0 {
+		tf := pass.Fset.File(ts.Pos())
+		pos := tf.LineStart(tf.Line(ts.Pos()))
+		edits = append(edits, analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(note(r.Doc) + "\n")})
+	}
+	// calculate sizes of both structs
+	sizeo, _, ptro := collections.SizeAlignPtr(o)
+	sizer, _, ptrr := collections.SizeAlignPtr(r)
+	pass.Report(analysis.Diagnostic{
+		Pos: ts.Pos(),
+		End: ts.End(),
+		Message: fmt.Sprintf(
+			"struct %s layout differs from gopium strategies result, size %d -> %d bytes, ptr scan size %d -> %d bytes",
+			ts.Name.Name,
+			sizeo,
+			sizer,
+			ptro,
+			ptrr,
+		),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("apply gopium strategies %s to struct %s", a.stgs, ts.Name.Name),
+			TextEdits: edits,
+		}},
+	})
+	return nil
+}
+
+// enum converts types struct to inner gopium
+// struct format by using maven exposer
+// and calculates its fields offsets,
+// fields types names are qualified by qualifier
+// so they are valid go code inside the file
+func enum(exp gopium.Exposer, q types.Qualifier, name string, st *types.Struct) gopium.Struct {
+	r := gopium.Struct{Name: name}
+	nf := st.NumFields()
+	r.Fields = make([]gopium.Field, 0, nf)
+	for i := 0; i < nf; i++ {
+		f := st.Field(i)
+		r.Fields = append(r.Fields, gopium.Field{
+			Name:     f.Name(),
+			Type:     types.TypeString(f.Type(), q),
+			Size:     exp.Size(f.Type()),
+			Align:    exp.Align(f.Type()),
+			Ptr:      exp.Ptr(f.Type()),
+			Tag:      st.Tag(i),
+			Exported: f.Exported(),
+			Embedded: f.Embedded(),
+		})
+	}
+	return collections.OffsetStruct(r)
+}
+
+// qualifier returns types qualifier relative
+// to provided package that respects file imports
+// names, packages that aren't imported by the file
+// are qualified by their own names
+func qualifier(pkg *types.Package, file *ast.File) types.Qualifier {
+	return func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		for _, imp := range file.Imports {
+			if path, err := strconv.Unquote(imp.Path.Value); err != nil || path != p.Path() {
+				continue
+			}
+			// dot imports aren't qualified
+			// and blank imports are skipped
+			if imp.Name != nil {
+				switch imp.Name.Name {
+				case ".":
+					return ""
+				case "_":
+					continue
+				default:
+					return imp.Name.Name
+				}
+			}
+			return p.Name()
+		}
+		return p.Name()
+	}
+}
+
+// moved checks if resulted struct
+// fields were moved or changed
+func moved(o gopium.Struct, r gopium.Struct) bool {
+	if len(o.Fields) != len(r.Fields) {
+		return true
+	}
+	for i := range o.Fields {
+		if o.Fields[i].Name != r.Fields[i].Name || o.Fields[i].Type != r.Fields[i].Type {
+			return true
+		}
+	}
+	return false
+}
+
+// layout strips all notes from struct
+// to compare only fields layout
+func layout(st gopium.Struct) gopium.Struct {
+	l := gopium.Struct{Name: st.Name}
+	for _, f := range st.Fields {
+		f.Doc, f.Comment = nil, nil
+		l.Fields = append(l.Fields, f)
+	}
+	return l
+}
+
+// render prints resulted struct type to go code
+// by reusing original fields sources
+// and generating new paddings
+func render(fset *token.FileSet, file *ast.File, ts *ast.TypeSpec, tst *ast.StructType, r gopium.Struct) ([]byte, error) {
+	// collect original fields sources
+	// in flat declaration order
+	fields := make(map[string]*ast.Field, tst.Fields.NumFields())
+	names := make(map[string]*ast.Ident, tst.Fields.NumFields())
+	for _, field := range tst.Fields.List {
+		// embedded fields are identified by type name
+		if len(field.Names) == 0 {
+			fields[embedded(field.Type)] = field
+			continue
+		}
+		for _, name := range field.Names {
+			fields[name.Name] = field
+			names[name.Name] = name
+		}
+	}
+	// write resulted fields one by one
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\ntype %s struct {\n", file.Name.Name, ts.Name.Name)
+	for _, f := range r.Fields {
+		field, ok := fields[f.Name]
+		// pad fields and unknown fields are generated
+		if f.Name == "_" || !ok {
+			if len(f.Doc) > 0 {
+				fmt.Fprintf(&buf, "%s\n", note(f.Doc))
+			}
+			fmt.Fprintf(&buf, "%s %s%s", f.Name, f.Type, tag(f.Tag))
+			if len(f.Comment) > 0 {
+				fmt.Fprintf(&buf, " %s", note(f.Comment))
+			}
+			buf.WriteString("\n")
+			continue
+		}
+		// write field doc either from result
+		// or from original field source
+		switch {
+		case len(f.Doc) > 0:
+			fmt.Fprintf(&buf, "%s\n", note(f.Doc))
+		case field.Doc != nil:
+			for _, com := range field.Doc.List {
+				fmt.Fprintf(&buf, "%s\n", com.Text)
+			}
+		}
+		// write field name and type
+		if !f.Embedded {
+			fmt.Fprintf(&buf, "%s ", names[f.Name].Name)
+		}
+		buf.Write(source(fset, field.Type))
+		// write field tag either from result
+		// or from original field source
+		switch {
+		case f.Tag != "":
+			buf.WriteString(tag(f.Tag))
+		case field.Tag != nil:
+			fmt.Fprintf(&buf, " %s", field.Tag.Value)
+		}
+		// write field comment either from result
+		// or from original field source
+		switch {
+		case len(f.Comment) > 0:
+			fmt.Fprintf(&buf, " %s", note(f.Comment))
+		case field.Comment != nil:
+			for _, com := range field.Comment.List {
+				fmt.Fprintf(&buf, " %s", com.Text)
+			}
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}")
+	if len(r.Comment) > 0 {
+		fmt.Fprintf(&buf, " %s", note(r.Comment))
+	}
+	buf.WriteString("\n")
+	// format rendered declaration
+	// and cut off artificial header
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("type %s ", ts.Name.Name)
+	if index := bytes.Index(src, []byte(header)); index >= 0 {
+		src = src[index+len(header):]
+	}
+	return bytes.TrimRight(src, "\n"), nil
+}
+
+// source returns original source of ast node
+func source(fset *token.FileSet, node ast.Node) []byte {
+	var buf bytes.Buffer
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_ = format.Node(&buf, fset, node)
+	return buf.Bytes()
+}
+
+// trailing finds comment group
+// that starts on the same line
+// right after provided pos
+func trailing(fset *token.FileSet, file *ast.File, pos token.Pos) *ast.CommentGroup {
+	line := fset.Position(pos).Line
+	for _, com := range file.Comments {
+		if com.Pos() >= pos && fset.Position(com.Pos()).Line == line {
+			return com
+		}
+	}
+	return nil
+}
+
+// embedded returns embedded field type name
+func embedded(expr ast.Expr) string {
+	switch tp := expr.(type) {
+	case *ast.Ident:
+		return tp.Name
+	case *ast.StarExpr:
+		return embedded(tp.X)
+	case *ast.SelectorExpr:
+		return tp.Sel.Name
+	case *ast.IndexExpr:
+		return embedded(tp.X)
+	case *ast.IndexListExpr:
+		return embedded(tp.X)
+	}
+	return ""
+}
+
+// tag formats struct field tag literal
+func tag(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	return fmt.Sprintf(" `%s`", tag)
+}
+
+// note formats list of notes to single comment
+func note(notes []string) string {
+	return fmt.Sprintf("//%s", strings.ReplaceAll(strings.Join(notes, ""), "//", ""))
+}
@@ -0,0 +1,339 @@
+package analyzers
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestAnalyzer(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		flags map[string]string
+		src   string
+		diags []string
+		fixes []string
+		err   error
+	}{
+		"empty package should report nothing": {
+			src: `package test`,
+		},
+		"optimal struct should report nothing": {
+			src: `
+package test
+
+type A struct {
+	a int64
+	b bool
+}
+`,
+		},
+		"non optimal struct should report expected fix": {
+			src: `
+package test
+
+type A struct {
+	// a doc
+	a bool
+	b int64 // b comment
+	c bool
+}
+`,
+			diags: []string{
+				"struct A layout differs from gopium strategies result, size 24 -> 16 bytes, ptr scan size 0 -> 0 bytes",
+			},
+			fixes: []string{
+				"struct {\n\tb int64 // b comment\n\t// a doc\n\ta bool\n\tc bool\n}",
+			},
+		},
+		"non optimal nested and embedded structs should report expected fixes": {
+			src: `
+package test
+
+type B struct{}
+
+func f() {
+	type A struct {
+		B
+		a, b bool
+		c    string ` + "`json:\"c\"`" + `
+	}
+}
+`,
+			diags: []string{
+				"struct A layout differs from gopium strategies result, size 24 -> 24 bytes, ptr scan size 16 -> 8 bytes",
+			},
+			fixes: []string{
+				"struct {\n\tB\n\tc string `json:\"c\"`\n\ta bool\n\tb bool\n}",
+			},
+		},
+		"generic structs should report nothing": {
+			src: `
+package test
+
+type G[T any] struct {
+	a bool
+	b T
+	c int64
+	d bool
+}
+
+type P[K comparable, V any] struct {
+	a bool
+	m map[K]V
+	c bool
+}
+
+var g G[int64]
+`,
+		},
+		"pinned structs should report nothing and other structs should report expected fixes": {
+			src: `
+package test
+
+import (
+	"encoding/binary"
+	"io"
+	"unsafe"
+)
+
+type A struct {
+	a bool
+	b int64
+	c bool
+}
+
+type D struct {
+	a bool
+	b int64
+	c bool
+}
+
+type B struct {
+	a bool
+	b int64
+	c bool
+}
+
+type C struct {
+	a bool
+	b B
+}
+
+var off = unsafe.Offsetof(A{}.c)
+
+func f(p unsafe.Pointer) *C {
+	return (*C)(p)
+}
+
+type E struct {
+	a bool
+	b int64
+	c bool
+}
+
+func g(r io.Reader) (d D, err error) {
+	err = binary.Read(r, binary.LittleEndian, &d)
+	return
+}
+`,
+			diags: []string{
+				"struct E layout differs from gopium strategies result, size 24 -> 16 bytes, ptr scan size 0 -> 0 bytes",
+			},
+			fixes: []string{
+				"struct {\n\tb int64\n\ta bool\n\tc bool\n}",
+			},
+		},
+		"non matching regex struct should report nothing": {
+			flags: map[string]string{"walker_regexp": "^B$"},
+			src: `
+package test
+
+type A struct {
+	a bool
+	b int64
+}
+`,
+		},
+		"non optimal struct should report expected fix with notes and tags": {
+			flags: map[string]string{"strategies": "memory_pack,struct_annotate_comment,add_tag_group_force"},
+			src: `
+package test
+
+type A struct {
+	a bool
+	b int64
+} // old comment
+`,
+			diags: []string{
+				"struct A layout differs from gopium strategies result, size 16 -> 16 bytes, ptr scan size 0 -> 0 bytes",
+			},
+			fixes: []string{
+				"struct {\n\tb int64 `gopium:\"memory_pack,struct_annotate_comment,add_tag_group_force\"`\n\ta bool  `gopium:\"memory_pack,struct_annotate_comment,add_tag_group_force\"`\n} // struct size: 9 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg",
+			},
+		},
+		"struct with pads should report expected fix": {
+			flags: map[string]string{"strategies": "filter_pads,cache_rounding_cpu_l1_full"},
+			src: `
+package test
+
+type A struct {
+	a int64
+	_ [8]byte
+}
+`,
+			diags: []string{
+				"struct A layout differs from gopium strategies result, size 16 -> 64 bytes, ptr scan size 0 -> 0 bytes",
+			},
+			fixes: []string{
+				"struct {\n\ta int64\n\t_ [56]byte\n}",
+			},
+		},
+		"invalid strategy should return error": {
+			flags: map[string]string{"strategies": "test"},
+			src:   `package test`,
+			err:   errors.New(`can't build such strategy [test] strategy "test" wasn't found`),
+		},
+		"invalid regex should return error": {
+			flags: map[string]string{"walker_regexp": "["},
+			src:   `package test`,
+			err:   errors.New("can't compile such regexp error parsing regexp: missing closing ]: `[`"),
+		},
+		"invalid target should return error": {
+			flags: map[string]string{"target_architecture": "test"},
+			src:   `package test`,
+			err:   errors.New(`can't set up maven unsuported compiler "gc" arch "test" combination`),
+		},
+		"invalid cache lines should return error": {
+			flags: map[string]string{"target_cpu_cache_lines_sizes": "64,test"},
+			src:   `package test`,
+			err:   errors.New(`can't parse cache line size "test" strconv.ParseInt: parsing "test": invalid syntax`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			an := NewAnalyzer()
+			for flag, val := range tcase.flags {
+				if err := an.Flags.Set(flag, val); err != nil {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+			}
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tcase.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			info := &types.Info{
+				Types:      make(map[ast.Expr]types.TypeAndValue),
+				Defs:       make(map[*ast.Ident]types.Object),
+				Uses:       make(map[*ast.Ident]types.Object),
+				Selections: make(map[*ast.SelectorExpr]*types.Selection),
+			}
+			conf := &types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+			pkg, err := conf.Check("test", fset, []*ast.File{file}, info)
+			if err != nil {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			var diags, fixes []string
+			pass := &analysis.Pass{
+				Analyzer:  an,
+				Fset:      fset,
+				Files:     []*ast.File{file},
+				Pkg:       pkg,
+				TypesInfo: info,
+				Report: func(d analysis.Diagnostic) {
+					diags = append(diags, d.Message)
+					for _, fix := range d.SuggestedFixes {
+						fixes = append(fixes, string(fix.TextEdits[0].NewText))
+					}
+				},
+			}
+			// exec
+			_, err = an.Run(pass)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if !reflect.DeepEqual(diags, tcase.diags) {
+				t.Errorf("actual %v doesn't equal to expected %v", diags, tcase.diags)
+			}
+			if !reflect.DeepEqual(fixes, tcase.fixes) {
+				t.Errorf("actual %q doesn't equal to expected %q", fixes, tcase.fixes)
+			}
+		})
+	}
+}
+
+func TestEnum(t *testing.T) {
+	// prepare
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", `
+package test
+
+import (
+	z "github.com/x/y"
+	. "github.com/x/dot"
+	_ "github.com/x/blank"
+	"github.com/x/plain"
+)
+`, parser.ParseComments)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	pkg := types.NewPackage("github.com/x/test", "test")
+	named := func(path, name string) types.Type {
+		p := pkg
+		if path != pkg.Path() {
+			p = types.NewPackage(path, name[:len(name)-1])
+		}
+		return types.NewNamed(types.NewTypeName(token.NoPos, p, name, nil), types.NewStruct(nil, nil), nil)
+	}
+	st := types.NewStruct(
+		[]*types.Var{
+			types.NewVar(token.NoPos, pkg, "a", types.NewPointer(named("github.com/x/y", "yT"))),
+			types.NewVar(token.NoPos, pkg, "b", named("github.com/x/dot", "dotT")),
+			types.NewVar(token.NoPos, pkg, "c", types.NewSlice(named("github.com/x/blank", "blankT"))),
+			types.NewVar(token.NoPos, pkg, "d", types.NewMap(types.Typ[types.String], named("github.com/x/plain", "plainT"))),
+			types.NewVar(token.NoPos, pkg, "e", named("github.com/x/test", "testT")),
+		},
+		nil,
+	)
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	table := map[string]struct {
+		q     types.Qualifier
+		types []string
+	}{
+		"file qualifier should return types valid inside the file": {
+			q:     qualifier(pkg, file),
+			types: []string{"*z.yT", "dotT", "[]blank.blankT", "map[string]plain.plainT", "testT"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := enum(m, tcase.q, "test", st)
+			// check
+			var tps []string
+			for _, f := range r.Fields {
+				tps = append(tps, f.Type)
+			}
+			if !reflect.DeepEqual(tps, tcase.types) {
+				t.Errorf("actual %v doesn't equal to expected %v", tps, tcase.types)
+			}
+		})
+	}
+}
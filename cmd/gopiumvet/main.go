@@ -0,0 +1,14 @@
+package main
+
+import (
+	"github.com/1pkg/gopium/analyzers"
+
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+// main gopium analyzer entry point
+// that could be used either as standalone
+// checker or as `go vet -vettool` tool
+func main() {
+	singlechecker.Main(analyzers.Analyzer)
+}
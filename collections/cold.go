@@ -0,0 +1,37 @@
+package collections
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// ColdField creates cold companion pointer field
+// for structure with provided name and word size
+// that references generated companion structure
+// holding provided structure cold fields
+func ColdField(name string, word int64, cold ...string) gopium.Field {
+	return gopium.Field{
+		Name:  "cold",
+		Type:  fmt.Sprintf("*%sCold", name),
+		Size:  word,
+		Align: word,
+		Ptr:   word,
+		Comment: []string{
+			fmt.Sprintf("// cold fields: %s; - %s", strings.Join(cold, ", "), gopium.STAMP),
+		},
+	}
+}
+
+// ColdName checks if structure has cold
+// companion pointer field and returns
+// generated companion structure name
+func ColdName(st gopium.Struct) (string, bool) {
+	for _, f := range st.Fields {
+		if f.Name == "cold" && strings.HasPrefix(f.Type, "*"+st.Name) && strings.HasSuffix(f.Type, "Cold") {
+			return st.Name + "Cold", true
+		}
+	}
+	return "", false
+}
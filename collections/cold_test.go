@@ -0,0 +1,91 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestColdField(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		name string
+		word int64
+		cold []string
+		f    gopium.Field
+	}{
+		"cold field should return expected field": {
+			name: "test",
+			word: 8,
+			cold: []string{"a", "b"},
+			f: gopium.Field{
+				Name:    "cold",
+				Type:    "*testCold",
+				Size:    8,
+				Align:   8,
+				Ptr:     8,
+				Comment: []string{"// cold fields: a, b; - 🌺 gopium @1pkg"},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			f := ColdField(tcase.name, tcase.word, tcase.cold...)
+			// check
+			if !reflect.DeepEqual(f, tcase.f) {
+				t.Errorf("actual %v doesn't equal to expected %v", f, tcase.f)
+			}
+		})
+	}
+}
+
+func TestColdName(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		st   gopium.Struct
+		name string
+		ok   bool
+	}{
+		"empty struct should return empty name": {},
+		"struct without cold field should return empty name": {
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "cold",
+						Type: "bool",
+					},
+				},
+			},
+		},
+		"struct with cold field should return expected name": {
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Type: "bool",
+					},
+					ColdField("test", 8, "b"),
+				},
+			},
+			name: "testCold",
+			ok:   true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			cname, ok := ColdName(tcase.st)
+			// check
+			if cname != tcase.name {
+				t.Errorf("actual %v doesn't equal to expected %v", cname, tcase.name)
+			}
+			if ok != tcase.ok {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package collections
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/1pkg/gopium/gopium"
@@ -46,8 +47,19 @@ func (f Flat) Sorted() []gopium.Struct {
 			return false
 		case errj != nil:
 			return true
-		default:
+		case numi != numj:
 			return numi < numj
+		default:
+			// anonymous structs ids
+			// share the same line number
+			// so sort them by their columns
+			if coli, colj := column(ids[i]), column(ids[j]); coli != colj {
+				return coli < colj
+			}
+			// generic structs instances ids
+			// share the same line number
+			// so sort them naturally
+			return ids[i] < ids[j]
 		}
 	})
 	// collect all structs in asc order
@@ -56,3 +68,17 @@ func (f Flat) Sorted() []gopium.Struct {
 	}
 	return sorted
 }
+
+// column helps to parse optional column
+// from anonymous struct "%s:%d:%d" id
+// or returns zero column otherwise
+func column(id string) int {
+	if strings.Count(id, ":") < 2 {
+		return 0
+	}
+	col, err := strconv.Atoi(id[strings.LastIndex(id, ":")+1:])
+	if err != nil {
+		return 0
+	}
+	return col
+}
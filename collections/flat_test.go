@@ -71,6 +71,34 @@ func TestFlatSorted(t *testing.T) {
 				{Name: "test1000"},
 			},
 		},
+		"multiple generic instances items flat collection should return items sorted naturally inside line": {
+			f: Flat{
+				"test:2[int]":  gopium.Struct{Name: "test2[int]"},
+				"test:1":       gopium.Struct{Name: "test1"},
+				"test:2":       gopium.Struct{Name: "test2"},
+				"test:2[bool]": gopium.Struct{Name: "test2[bool]"},
+			},
+			r: []gopium.Struct{
+				{Name: "test1"},
+				{Name: "test2"},
+				{Name: "test2[bool]"},
+				{Name: "test2[int]"},
+			},
+		},
+		"multiple anonymous items flat collection should return items sorted by columns inside line": {
+			f: Flat{
+				"test:2:18": gopium.Struct{Name: "test2:18"},
+				"test:1":    gopium.Struct{Name: "test1"},
+				"test:2":    gopium.Struct{Name: "test2"},
+				"test:2:9":  gopium.Struct{Name: "test2:9"},
+			},
+			r: []gopium.Struct{
+				{Name: "test1"},
+				{Name: "test2"},
+				{Name: "test2:9"},
+				{Name: "test2:18"},
+			},
+		},
 		"multiple non pattern ids items flat collection should return items sorted naturally": {
 			f: Flat{
 				"test:a": gopium.Struct{Name: "testa"},
@@ -60,6 +60,12 @@ func SizeAlignPtr(st gopium.Struct) (int64, int64, int64) {
 	// preset defaults
 	var alsize, align, ptrsize int64 = 0, 1, 0
 	WalkStruct(st, 0, func(pad int64, fields ...gopium.Field) {
+		// gc pads zero size last field
+		// of non zero size struct by one byte
+		// so it doesn't point past the struct
+		if n := len(st.Fields); len(fields) == 0 && n > 0 && st.Fields[n-1].Size == 0 && alsize > 0 {
+			pad = Align(alsize+1, align) - alsize
+		}
 		// add pad to aligned size
 		alsize += pad
 		// go through fields
@@ -82,6 +88,31 @@ func SizeAlignPtr(st gopium.Struct) (int64, int64, int64) {
 	return alsize, align, ptrsize
 }
 
+// OffsetStruct calculates fields offsets and paddings
+// preceding them by using walk struct helper
+// and sets them to copy of provided struct
+func OffsetStruct(st gopium.Struct) gopium.Struct {
+	// copy original structure to result
+	r := CopyStruct(st)
+	// preset defaults
+	var offset, i int64 = 0, 0
+	WalkStruct(r, 0, func(pad int64, fields ...gopium.Field) {
+		// add pad to current offset
+		offset += pad
+		// go through fields
+		for range fields {
+			// set field offset and
+			// preceding padding
+			r.Fields[i].Offset = offset
+			r.Fields[i].Pad = pad
+			// add field size to offset
+			offset += r.Fields[i].Size
+			i++
+		}
+	})
+	return r
+}
+
 // PadField defines helper that
 // creates pad field with specified size
 func PadField(pad int64) gopium.Field {
@@ -49,6 +49,48 @@ func TestSizeAlignPtr(t *testing.T) {
 			align: 8,
 			ptr:   8,
 		},
+		"struct with trailing zero size field should return expected size, align and ptr": {
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "*int",
+						Size:  8,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test2",
+						Type:  "struct{}",
+						Align: 1,
+					},
+				},
+			},
+			size:  16,
+			align: 8,
+			ptr:   8,
+		},
+		"struct with only zero size fields should return expected size, align and ptr": {
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "[0]int64",
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Type:  "struct{}",
+						Align: 1,
+					},
+				},
+			},
+			size:  0,
+			align: 8,
+			ptr:   0,
+		},
 		"struct with pads should return expected size, align and ptr": {
 			st: gopium.Struct{
 				Name:    "test",
@@ -98,6 +140,95 @@ func TestSizeAlignPtr(t *testing.T) {
 	}
 }
 
+func TestOffsetStruct(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		st gopium.Struct
+		r  gopium.Struct
+	}{
+		"empty struct should return empty struct": {},
+		"non empty struct should return expected fields offsets and pads": {
+			st: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"test"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:   "test2",
+						Type:   "float64",
+						Size:   8,
+						Align:  8,
+						Offset: 100,
+					},
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+						Pad:   100,
+					},
+					{
+						Name:  "test4",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"test"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:   "test2",
+						Type:   "float64",
+						Size:   8,
+						Align:  8,
+						Offset: 8,
+						Pad:    7,
+					},
+					{
+						Name:   "test3",
+						Type:   "int32",
+						Size:   4,
+						Align:  4,
+						Offset: 16,
+					},
+					{
+						Name:   "test4",
+						Type:   "int64",
+						Size:   8,
+						Align:  8,
+						Offset: 24,
+						Pad:    4,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := OffsetStruct(tcase.st)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to %v", r, tcase.r)
+			}
+		})
+	}
+}
+
 func TestPadField(t *testing.T) {
 	// prepare
 	table := map[string]struct {
@@ -0,0 +1,62 @@
+package collections
+
+// list of go runtime allocator
+// malloc size classes in bytes
+// note: copied from `runtime/sizeclasses.go`
+var sizeclasses = []int64{
+	0, 8, 16, 24, 32, 48, 64, 80, 96, 112, 128, 144, 160, 176, 192, 208, 224, 240, 256, 288,
+	320, 352, 384, 416, 448, 480, 512, 576, 640, 704, 768, 896, 1024, 1152, 1280, 1408, 1536,
+	1792, 2048, 2304, 2688, 3072, 3200, 3456, 4096, 4864, 5376, 6144, 6528, 6784, 6912, 8192,
+	9472, 9728, 10240, 10880, 12288, 13568, 14336, 16384, 18432, 19072, 20480, 21760, 24576,
+	27264, 28672, 32768,
+}
+
+// pagesize defines go runtime allocator
+// page size that large objects are rounded to
+const pagesize = 8192
+
+// SizeClass calculates go runtime allocator size class
+// that object of provided size is allocated in,
+// objects bigger than the biggest size class
+// are allocated directly with rounding to page size
+func SizeClass(size int64) int64 {
+	// skip zero sized objects
+	if size <= 0 {
+		return 0
+	}
+	// round large objects to page size
+	if size > sizeclasses[len(sizeclasses)-1] {
+		return Align(size, pagesize)
+	}
+	// find the smallest fitting size class
+	i := 0
+	for sizeclasses[i] < size {
+		i++
+	}
+	return sizeclasses[i]
+}
+
+// PrevSizeClass calculates the biggest go runtime
+// allocator size class that is smaller than size class
+// of provided size, zero is returned for the smallest classes
+func PrevSizeClass(size int64) int64 {
+	// get current object size class
+	class := SizeClass(size)
+	// for large objects previous class
+	// is either previous page or the biggest class
+	if biggest := sizeclasses[len(sizeclasses)-1]; class > biggest {
+		if prev := class - pagesize; prev > biggest {
+			return prev
+		}
+		return biggest
+	}
+	// find the biggest smaller size class
+	var prev int64
+	for _, c := range sizeclasses {
+		if c >= class {
+			break
+		}
+		prev = c
+	}
+	return prev
+}
@@ -0,0 +1,65 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSizeClass(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		size  int64
+		class int64
+		prev  int64
+	}{
+		"zero size should return zero classes": {
+			size:  0,
+			class: 0,
+			prev:  0,
+		},
+		"tiny size should return the smallest class": {
+			size:  3,
+			class: 8,
+			prev:  0,
+		},
+		"exact class size should return the same class": {
+			size:  48,
+			class: 48,
+			prev:  32,
+		},
+		"small size should return expected classes": {
+			size:  52,
+			class: 64,
+			prev:  48,
+		},
+		"the biggest class size should return expected classes": {
+			size:  32000,
+			class: 32768,
+			prev:  28672,
+		},
+		"large size should return page rounded classes": {
+			size:  40000,
+			class: 40960,
+			prev:  32768,
+		},
+		"huge size should return page rounded classes": {
+			size:  50000,
+			class: 57344,
+			prev:  49152,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			class := SizeClass(tcase.size)
+			prev := PrevSizeClass(tcase.size)
+			// check
+			if !reflect.DeepEqual(class, tcase.class) {
+				t.Errorf("actual %v doesn't equal to expected %v", class, tcase.class)
+			}
+			if !reflect.DeepEqual(prev, tcase.prev) {
+				t.Errorf("actual %v doesn't equal to expected %v", prev, tcase.prev)
+			}
+		})
+	}
+}
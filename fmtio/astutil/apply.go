@@ -6,6 +6,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"io"
 	"sort"
 	"strings"
 	"sync"
@@ -19,23 +20,53 @@ import (
 )
 
 // UFFN implements apply and combines:
+// - split helper with provided writer reporting
+// - unkeyed helper with provided writer reporting
 // - ufmt with fmtio FSPT helper
 // - filter helper
 // - note helper
-var UFFN = combine(
-	ufmt(walk, fmtio.FSPT),
-	filter(walk),
-	note(
-		walk,
-		&typepkg.ParserXToolPackagesAst{
-			ModeAst: parser.ParseComments | parser.AllErrors,
-		},
-		fmtio.Gofmt{},
-	),
-)
+func UFFN(report io.Writer) gopium.Apply {
+	return combine(
+		split(walk, report),
+		unkeyed(walk, report),
+		ufmt(walk, fmtio.FSPT),
+		filter(walk),
+		note(
+			walk,
+			&typepkg.ParserXToolPackagesAst{
+				ModeAst: parser.ParseComments | parser.AllErrors,
+			},
+			fmtio.Gofmt{},
+		),
+	)
+}
+
+// touchedKey defines context key
+// for package files touched by ast helpers
+type touchedKey struct{}
+
+// touch helps to mark package file
+// as touched in context if context
+// carries touched package files
+func touch(ctx context.Context, name string) {
+	if touched, ok := ctx.Value(touchedKey{}).(*sync.Map); ok {
+		touched.Store(name, true)
+	}
+}
+
+// touches checks if package file
+// is marked as touched in context
+func touches(ctx context.Context, name string) bool {
+	if touched, ok := ctx.Value(touchedKey{}).(*sync.Map); ok {
+		_, ok := touched.Load(name)
+		return ok
+	}
+	return false
+}
 
 // combine helps to pipe several
 // ast helpers to single apply func
+// that share touched package files
 func combine(funcs ...gopium.Apply) gopium.Apply {
 	return func(
 		ctx context.Context,
@@ -43,11 +74,9 @@ func combine(funcs ...gopium.Apply) gopium.Apply {
 		loc gopium.Locator,
 		c gopium.Categorized,
 	) (rpkg *ast.Package, err error) {
-		// filters all files that
-		// could be skipped
-		// we could skip err check here
-		// as cat alway returns nil
-		pkg, _ = cat(ctx, pkg, loc, c)
+		// mark touched package files
+		// in context shared by all funcs
+		ctx = context.WithValue(ctx, touchedKey{}, &sync.Map{})
 		// go through all provided funcs
 		for _, fun := range funcs {
 			// manage context actions
@@ -70,26 +99,6 @@ func combine(funcs ...gopium.Apply) gopium.Apply {
 	}
 }
 
-// cat helps to filter only ast files
-// that exist inside hierarchic collection
-func cat(
-	_ context.Context,
-	//nolint
-	pkg *ast.Package,
-	_ gopium.Locator,
-	c gopium.Categorized,
-) (*ast.Package, error) {
-	files := make(map[string]*ast.File, len(pkg.Files))
-	for name, file := range pkg.Files {
-		// skip empty writes
-		if _, ok := c.Cat(name); ok {
-			files[name] = file
-		}
-	}
-	pkg.Files = files
-	return pkg, nil
-}
-
 // ufmt helps to update ast package
 // accordingly to gopium struct result
 // using custom fmtio ast formatter
@@ -256,8 +265,10 @@ func note(w gopium.Walk, xp gopium.AstParser, p gopium.Printer) gopium.Apply {
 				if err != nil {
 					return err
 				}
-				// sort all comments by their ast pos
+				// note generated cold companion structs
+				// and sort all comments by their ast pos
 				file = node.(*ast.File)
+				coldnote(file, catsts)
 				sort.SliceStable(file.Comments, func(i, j int) bool {
 					return file.Comments[i].Pos() < file.Comments[j].Pos()
 				})
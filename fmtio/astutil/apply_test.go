@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"go/parser"
+	"io"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -108,13 +109,13 @@ func TestApply(t *testing.T) {
 	}{
 		"empty pkg should apply nothing": {
 			p:   data.NewParser("empty"),
-			a:   UFFN,
+			a:   UFFN(io.Discard),
 			ctx: context.Background(),
 			r:   map[string][]byte{},
 		},
 		"note struct pkg should apply expected structs": {
 			p:   data.NewParser("note"),
-			a:   UFFN,
+			a:   UFFN(io.Discard),
 			ctx: context.Background(),
 			h:   lh,
 			r: map[string][]byte{
@@ -148,7 +149,7 @@ type DocCom struct {
 		},
 		"note struct pkg should skip expected structs": {
 			p:   data.NewParser("note"),
-			a:   UFFN,
+			a:   UFFN(io.Discard),
 			ctx: context.Background(),
 			h:   ldc,
 			r: map[string][]byte{
@@ -197,7 +198,7 @@ type tt gopium.Struct
 		},
 		"note struct pkg should apply nothing on canceled context": {
 			p:   data.NewParser("note"),
-			a:   UFFN,
+			a:   UFFN(io.Discard),
 			ctx: cctx,
 			r:   map[string][]byte{},
 			err: context.Canceled,
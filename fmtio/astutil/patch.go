@@ -0,0 +1,163 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Patch ast asyn pesists package implementation
+// which prints ast files one by one and persists
+// single unified diff of all changed files
+// against original os files to fmtio writer
+type Patch struct{} // struct size: 0 bytes; struct align: 1 bytes; struct aligned size: 0 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Persist patch implementation
+func (Patch) Persist(
+	ctx context.Context,
+	p gopium.Printer,
+	w gopium.Writer,
+	loc gopium.Locator,
+	node ast.Node,
+) error {
+	// create sync error group
+	// with cancelation context
+	group, gctx := errgroup.WithContext(ctx)
+	// prepare patches storage
+	var mutex sync.Mutex
+	files := node.(*ast.Package).Files
+	patches := make(map[string][]byte, len(files))
+	// go through all files in package
+	// and diff them to concurently
+	for name, file := range files {
+		// manage context actions
+		// in case of cancelation
+		// stop execution
+		select {
+		case <-gctx.Done():
+			break
+		default:
+		}
+		// capture name and file copies
+		name := name
+		file := file
+		// run error group diff call
+		group.Go(func() error {
+			// grab the latest file fset
+			fset, _ := loc.Fset(name, nil)
+			// print updated ast file to buffer
+			// use original file set to keep format
+			// in case any error happened
+			// just return error back
+			var buf bytes.Buffer
+			if err := p.Print(gctx, &buf, fset, file); err != nil {
+				return err
+			}
+			// read original file source
+			// either from locator (overlay)
+			// or from os file otherwise
+			// in case any error happened
+			// just return error back
+			origin, ok := loc.Source(name, nil)
+			if !ok {
+				var err error
+				if origin, err = os.ReadFile(name); err != nil {
+					return err
+				}
+			}
+			// diff original and updated files
+			// and store patch to storage
+			patch := fmtio.Unified(rel(name), origin, buf.Bytes())
+			defer mutex.Unlock()
+			mutex.Lock()
+			patches[name] = patch
+			return gctx.Err()
+		})
+	}
+	// wait until all diffs
+	// resolve their jobs
+	if err := group.Wait(); err != nil {
+		return err
+	}
+	// combine all patches
+	// in files names order
+	names := make([]string, 0, len(patches))
+	for name := range patches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf bytes.Buffer
+	for _, name := range names {
+		buf.Write(patches[name])
+	}
+	// skip empty patches
+	if buf.Len() == 0 {
+		return nil
+	}
+	// generate relevant writer
+	// in case any error happened
+	// just return error back
+	writer, err := w.Generate(names[0])
+	if err != nil {
+		return err
+	}
+	// write combined patch
+	// and flush writer result
+	// in case any error happened
+	// just return error back
+	if _, err := writer.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// rel helps to make file path relative
+// to file repository root or module root
+// as patches can't contain parent paths,
+// otherwise it falls back to path relative
+// to current working directory if possible
+func rel(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	for _, marker := range []string{".git", "go.mod"} {
+		if root, ok := find(filepath.Dir(abs), marker); ok {
+			if rpath, err := filepath.Rel(root, abs); err == nil {
+				return filepath.ToSlash(rpath)
+			}
+		}
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if rpath, err := filepath.Rel(wd, abs); err == nil && !strings.HasPrefix(rpath, "..") {
+			return filepath.ToSlash(rpath)
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+// find looks for the closest directory
+// that contains provided marker file
+// starting from provided directory
+func find(dir string, marker string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,195 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestPatch(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		xp   gopium.Parser
+		ctx  context.Context
+		p    gopium.Printer
+		w    gopium.Writer
+		srcs map[string][]byte
+		r    map[string][]byte
+		err  error
+	}{
+		"empty pkg should persist nothing": {
+			xp:  data.NewParser("empty"),
+			ctx: context.Background(),
+			p:   fmtio.Gofmt{},
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			r:   map[string][]byte{},
+		},
+		"single struct pkg should persist nothing on unchanged struct": {
+			xp:  data.NewParser("single"),
+			ctx: context.Background(),
+			p:   fmtio.Gofmt{},
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			r:   map[string][]byte{},
+		},
+		"single struct pkg should persist expected patch on changed struct": {
+			xp:  data.NewParser("single"),
+			ctx: context.Background(),
+			p:   fmtio.NewGoprinter(0, 4, true),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			r: map[string][]byte{
+				"tests_data_single_file.go": []byte(`
+diff --git a/tests/data/single/file.go b/tests/data/single/file.go
+--- a/tests/data/single/file.go
++++ b/tests/data/single/file.go
+@@ -3,7 +3,7 @@
+ package single
+ 
+ type Single struct {
+-	A string
+-	B string
+-	C string
++    A   string
++    B   string
++    C   string
+ }
+`),
+			},
+		},
+		"single struct pkg should persist expected patch against overlay source": {
+			xp:  data.NewParser("single"),
+			ctx: context.Background(),
+			p:   fmtio.Gofmt{},
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			srcs: map[string][]byte{
+				filepath.Join("tests", "data", "single", "file.go"): []byte(`//go:build tests_data
+
+package single
+
+type Single struct {
+	A string
+	B string
+	C string
+	D string
+}
+`),
+			},
+			r: map[string][]byte{
+				"tests_data_single_file.go": []byte(`
+diff --git a/tests/data/single/file.go b/tests/data/single/file.go
+--- a/tests/data/single/file.go
++++ b/tests/data/single/file.go
+@@ -6,5 +6,4 @@
+ 	A string
+ 	B string
+ 	C string
+-	D string
+ }
+`),
+			},
+		},
+		"single struct pkg should persist nothing on canceled context": {
+			xp:  data.NewParser("single"),
+			ctx: cctx,
+			p:   fmtio.Gofmt{},
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			r:   map[string][]byte{},
+			err: context.Canceled,
+		},
+		"single struct pkg should persist nothing on printer error": {
+			xp:  data.NewParser("single"),
+			ctx: context.Background(),
+			p:   mocks.Printer{Err: errors.New("test-1")},
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			r:   map[string][]byte{},
+			err: errors.New("test-1"),
+		},
+		"single struct pkg should persist nothing on persist error": {
+			xp:  data.NewParser("single"),
+			ctx: context.Background(),
+			p:   fmtio.NewGoprinter(0, 4, true),
+			w:   data.Writer{Writer: (&mocks.Writer{Gerr: errors.New("test-2")})},
+			r:   map[string][]byte{},
+			err: errors.New("test-2"),
+		},
+		"single struct pkg should persist nothing on persist write error": {
+			xp:  data.NewParser("single"),
+			ctx: context.Background(),
+			p:   fmtio.NewGoprinter(0, 4, true),
+			w: data.Writer{Writer: (&mocks.Writer{RWCs: map[string]*mocks.RWC{
+				"tests_data_single_file.go": {Werr: errors.New("test-3")},
+			}})},
+			r:   map[string][]byte{},
+			err: errors.New("test-3"),
+		},
+		"single struct pkg should persist nothing on persist flush error": {
+			xp:  data.NewParser("single"),
+			ctx: context.Background(),
+			p:   fmtio.NewGoprinter(0, 4, true),
+			w: data.Writer{Writer: (&mocks.Writer{RWCs: map[string]*mocks.RWC{
+				"tests_data_single_file.go": {Cerr: errors.New("test-4")},
+			}})},
+			r:   map[string][]byte{},
+			err: errors.New("test-4"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			pkg, loc, err := tcase.xp.ParseAst(context.Background())
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			for name, src := range tcase.srcs {
+				loc.Source(filepath.Join(tests.Gopium, name), src)
+			}
+			// exec
+			err = Patch{}.Persist(tcase.ctx, tcase.p, tcase.w, loc, pkg)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				w := (tcase.w.(data.Writer)).Writer.(*mocks.Writer)
+				for name, rwc := range w.RWCs {
+					// check all patches
+					// against bytes map
+					if st, ok := tcase.r[name]; ok {
+						// read rwc to buffer
+						var buf bytes.Buffer
+						_, err := buf.ReadFrom(rwc)
+						if !reflect.DeepEqual(err, nil) {
+							t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+						}
+						// format actual and expected identically
+						actual := strings.Trim(buf.String(), "\n")
+						expected := strings.Trim(string(st), "\n")
+						if !reflect.DeepEqual(actual, expected) {
+							t.Errorf("name %v actual %v doesn't equal to expected %v", name, actual, expected)
+						}
+						delete(tcase.r, name)
+					} else {
+						t.Errorf("actual %v doesn't equal to expected %v", name, "")
+					}
+				}
+				// check that map has been drained
+				if !reflect.DeepEqual(tcase.r, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.r, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
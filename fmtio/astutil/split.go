@@ -0,0 +1,670 @@
+package astutil
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// coldsplit defines single split struct
+// data transfer object that keeps struct
+// type spec, companion struct name
+// and split cold fields names
+type coldsplit struct {
+	ts    *ast.TypeSpec   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	cold  map[string]bool `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	cname string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 24 bytes; - 🌺 gopium @1pkg
+
+// split helps to split cold fields of result
+// structs with cold companion pointer field
+// to generated companion structs
+//
+// it resolves fields accesses by type checking
+// ast package, moves cold fields to companion
+// structs appended to the end of structs files,
+// rewrites all cold fields selectors and literals
+// and reports accesses that can't be rewritten
+// including accesses inside other packages,
+// zero values and literals that can't be rewritten
+// don't allocate companion structs so they
+// fail the split with an error instead
+func split(w gopium.Walk, report io.Writer) gopium.Apply {
+	//nolint
+	return func(
+		ctx context.Context,
+		pkg *ast.Package,
+		loc gopium.Locator,
+		c gopium.Categorized,
+	) (*ast.Package, error) {
+		// collect all result structs type specs
+		tc := &tcollect{tss: make(map[*ast.TypeSpec]gopium.Struct)}
+		if _, err := w(
+			ctx,
+			pkg,
+			tc,
+			&flatid{loc: loc, sts: collections.Flat(c.Full())},
+		); err != nil {
+			return nil, err
+		}
+		// collect all result structs with cold
+		// companion pointer field which
+		// original structs don't have
+		cts := make(map[*ast.TypeSpec]gopium.Struct, len(tc.tss))
+		for ts, st := range tc.tss {
+			if _, ok := collections.ColdName(st); !ok || hascold(ts) {
+				continue
+			}
+			// anonymous structs can't
+			// have companion structs
+			if synthetic(ts) {
+				return nil, fmt.Errorf("anonymous struct %q can't be split", ts.Name.Name)
+			}
+			cts[ts] = st
+		}
+		// skip type checking
+		// if nothing should be split
+		if len(cts) == 0 {
+			return pkg, nil
+		}
+		// type check ast package files
+		names, tpkg, info := tcheck(pkg, loc.Root())
+		// collect all split structs
+		// by their types and cold fields
+		// by their fields objects
+		splits := make(map[types.Type]*coldsplit, len(cts))
+		fields := make(map[*types.Var]*coldsplit)
+		for ts, st := range cts {
+			tn, ok := info.Defs[ts.Name].(*types.TypeName)
+			if !ok || tpkg == nil || tn.Parent() != tpkg.Scope() {
+				return nil, fmt.Errorf("local struct %q can't be split", ts.Name.Name)
+			}
+			cname, _ := collections.ColdName(st)
+			if tpkg.Scope().Lookup(cname) != nil {
+				return nil, fmt.Errorf("struct %q can't be split, companion struct %q is already declared", ts.Name.Name, cname)
+			}
+			// all non blank and non embedded fields
+			// that result struct doesn't have are cold
+			hot := make(map[string]bool, len(st.Fields))
+			for _, f := range st.Fields {
+				hot[f.Name] = true
+			}
+			s := &coldsplit{ts: ts, cold: make(map[string]bool), cname: cname}
+			tst := tn.Type().Underlying().(*types.Struct)
+			for i := 0; i < tst.NumFields(); i++ {
+				f := tst.Field(i)
+				if f.Name() == "_" || f.Embedded() || hot[f.Name()] {
+					continue
+				}
+				s.cold[f.Name()] = true
+				fields[f] = s
+				// in case cold field could be
+				// accessed in other packages report it
+				if tn.Exported() && f.Exported() {
+					fmt.Fprintf(
+						report,
+						"%s: accesses to cold field %s of struct %s in other packages can't be rewritten\n",
+						loc.Root().Position(f.Pos()),
+						f.Name(),
+						ts.Name.Name,
+					)
+				}
+			}
+			splits[tn.Type()] = s
+		}
+		// collect all cold fields names
+		// and structs that have them
+		cnames := make(map[string][]string, len(fields))
+		for f, s := range fields {
+			cnames[f.Name()] = append(cnames[f.Name()], s.ts.Name.Name)
+		}
+		for _, snames := range cnames {
+			sort.Strings(snames)
+		}
+		// copies of split structs share companion
+		// structs with their originals so such
+		// usages are reported, while zero values
+		// don't allocate companion structs at all
+		// so such usages are collected as failures
+		var fails []string
+		holds := splitholds(splits)
+		copied := func(expr ast.Expr) {
+			switch ast.Unparen(expr).(type) {
+			case *ast.CompositeLit, *ast.CallExpr:
+				return
+			}
+			if tv, ok := info.Types[expr]; ok && tv.IsValue() {
+				if s := holds(tv.Type); s != nil {
+					fmt.Fprintf(
+						report,
+						"%s: copy of struct %s shares its cold fields\n",
+						loc.Root().Position(expr.Pos()),
+						s.ts.Name.Name,
+					)
+				}
+			}
+		}
+		zeroed := func(pos token.Pos, t types.Type) {
+			if s := holds(t); s != nil {
+				fails = append(fails, fmt.Sprintf(
+					"%s: zero value of struct %s doesn't allocate its cold fields",
+					loc.Root().Position(pos),
+					s.ts.Name.Name,
+				))
+			}
+		}
+		// go through all files
+		// and rewrite cold fields
+		// selectors and literals
+		for _, name := range names {
+			// manage context actions
+			// in case of cancelation
+			// stop execution
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			file := pkg.Files[name]
+			lhs := make(map[ast.Expr]bool)
+			ast.Inspect(file, func(node ast.Node) bool {
+				switch n := node.(type) {
+				case *ast.ValueSpec:
+					if len(n.Values) == 0 && n.Type != nil {
+						zeroed(n.Pos(), info.TypeOf(n.Type))
+					}
+					for _, val := range n.Values {
+						copied(val)
+					}
+				case *ast.FuncType:
+					if n.Results != nil {
+						for _, f := range n.Results.List {
+							if len(f.Names) > 0 {
+								zeroed(f.Pos(), info.TypeOf(f.Type))
+							}
+						}
+					}
+				case *ast.AssignStmt:
+					for _, expr := range n.Lhs {
+						lhs[ast.Unparen(expr)] = true
+					}
+					for _, expr := range n.Rhs {
+						copied(expr)
+					}
+				case *ast.ReturnStmt:
+					for _, expr := range n.Results {
+						copied(expr)
+					}
+				case *ast.SendStmt:
+					copied(n.Value)
+				case *ast.RangeStmt:
+					if n.Value != nil {
+						copied(n.Value)
+					}
+				case *ast.IndexExpr:
+					// missing map values are zero values
+					if t := info.TypeOf(n.X); t != nil && !lhs[n] {
+						if m, ok := t.Underlying().(*types.Map); ok {
+							zeroed(n.Pos(), m.Elem())
+						}
+					}
+				case *ast.CallExpr:
+					// builtin allocations produce zero values
+					// and any other calls copy their arguments
+					if b, ok := info.Uses[callee(n.Fun)].(*types.Builtin); ok {
+						switch b.Name() {
+						case "new":
+							zeroed(n.Pos(), info.TypeOf(n.Args[0]))
+						case "make":
+							// slices made with zero length
+							// don't have any zero values
+							t := info.TypeOf(n.Args[0])
+							if t == nil || len(n.Args) < 2 {
+								break
+							}
+							if sl, ok := t.Underlying().(*types.Slice); ok {
+								if tv := info.Types[n.Args[1]]; tv.Value == nil || tv.Value.String() != "0" {
+									zeroed(n.Pos(), sl.Elem())
+								}
+							}
+						case "append":
+							if !n.Ellipsis.IsValid() {
+								for _, arg := range n.Args[1:] {
+									copied(arg)
+								}
+							}
+						}
+						return true
+					}
+					if tv, ok := info.Types[n.Fun]; ok && tv.IsType() {
+						return true
+					}
+					for _, arg := range n.Args {
+						copied(arg)
+					}
+				case *ast.SelectorExpr:
+					// skip selectors of irrelevant fields
+					// use generic origin for fields
+					// as only origin is split
+					sel, ok := info.Selections[n]
+					// in case selector can't be resolved
+					// and it is named like any cold field
+					// it can't be rewritten so report it
+					if !ok {
+						if id, ok := n.X.(*ast.Ident); ok {
+							if _, ok := info.Uses[id].(*types.PkgName); ok {
+								return true
+							}
+						}
+						if snames, ok := cnames[n.Sel.Name]; ok {
+							fmt.Fprintf(
+								report,
+								"%s: selector %s can't be resolved and could access cold field of struct %s\n",
+								loc.Root().Position(n.Sel.Pos()),
+								n.Sel.Name,
+								strings.Join(snames, ", "),
+							)
+						}
+						return true
+					}
+					if sel.Kind() != types.FieldVal {
+						return true
+					}
+					if _, ok := fields[sel.Obj().(*types.Var).Origin()]; !ok {
+						return true
+					}
+					// otherwise select cold field
+					// through explicit embedded fields
+					// and companion pointer field
+					// so it can't be ambiguous
+					x, t := n.X, sel.Recv()
+					for _, i := range sel.Index()[:len(sel.Index())-1] {
+						if ptr, ok := t.Underlying().(*types.Pointer); ok {
+							t = ptr.Elem()
+						}
+						f := t.Underlying().(*types.Struct).Field(i)
+						x = &ast.SelectorExpr{X: x, Sel: &ast.Ident{Name: f.Name(), NamePos: n.Sel.Pos()}}
+						t = f.Type()
+					}
+					n.X = &ast.SelectorExpr{
+						X:   x,
+						Sel: &ast.Ident{Name: "cold", NamePos: n.Sel.Pos()},
+					}
+					touch(ctx, name)
+				case *ast.CompositeLit:
+					// literal elements are copied
+					// and omitted elements are zero values
+					for _, elt := range n.Elts {
+						if kv, ok := elt.(*ast.KeyValueExpr); ok {
+							elt = kv.Value
+						}
+						copied(elt)
+					}
+					omitted(n, info.Types[n].Type, zeroed)
+					// skip literals of irrelevant types
+					// use generic origin for named types
+					// as only origin is split
+					t := info.Types[n].Type
+					if named, ok := t.(*types.Named); ok {
+						t = named.Obj().Type()
+					}
+					s, ok := splits[t]
+					if !ok {
+						return true
+					}
+					// in case literal can't be rewritten
+					// it doesn't allocate companion struct
+					if !coldlit(n, s, t.Underlying().(*types.Struct)) {
+						fails = append(fails, fmt.Sprintf(
+							"%s: literal of struct %s can't be rewritten to allocate its cold fields",
+							loc.Root().Position(n.Pos()),
+							s.ts.Name.Name,
+						))
+						return true
+					}
+					touch(ctx, name)
+				}
+				return true
+			})
+		}
+		// in case any split struct value
+		// doesn't allocate companion struct
+		// its cold fields accesses would
+		// dereference nil pointer so fail
+		if len(fails) > 0 {
+			return nil, fmt.Errorf("structs can't be split, %s", strings.Join(fails, "; "))
+		}
+		// go through all files and move cold
+		// fields to companion structs
+		// in stable structs order
+		for _, name := range names {
+			file := pkg.Files[name]
+			tfile := loc.Root().File(file.Pos())
+			fsplits := make([]*coldsplit, 0, len(splits))
+			for _, s := range splits {
+				if loc.Root().File(s.ts.Pos()) == tfile {
+					fsplits = append(fsplits, s)
+				}
+			}
+			sort.SliceStable(fsplits, func(i, j int) bool {
+				return fsplits[i].ts.Pos() < fsplits[j].ts.Pos()
+			})
+			// companion structs are placed
+			// at the end of the file so they
+			// don't interfere with any comments
+			eof := token.Pos(tfile.Base() + tfile.Size())
+			for _, s := range fsplits {
+				file.Decls = append(file.Decls, coldspec(s, eof))
+				touch(ctx, name)
+			}
+		}
+		return pkg, nil
+	}
+}
+
+// splitholds helps to build resolver
+// of split struct held by provided type value
+// directly or inside arrays and structs fields
+func splitholds(splits map[types.Type]*coldsplit) func(types.Type) *coldsplit {
+	held := make(map[types.Type]*coldsplit)
+	var holds func(types.Type) *coldsplit
+	holds = func(t types.Type) *coldsplit {
+		if t == nil {
+			return nil
+		}
+		// use generic origin for named types
+		// as only origin is split
+		if named, ok := t.(*types.Named); ok {
+			if s, ok := splits[named.Obj().Type()]; ok {
+				return s
+			}
+		}
+		if s, ok := held[t]; ok {
+			return s
+		}
+		// mark type before going deeper
+		// to break recursive types cycles
+		held[t] = nil
+		var s *coldsplit
+		switch tt := t.Underlying().(type) {
+		case *types.Array:
+			s = holds(tt.Elem())
+		case *types.Struct:
+			for i := 0; i < tt.NumFields() && s == nil; i++ {
+				s = holds(tt.Field(i).Type())
+			}
+		}
+		held[t] = s
+		return s
+	}
+	return holds
+}
+
+// omitted helps to find literal elements
+// which are omitted and so zero valued
+// for struct literals and array literals
+func omitted(lit *ast.CompositeLit, t types.Type, zeroed func(token.Pos, types.Type)) {
+	if t == nil {
+		return
+	}
+	switch tt := t.Underlying().(type) {
+	case *types.Array:
+		if int64(len(lit.Elts)) < tt.Len() {
+			zeroed(lit.Pos(), tt.Elem())
+		}
+	case *types.Struct:
+		// unkeyed literals
+		// can't omit fields
+		keys := make(map[string]bool, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				return
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				keys[key.Name] = true
+			}
+		}
+		for i := 0; i < tt.NumFields(); i++ {
+			if f := tt.Field(i); f.Name() != "_" && !keys[f.Name()] {
+				zeroed(lit.Pos(), f.Type())
+			}
+		}
+	}
+}
+
+// callee helps to get
+// call function identifier
+func callee(fun ast.Expr) *ast.Ident {
+	id, _ := ast.Unparen(fun).(*ast.Ident)
+	return id
+}
+
+// hascold checks if original
+// struct has field named cold
+func hascold(ts *ast.TypeSpec) bool {
+	tts, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		return false
+	}
+	for _, f := range tts.Fields.List {
+		for _, name := range f.Names {
+			if name.Name == "cold" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coldtype helps to build companion
+// struct type expression that uses
+// provided type params or type args
+func coldtype(cname string, pos token.Pos, targs []ast.Expr) ast.Expr {
+	ident := &ast.Ident{Name: cname, NamePos: pos}
+	switch len(targs) {
+	case 0:
+		return ident
+	case 1:
+		return &ast.IndexExpr{X: ident, Lbrack: pos, Index: targs[0], Rbrack: pos}
+	default:
+		return &ast.IndexListExpr{X: ident, Lbrack: pos, Indices: targs, Rbrack: pos}
+	}
+}
+
+// coldlit helps to rewrite struct literal
+// by moving all cold fields values to
+// companion struct literal referenced
+// by companion pointer field value
+func coldlit(lit *ast.CompositeLit, s *coldsplit, tst *types.Struct) bool {
+	// resolve companion struct type args
+	// generic companion struct type args
+	// can't be resolved from elided literal type
+	var targs []ast.Expr
+	switch t := lit.Type.(type) {
+	case *ast.IndexExpr:
+		targs = []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		targs = t.Indices
+	default:
+		if s.ts.TypeParams != nil {
+			return false
+		}
+	}
+	// convert unkeyed literal to keyed literal
+	// skipping all blank fields values
+	if len(lit.Elts) > 0 {
+		if _, ok := lit.Elts[0].(*ast.KeyValueExpr); !ok {
+			if len(lit.Elts) != tst.NumFields() {
+				return false
+			}
+			elts := make([]ast.Expr, 0, len(lit.Elts))
+			for i, elt := range lit.Elts {
+				f := tst.Field(i)
+				if f.Name() == "_" {
+					continue
+				}
+				elts = append(elts, &ast.KeyValueExpr{
+					Key:   &ast.Ident{Name: f.Name(), NamePos: elt.Pos()},
+					Colon: elt.Pos(),
+					Value: elt,
+				})
+			}
+			lit.Elts = elts
+		}
+	}
+	// separate cold fields values
+	hot := make([]ast.Expr, 0, len(lit.Elts))
+	cold := make([]ast.Expr, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && s.cold[key.Name] {
+				cold = append(cold, elt)
+				continue
+			}
+		}
+		hot = append(hot, elt)
+	}
+	// always allocate companion struct
+	// so cold fields are accessible
+	pos, end := lit.Rbrace, lit.Rbrace
+	if len(cold) > 0 {
+		pos, end = cold[0].Pos(), cold[len(cold)-1].End()
+	}
+	lit.Elts = append(hot, &ast.KeyValueExpr{
+		Key:   &ast.Ident{Name: "cold", NamePos: pos},
+		Colon: pos,
+		Value: &ast.UnaryExpr{
+			OpPos: pos,
+			Op:    token.AND,
+			X: &ast.CompositeLit{
+				Type:   coldtype(s.cname, pos, targs),
+				Lbrace: pos,
+				Elts:   cold,
+				Rbrace: end,
+			},
+		},
+	})
+	return true
+}
+
+// coldspec helps to move struct cold fields
+// to generated companion struct decl
+// and replace them with companion pointer field
+func coldspec(s *coldsplit, pos token.Pos) *ast.GenDecl {
+	// separate cold fields names
+	// from hot fields names
+	tts := s.ts.Type.(*ast.StructType)
+	hot := make([]*ast.Field, 0, len(tts.Fields.List)+1)
+	cold := make([]*ast.Field, 0, len(tts.Fields.List))
+	for _, f := range tts.Fields.List {
+		hnames := make([]*ast.Ident, 0, len(f.Names))
+		cnames := make([]*ast.Ident, 0, len(f.Names))
+		for _, name := range f.Names {
+			if s.cold[name.Name] {
+				cnames = append(cnames, name)
+				continue
+			}
+			hnames = append(hnames, name)
+		}
+		// cold fields are reindexed
+		// so they are placed on
+		// consecutive lines
+		if len(cnames) > 0 {
+			for _, name := range cnames {
+				name.NamePos = token.Pos(len(cold))
+			}
+			cold = append(cold, &ast.Field{Names: cnames, Type: f.Type, Tag: f.Tag})
+		}
+		// embedded fields should
+		// be still collected
+		if len(f.Names) == 0 || len(hnames) > 0 {
+			if len(f.Names) > 0 {
+				f.Names = hnames
+			}
+			hot = append(hot, f)
+		}
+	}
+	// build companion struct type params
+	// place companion pointer field
+	// instead of the first cold field
+	cpos := tts.Fields.Closing
+	if len(cold) > 0 {
+		cpos = cold[0].Type.Pos()
+	}
+	var targs []ast.Expr
+	if s.ts.TypeParams != nil {
+		for _, f := range s.ts.TypeParams.List {
+			for _, name := range f.Names {
+				targs = append(targs, &ast.Ident{Name: name.Name, NamePos: cpos})
+			}
+		}
+	}
+	tts.Fields.List = append(hot, &ast.Field{
+		Names: []*ast.Ident{{Name: "cold", NamePos: cpos}},
+		Type:  &ast.StarExpr{Star: cpos, X: coldtype(s.cname, cpos, targs)},
+	})
+	// companion struct doc is used only to
+	// separate companion struct decl by empty line
+	// as it is pressed later by note helper
+	// and companion struct opening brace
+	// position is skipped so the struct
+	// is never collapsed to single line
+	return &ast.GenDecl{
+		Doc: &ast.CommentGroup{List: []*ast.Comment{
+			{Text: colddoc(s.cname, s.ts.Name.Name)},
+		}},
+		Tok:    token.TYPE,
+		TokPos: pos,
+		Specs: []ast.Spec{&ast.TypeSpec{
+			Name:       &ast.Ident{Name: s.cname, NamePos: pos},
+			TypeParams: s.ts.TypeParams,
+			Type: &ast.StructType{
+				Struct: pos,
+				Fields: &ast.FieldList{List: cold, Closing: pos},
+			},
+		}},
+	}
+}
+
+// colddoc builds companion struct doc
+func colddoc(cname string, name string) string {
+	return fmt.Sprintf("// %s defines cold fields of %s; - %s", cname, name, gopium.STAMP)
+}
+
+// coldnote helps to press docs of generated
+// companion structs of provided structs
+// that have no docs into ast file
+func coldnote(file *ast.File, sts map[string]gopium.Struct) {
+	// collect companion structs names
+	cnames := make(map[string]string, len(sts))
+	for _, st := range sts {
+		if cname, ok := collections.ColdName(st); ok {
+			cnames[cname] = st.Name
+		}
+	}
+	// go through all file type decls
+	for _, decl := range file.Decls {
+		gdecl, ok := decl.(*ast.GenDecl)
+		if !ok || gdecl.Tok != token.TYPE || gdecl.Doc != nil || len(gdecl.Specs) != 1 {
+			continue
+		}
+		ts := gdecl.Specs[0].(*ast.TypeSpec)
+		name, ok := cnames[ts.Name.Name]
+		if !ok {
+			continue
+		}
+		// doc position is position of name - len of `type` keyword
+		file.Comments = append(file.Comments, &ast.CommentGroup{List: []*ast.Comment{
+			{Slash: ts.Name.Pos() - token.Pos(6), Text: colddoc(ts.Name.Name, name)},
+		}})
+	}
+}
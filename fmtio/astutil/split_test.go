@@ -0,0 +1,342 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestSplit(t *testing.T) {
+	// prepare
+	h := collections.NewHierarchic(tests.Gopium)
+	h.Push(
+		"tests_data_split_file-1.go:6",
+		filepath.Join(tests.Gopium, "tests", "data", "split", "file-1.go"),
+		gopium.Struct{
+			Name: "Split",
+			Fields: []gopium.Field{
+				{Name: "id", Type: "int64"},
+				{Name: "hits", Type: "int64"},
+				{Name: "Stats", Type: "Stats", Exported: true, Embedded: true},
+				collections.ColdField("Split", 8, "name", "notes"),
+			},
+		},
+	)
+	h.Push(
+		"tests_data_split_file-1.go:19",
+		filepath.Join(tests.Gopium, "tests", "data", "split", "file-1.go"),
+		gopium.Struct{
+			Name: "Stats",
+			Fields: []gopium.Field{
+				{Name: "Min", Type: "int64", Exported: true},
+				{Name: "Count", Type: "int64", Exported: true},
+				collections.ColdField("Stats", 8, "Max"),
+			},
+		},
+	)
+	h.Push(
+		"tests_data_split_file-1.go:24",
+		filepath.Join(tests.Gopium, "tests", "data", "split", "file-1.go"),
+		gopium.Struct{
+			Name: "Pair",
+			Fields: []gopium.Field{
+				{Name: "key", Type: "K"},
+				{Name: "val", Type: "V"},
+				collections.ColdField("Pair[string, int]", 8, "meta"),
+			},
+		},
+	)
+	hz := collections.NewHierarchic(tests.Gopium)
+	hz.Push(
+		"tests_data_splitzero_file.go:7",
+		filepath.Join(tests.Gopium, "tests", "data", "splitzero", "file.go"),
+		gopium.Struct{
+			Name: "Zero",
+			Fields: []gopium.Field{
+				{Name: "id", Type: "int64"},
+				collections.ColdField("Zero", 8, "name"),
+			},
+		},
+	)
+	hz.Push(
+		"tests_data_splitzero_file.go:14",
+		filepath.Join(tests.Gopium, "tests", "data", "splitzero", "file.go"),
+		gopium.Struct{
+			Name: "Pair",
+			Fields: []gopium.Field{
+				{Name: "key", Type: "K"},
+				collections.ColdField("Pair", 8, "meta"),
+			},
+		},
+	)
+	ha := collections.NewHierarchic(tests.Gopium)
+	ha.Push(
+		"tests_data_anonymous_file.go:21:14",
+		filepath.Join(tests.Gopium, "tests", "data", "anonymous", "file.go"),
+		gopium.Struct{
+			Name: "rows",
+			Fields: []gopium.Field{
+				{Name: "ok", Type: "bool"},
+				collections.ColdField("rows", 8, "value", "done"),
+			},
+		},
+	)
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		p   gopium.Parser
+		ctx context.Context
+		h   collections.Hierarchic
+		r   map[string][]byte
+		rep string
+		err error
+	}{
+		"empty pkg should apply nothing": {
+			p:   data.NewParser("empty"),
+			ctx: context.Background(),
+			r:   map[string][]byte{},
+		},
+		"single struct pkg should apply nothing on unchanged struct": {
+			p:   data.NewParser("single"),
+			ctx: context.Background(),
+			r:   map[string][]byte{},
+		},
+		"split struct pkg should apply expected structs": {
+			p:   data.NewParser("split"),
+			ctx: context.Background(),
+			h:   h,
+			r: map[string][]byte{
+				"tests_data_split_file-1.go": []byte(`
+//go:build tests_data
+
+package split
+
+// Split defines split struct
+type Split struct {
+	id int64
+
+	hits int64 // hits comment
+
+	Stats
+	cold *SplitCold
+} // split comment
+
+func (s *Split) hit() {
+	s.hits++
+	s.cold.notes = append(s.cold.notes, s.cold.name)
+}
+
+type Stats struct {
+	Min   int64
+	Count int64
+	cold  *StatsCold
+}
+
+type Pair[K comparable, V any] struct {
+	key  K
+	val  V
+	cold *PairCold[K, V]
+}
+
+var p = Pair[string, int]{key: "k", val: 1, cold: &PairCold[string, int]{meta: "m"}}
+
+type SplitCold struct {
+	name  string   ` + "`" + `gopium:"group:cold;memory_pack"` + "`" + `
+	notes []string ` + "`" + `gopium:"group:cold;memory_pack"` + "`" + `
+}
+
+type StatsCold struct {
+	Max int64
+}
+
+type PairCold[K comparable, V any] struct {
+	meta string
+}
+`),
+				"tests_data_split_file-2.go": []byte(`
+//go:build tests_data
+
+package split
+
+var s = Split{id: 1, hits: 0, Stats: Stats{cold: &StatsCold{}}, cold: &SplitCold{name: "s", notes: nil}}
+
+func names(ss []*Split) []string {
+	names := make([]string, 0, len(ss))
+	for _, s := range ss {
+		names = append(names, s.cold.name)
+	}
+	return names
+}
+
+var st = Stats{Min: 1, Count: 3, cold: &StatsCold{Max: 2}}
+
+func max(s Split) int64 {
+	return s.Stats.cold.Max + s.Stats.cold.Max + s.Min
+}
+`),
+			},
+			rep: `
+tests/data/split/file-1.go:20:7: accesses to cold field Max of struct Stats in other packages can't be rewritten
+tests/data/split/file-3.go:10:7: copy of struct Split shares its cold fields
+tests/data/split/file-3.go:11:9: copy of struct Split shares its cold fields
+tests/data/split/file-3.go:15:29: selector name can't be resolved and could access cold field of struct Split
+`,
+		},
+		"split struct pkg with zero values should apply nothing": {
+			p:   data.NewParser("splitzero"),
+			ctx: context.Background(),
+			h:   hz,
+			r:   map[string][]byte{},
+			err: errors.New("structs can't be split, " + strings.Join([]string{
+				"tests/data/splitzero/file.go:19:30: literal of struct Pair can't be rewritten to allocate its cold fields",
+				"tests/data/splitzero/file.go:22:6: zero value of struct Zero doesn't allocate its cold fields",
+				"tests/data/splitzero/file.go:23:7: zero value of struct Zero doesn't allocate its cold fields",
+				"tests/data/splitzero/file.go:25:27: zero value of struct Zero doesn't allocate its cold fields",
+				"tests/data/splitzero/file.go:25:41: zero value of struct Zero doesn't allocate its cold fields",
+			}, "; ")),
+		},
+		"anonymous struct pkg should apply nothing": {
+			p:   data.NewParser("anonymous"),
+			ctx: context.Background(),
+			h:   ha,
+			r:   map[string][]byte{},
+			err: errors.New(`anonymous struct "rows" can't be split`),
+		},
+		"split struct pkg should apply nothing on canceled context": {
+			p:   data.NewParser("split"),
+			ctx: cctx,
+			h:   h,
+			r:   map[string][]byte{},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			w := &mocks.Writer{}
+			var rep bytes.Buffer
+			pkg, loc, err := tcase.p.ParseAst(context.Background())
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			// exec
+			pkg, err = combine(split(walk, &rep), unkeyed(walk, &rep))(tcase.ctx, pkg, loc, tcase.h)
+			if strings.ReplaceAll(fmt.Sprintf("%v", err), tests.Gopium+"/", "") != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// prepare
+			if pkg != nil {
+				err = Package{}.Persist(context.Background(), fmtio.Gofmt{}, data.Writer{Writer: w}, loc, pkg)
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+			}
+			// check
+			actual := strings.Trim(strings.ReplaceAll(rep.String(), tests.Gopium+"/", ""), "\n")
+			expected := strings.Trim(tcase.rep, "\n")
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("actual %v doesn't equal to expected %v", actual, expected)
+			}
+			for name, rwc := range w.RWCs {
+				// check all struct
+				// against bytes map
+				if st, ok := tcase.r[name]; ok {
+					// read rwc to buffer
+					var buf bytes.Buffer
+					_, err := buf.ReadFrom(rwc)
+					if !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					// format actual and expected identically
+					actual := strings.Trim(buf.String(), "\n")
+					expected := strings.Trim(string(st), "\n")
+					if !reflect.DeepEqual(actual, expected) {
+						t.Errorf("name %v actual %v doesn't equal to expected %v", name, actual, expected)
+					}
+					delete(tcase.r, name)
+				} else {
+					t.Errorf("actual %v doesn't equal to expected %v", name, "")
+				}
+			}
+			// check that map has been drained
+			if !reflect.DeepEqual(tcase.r, map[string][]byte{}) {
+				t.Errorf("actual %v doesn't equal to expected %v", tcase.r, map[string][]byte{})
+			}
+		})
+	}
+}
+
+func TestColdnote(t *testing.T) {
+	// prepare
+	src := `
+package split
+
+type A struct {
+	a    bool
+	cold *ACold
+}
+
+type ACold struct {
+	b bool
+}
+
+// BCold doc
+type BCold struct {
+	c bool
+}
+`
+	table := map[string]struct {
+		sts map[string]gopium.Struct
+		r   []string
+	}{
+		"structs without cold field should note nothing": {
+			sts: map[string]gopium.Struct{
+				"A": {Name: "A", Fields: []gopium.Field{{Name: "a", Type: "bool"}}},
+			},
+			r: []string{"// BCold doc"},
+		},
+		"structs with cold field should note only companion structs without docs": {
+			sts: map[string]gopium.Struct{
+				"A": {Name: "A", Fields: []gopium.Field{collections.ColdField("A", 8, "b")}},
+				"B": {Name: "B", Fields: []gopium.Field{collections.ColdField("B", 8, "c")}},
+			},
+			r: []string{
+				"// BCold doc",
+				"// ACold defines cold fields of A; - 🌺 gopium @1pkg",
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			// exec
+			coldnote(file, tcase.sts)
+			// check
+			r := make([]string, 0, len(file.Comments))
+			for _, com := range file.Comments {
+				r = append(r, com.List[0].Text)
+			}
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package astutil
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// unkeyed helps to rewrite all unkeyed composite
+// literals of result structs to keyed literals
+// so they are kept valid after fields reordering
+//
+// it resolves literals types by type checking
+// ast package, keeps only files that either
+// contain result structs or rewritten literals
+// or files touched by previous ast helpers
+// and reports literals that can't be fixed
+// including literals inside other packages
+func unkeyed(w gopium.Walk, report io.Writer) gopium.Apply {
+	//nolint
+	return func(
+		ctx context.Context,
+		pkg *ast.Package,
+		loc gopium.Locator,
+		c gopium.Categorized,
+	) (*ast.Package, error) {
+		// collect all result structs type specs
+		tc := &tcollect{tss: make(map[*ast.TypeSpec]gopium.Struct)}
+		if _, err := w(
+			ctx,
+			pkg,
+			tc,
+			&flatid{loc: loc, sts: collections.Flat(c.Full())},
+		); err != nil {
+			return nil, err
+		}
+		// type check ast package files
+		names, _, info := tcheck(pkg, loc.Root())
+		// collect all result structs whose
+		// fields list doesn't match original one
+		// named structs are keyed by their types
+		// and anonymous structs by their struct types
+		tss := make(map[types.Type]*ast.TypeSpec, len(tc.tss))
+		for ts, st := range tc.tss {
+			if synthetic(ts) {
+				tst, ok := info.Types[ts.Type].Type.(*types.Struct)
+				if ok && reordered(tst, st) {
+					tss[tst] = ts
+				}
+				continue
+			}
+			tn, ok := info.Defs[ts.Name].(*types.TypeName)
+			if !ok {
+				continue
+			}
+			tst, ok := tn.Type().Underlying().(*types.Struct)
+			if !ok || !reordered(tst, st) {
+				continue
+			}
+			tss[tn.Type()] = ts
+			// in case struct could be used with unkeyed
+			// literals in other packages report it
+			if exported(tn, tst) {
+				fmt.Fprintf(
+					report,
+					"%s: unkeyed literals of struct %s in other packages can't be fixed\n",
+					loc.Root().Position(ts.Pos()),
+					ts.Name.Name,
+				)
+			}
+		}
+		// go through all files
+		// and rewrite unkeyed literals
+		touched := make(map[string]bool, len(names))
+		for _, name := range names {
+			// manage context actions
+			// in case of cancelation
+			// stop execution
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			ast.Inspect(pkg.Files[name], func(node ast.Node) bool {
+				// skip empty and keyed literals
+				lit, ok := node.(*ast.CompositeLit)
+				if !ok || len(lit.Elts) == 0 {
+					return true
+				}
+				if _, ok := lit.Elts[0].(*ast.KeyValueExpr); ok {
+					return true
+				}
+				// skip literals of irrelevant types
+				// use generic origin for named types
+				// as only origin is visited
+				t := info.Types[lit].Type
+				if named, ok := t.(*types.Named); ok {
+					t = named.Obj().Type()
+				}
+				ts, ok := tss[t]
+				if !ok {
+					return true
+				}
+				tst := t.Underlying().(*types.Struct)
+				// in case literal is malformed
+				// it can't be fixed so report it
+				if len(lit.Elts) != tst.NumFields() {
+					fmt.Fprintf(
+						report,
+						"%s: unkeyed literal of struct %s can't be fixed\n",
+						loc.Root().Position(lit.Pos()),
+						ts.Name.Name,
+					)
+					return true
+				}
+				// otherwise convert it to keyed literal
+				// skipping all blank fields values
+				elts := make([]ast.Expr, 0, len(lit.Elts))
+				for i, elt := range lit.Elts {
+					f := tst.Field(i)
+					if f.Name() == "_" {
+						continue
+					}
+					elts = append(elts, &ast.KeyValueExpr{
+						Key:   &ast.Ident{Name: f.Name(), NamePos: elt.Pos()},
+						Colon: elt.Pos(),
+						Value: elt,
+					})
+				}
+				lit.Elts = elts
+				touched[name] = true
+				return true
+			})
+		}
+		// filter all files that
+		// could be skipped
+		rfiles := make(map[string]*ast.File, len(pkg.Files))
+		for name, file := range pkg.Files {
+			if _, ok := c.Cat(name); ok || touched[name] || touches(ctx, name) {
+				rfiles[name] = file
+			}
+		}
+		pkg.Files = rfiles
+		return pkg, nil
+	}
+}
+
+// tcheck helps to type check ast package files
+// in stable files names order and returns
+// sorted files names with type checked package info
+func tcheck(pkg *ast.Package, fset *token.FileSet) ([]string, *types.Package, *types.Info) {
+	names := make([]string, 0, len(pkg.Files))
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	files := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		files = append(files, pkg.Files[name])
+	}
+	info := &types.Info{
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	// we only need to resolve local package types
+	// so imports are skipped and all type check
+	// errors are ignored on purpose
+	cfg := types.Config{Importer: skipimporter{}, Error: func(error) {}}
+	tpkg, _ := cfg.Check(pkg.Name, fset, files, info)
+	return names, tpkg, info
+}
+
+// reordered checks if original struct fields
+// list differs from result struct fields list
+// note: structs with pads are always considered
+// reordered as pads sizes could be changed
+func reordered(tst *types.Struct, st gopium.Struct) bool {
+	if tst.NumFields() != len(st.Fields) {
+		return true
+	}
+	for i, f := range st.Fields {
+		if name := tst.Field(i).Name(); name != f.Name || name == "_" {
+			return true
+		}
+	}
+	return false
+}
+
+// exported checks if struct could be used
+// with unkeyed literals in other packages
+func exported(tn *types.TypeName, tst *types.Struct) bool {
+	// local and unexported structs
+	// can't be used in other packages
+	if !tn.Exported() || tn.Parent() != tn.Pkg().Scope() {
+		return false
+	}
+	// unkeyed literals can't be used in
+	// other packages with unexported fields
+	for i := 0; i < tst.NumFields(); i++ {
+		if !tst.Field(i).Exported() {
+			return false
+		}
+	}
+	return true
+}
+
+// skipimporter defines types importer
+// implementation that skips all imports
+// by returning empty complete packages
+type skipimporter struct{} // struct size: 0 bytes; struct align: 1 bytes; struct aligned size: 0 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Import skipimporter implementation
+func (skipimporter) Import(path string) (*types.Package, error) {
+	pkg := types.NewPackage(path, path)
+	pkg.MarkComplete()
+	return pkg, nil
+}
@@ -0,0 +1,286 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestUnkeyed(t *testing.T) {
+	// prepare
+	h := collections.NewHierarchic(tests.Gopium)
+	h.Push(
+		"tests_data_unkeyed_file-1.go:5",
+		filepath.Join(tests.Gopium, "tests", "data", "unkeyed", "file-1.go"),
+		gopium.Struct{
+			Name: "A",
+			Fields: []gopium.Field{
+				{Name: "b", Type: "int64"},
+				{Name: "a", Type: "bool"},
+				{Name: "c", Type: "bool"},
+			},
+		},
+	)
+	h.Push(
+		"tests_data_unkeyed_file-1.go:11",
+		filepath.Join(tests.Gopium, "tests", "data", "unkeyed", "file-1.go"),
+		gopium.Struct{
+			Name: "B",
+			Fields: []gopium.Field{
+				{Name: "A", Type: "A", Embedded: true},
+				{Name: "d", Type: "string"},
+			},
+		},
+	)
+	h.Push(
+		"tests_data_unkeyed_file-1.go:19",
+		filepath.Join(tests.Gopium, "tests", "data", "unkeyed", "file-1.go"),
+		gopium.Struct{
+			Name: "Exported",
+			Fields: []gopium.Field{
+				{Name: "Y", Type: "int64", Exported: true},
+				{Name: "X", Type: "bool", Exported: true},
+				{Name: "Z", Type: "bool", Exported: true},
+			},
+		},
+	)
+	h.Push(
+		"tests_data_unkeyed_file-2.go:12",
+		filepath.Join(tests.Gopium, "tests", "data", "unkeyed", "file-2.go"),
+		gopium.Struct{
+			Name: "C",
+			Fields: []gopium.Field{
+				{Name: "e", Type: "[]string"},
+				{Name: "d", Type: "bool"},
+				{Name: "f", Type: "bool"},
+			},
+		},
+	)
+	ha := collections.NewHierarchic(tests.Gopium)
+	ha.Push(
+		"tests_data_anonymous_file.go:21:14",
+		filepath.Join(tests.Gopium, "tests", "data", "anonymous", "file.go"),
+		gopium.Struct{
+			Name: "rows",
+			Fields: []gopium.Field{
+				{Name: "value", Type: "float64"},
+				{Name: "ok", Type: "bool"},
+				{Name: "done", Type: "bool"},
+			},
+		},
+	)
+	ha.Push(
+		"tests_data_anonymous_file.go:37:11",
+		filepath.Join(tests.Gopium, "tests", "data", "anonymous", "file.go"),
+		gopium.Struct{
+			Name: "scope.local",
+			Fields: []gopium.Field{
+				{Name: "b", Type: "int64"},
+				{Name: "a", Type: "int8"},
+				{Name: "c", Type: "int8"},
+			},
+		},
+	)
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		p   gopium.Parser
+		ctx context.Context
+		h   collections.Hierarchic
+		r   map[string][]byte
+		rep string
+		err error
+	}{
+		"empty pkg should apply nothing": {
+			p:   data.NewParser("empty"),
+			ctx: context.Background(),
+			r:   map[string][]byte{},
+		},
+		"single struct pkg should apply nothing on unchanged struct": {
+			p:   data.NewParser("single"),
+			ctx: context.Background(),
+			r:   map[string][]byte{},
+		},
+		"unkeyed struct pkg should apply expected literals": {
+			p:   data.NewParser("unkeyed"),
+			ctx: context.Background(),
+			h:   h,
+			r: map[string][]byte{
+				"tests_data_unkeyed_file-1.go": []byte(`
+//go:build tests_data
+
+package unkeyed
+
+type A struct {
+	a bool
+	b int64
+	c bool
+}
+
+type B struct {
+	A
+	_ [8]byte
+	d string
+}
+
+var a = A{a: true, b: 10, c: false}
+
+type Exported struct {
+	X bool
+	Y int64
+	Z bool
+}
+
+var e = Exported{X: true, Y: 20, Z: true}
+`),
+				"tests_data_unkeyed_file-2.go": []byte(`
+//go:build tests_data
+
+package unkeyed
+
+import "strings"
+
+var as = []A{{a: true, b: 1, c: false}, {a: true}, {}}
+
+var pb = &B{A: A{a: false, b: 2, c: true}, d: strings.ToUpper("b")}
+
+func local() interface{} {
+	type C struct {
+		d bool
+		e []string
+		f bool
+	}
+	return map[string]C{"c": {d: true, e: nil, f: false}}
+}
+`),
+			},
+			rep: `
+tests/data/unkeyed/file-1.go:19:6: unkeyed literals of struct Exported in other packages can't be fixed
+`,
+		},
+		"anonymous struct pkg should apply expected literals": {
+			p:   data.NewParser("anonymous"),
+			ctx: context.Background(),
+			h:   ha,
+			r: map[string][]byte{
+				"tests_data_anonymous_file.go": []byte(`
+//go:build tests_data
+
+package anonymous
+
+type Config struct {
+	name  string
+	inner struct {
+		a bool
+		b int64
+		c bool
+	}
+	flag bool
+}
+
+var cfg struct {
+	x int8
+	y []string
+	z int8
+}
+
+var rows = []struct {
+	ok    bool
+	value float64
+	done  bool
+}{
+	{ok: true, value: 1.5, done: false},
+	{ok: false, value: 2.5, done: true},
+}
+
+var index map[string]struct {
+	id   int32
+	ptr  *Config
+	seen bool
+}
+
+func scope() int64 {
+	local := struct {
+		a int8
+		b int64
+		c int8
+	}{a: 1, b: 2, c: 3}
+	return local.b
+}
+`),
+			},
+		},
+		"unkeyed struct pkg should apply nothing on canceled context": {
+			p:   data.NewParser("unkeyed"),
+			ctx: cctx,
+			h:   h,
+			r:   map[string][]byte{},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			w := &mocks.Writer{}
+			var rep bytes.Buffer
+			pkg, loc, err := tcase.p.ParseAst(context.Background())
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			// exec
+			pkg, err = unkeyed(walk, &rep)(tcase.ctx, pkg, loc, tcase.h)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// prepare
+			if pkg != nil {
+				err = Package{}.Persist(context.Background(), fmtio.Gofmt{}, data.Writer{Writer: w}, loc, pkg)
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+			}
+			// check
+			actual := strings.Trim(strings.ReplaceAll(rep.String(), tests.Gopium+"/", ""), "\n")
+			expected := strings.Trim(tcase.rep, "\n")
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("actual %v doesn't equal to expected %v", actual, expected)
+			}
+			for name, rwc := range w.RWCs {
+				// check all struct
+				// against bytes map
+				if st, ok := tcase.r[name]; ok {
+					// read rwc to buffer
+					var buf bytes.Buffer
+					_, err := buf.ReadFrom(rwc)
+					if !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					// format actual and expected identically
+					actual := strings.Trim(buf.String(), "\n")
+					expected := strings.Trim(string(st), "\n")
+					if !reflect.DeepEqual(actual, expected) {
+						t.Errorf("name %v actual %v doesn't equal to expected %v", name, actual, expected)
+					}
+					delete(tcase.r, name)
+				} else {
+					t.Errorf("actual %v doesn't equal to expected %v", name, "")
+				}
+			}
+			// check that map has been drained
+			if !reflect.DeepEqual(tcase.r, map[string][]byte{}) {
+				t.Errorf("actual %v doesn't equal to expected %v", tcase.r, map[string][]byte{})
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/typepkg"
 
 	"golang.org/x/tools/go/ast/astutil"
 )
@@ -17,6 +18,8 @@ import (
 // that walks through ast struct type
 // nodes with comparator function and
 // apply some custom action on them
+// anonymous struct types are walked
+// through their synthetic type specs
 func walk(
 	ctx context.Context,
 	node ast.Node,
@@ -25,48 +28,86 @@ func walk(
 ) (ast.Node, error) {
 	// err tracks error inside astutil apply
 	var err error
+	// path keeps all ast ancestors
+	// of currently walked node
+	var path []ast.Node
 	// apply astutil apply to parsed ast package
 	// and update structure in ast with action
 	return astutil.Apply(node, func(c *astutil.Cursor) bool {
-		if gendecl, ok := c.Node().(*ast.GenDecl); ok {
-			for _, spec := range gendecl.Specs {
-				if ts, ok := spec.(*ast.TypeSpec); ok {
-					// manage context actions
-					// in case of cancelation
-					// stop execution
-					select {
-					case <-ctx.Done():
-						err = ctx.Err()
-						return false
-					default:
-					}
-					// there are several scenarios for walk
-					switch ts.Type.(type) {
-					// in case of real structure process it
-					case *ast.StructType:
-						// check that structure
-						// should be visited
-						// and skip irrelevant structs
-						if st, ok := cmp.Check(ts); ok {
-							// visit ast node
-							err = v.Visit(ts, st)
-						}
-					// in case of structure under
-					// secelector or type ident
-					// just do check on comparator
-					case *ast.SelectorExpr:
-						_, _ = cmp.Check(ts)
-					case *ast.Ident:
-						_, _ = cmp.Check(ts)
-					}
-					// in case we have error
-					// break iteration
-					return err != nil
+		switch n := c.Node().(type) {
+		case *ast.TypeSpec:
+			// manage context actions
+			// in case of cancelation
+			// stop execution
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+				return false
+			default:
+			}
+			// there are several scenarios for walk
+			switch n.Type.(type) {
+			// in case of real structure process it
+			case *ast.StructType:
+				// check that structure
+				// should be visited
+				// and skip irrelevant structs
+				if st, ok := cmp.Check(n); ok {
+					// visit ast node
+					err = v.Visit(n, st)
+				}
+			// in case of structure under
+			// secelector or type ident
+			// just do check on comparator
+			case *ast.SelectorExpr:
+				_, _ = cmp.Check(n)
+			case *ast.Ident:
+				_, _ = cmp.Check(n)
+			}
+		case *ast.StructType:
+			// in case of anonymous structure
+			// process its synthetic type spec
+			if ts, ok := typepkg.AnonymousSpec(path, n); ok {
+				// manage context actions
+				// in case of cancelation
+				// stop execution
+				select {
+				case <-ctx.Done():
+					err = ctx.Err()
+					return false
+				default:
+				}
+				// check that structure
+				// should be visited
+				// and skip irrelevant structs
+				if st, ok := cmp.Check(ts); ok {
+					// visit ast node
+					err = v.Visit(ts, st)
 				}
 			}
 		}
+		// in case we have error
+		// break iteration
+		if err != nil {
+			return false
+		}
+		// otherwise go deeper
+		path = append(path, c.Node())
+		return true
+	}, func(c *astutil.Cursor) bool {
+		// pop node from path
+		// after its children are walked
+		path = path[:len(path)-1]
 		return true
-	}, nil), err
+	}), err
+}
+
+// synthetic checks if provided type spec
+// is synthetic anonymous struct type spec
+// which shares the same position for
+// its name and struct keyword
+func synthetic(ts *ast.TypeSpec) bool {
+	return ts.Name.Pos() == ts.Type.Pos()
 }
 
 // fmtioast defines gopium ast walk
@@ -87,16 +128,34 @@ type bcollect struct {
 
 // Visit bcollect implementation
 func (b *bcollect) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
+	// start position of name - len of `type` keyword
+	// or struct keyword for anonymous structs
+	first := ts.Name.Pos() - token.Pos(6)
+	if synthetic(ts) {
+		first = ts.Type.Pos()
+	}
 	// collect structs boundaries
 	b.bs = append(b.bs, collections.Boundary{
-		// start position of name - len of `type` keyword
-		First: ts.Name.Pos() - token.Pos(6),
+		First: first,
 		// end position of type decl
 		Last: ts.Type.End(),
 	})
 	return nil
 }
 
+// tcollect defines gopium ast walk
+// action type specs collector implementation
+type tcollect struct {
+	tss map[*ast.TypeSpec]gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 8 bytes; struct align: 8 bytes; struct aligned size: 8 bytes; struct ptr scan size: 8 bytes; - 🌺 gopium @1pkg
+
+// Visit tcollect implementation
+func (t *tcollect) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
+	// collect structs type specs
+	t.tss[ts] = st
+	return nil
+}
+
 // pressnote defines gopium ast walk
 // action press doc to file implementation
 // which presses comments from
@@ -108,7 +167,8 @@ func (pdc *pressnote) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
 	// prepare struct docs slice
 	file := ((*ast.File)(pdc))
 	// if it has at least one doc
-	if len(st.Doc) >= 1 {
+	// note: anonymous structs can't have docs
+	if len(st.Doc) >= 1 && !synthetic(ts) {
 		// doc position is position of name - len of `type` keyword
 		slash := ts.Name.Pos() - token.Pos(6)
 		// collect all docs from resulted structure
@@ -121,7 +181,12 @@ func (pdc *pressnote) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
 	// if it has at least one comment
 	if len(st.Comment) >= 1 {
 		// comment position is end of type decl
+		// or opening brace for anonymous structs
+		// as they could be followed by literals
 		slash := ts.Type.End()
+		if synthetic(ts) {
+			slash = ts.Type.(*ast.StructType).Fields.Opening + token.Pos(1)
+		}
 		// collect all comments from resulted structure
 		com := fmt.Sprintf("//%s", strings.ReplaceAll(strings.Join(st.Comment, ""), "//", ""))
 		// update file comments list
@@ -172,7 +237,11 @@ type flatid struct {
 func (cmp flatid) Check(ts *ast.TypeSpec) (gopium.Struct, bool) {
 	// just check if struct
 	// with such id is inside
+	// anonymous structs ids are column based
 	id := cmp.loc.ID(ts.Pos())
+	if synthetic(ts) {
+		id = typepkg.AnonymousID(cmp.loc, ts.Pos())
+	}
 	st, ok := cmp.sts[id]
 	return st, ok
 }
@@ -49,6 +49,8 @@ func Csvb(rw io.ReadWriter) gopium.Bytes {
 			"Field Size",
 			"Field Align",
 			"Field Ptr",
+			"Field Offset",
+			"Field Pad",
 			"Field Tag",
 			"Field Exported",
 			"Field Embedded",
@@ -71,6 +73,8 @@ func Csvb(rw io.ReadWriter) gopium.Bytes {
 					strconv.Itoa(int(f.Size)),
 					strconv.Itoa(int(f.Align)),
 					strconv.Itoa(int(f.Ptr)),
+					strconv.Itoa(int(f.Offset)),
+					strconv.Itoa(int(f.Pad)),
 					f.Tag,
 					strconv.FormatBool(f.Exported),
 					strconv.FormatBool(f.Embedded),
@@ -101,8 +105,8 @@ func Mdtb(sts []gopium.Struct) ([]byte, error) {
 		// no error should be
 		// checked as it uses
 		// buffered writer
-		_, _ = buf.WriteString("| Struct Name | Struct Doc | Struct Comment | Field Name | Field Type | Field Size | Field Align | Field Ptr | Field Tag | Field Exported | Field Embedded | Field Doc | Field Comment |\n")
-		_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |\n")
+		_, _ = buf.WriteString("| Struct Name | Struct Doc | Struct Comment | Field Name | Field Type | Field Size | Field Align | Field Ptr | Field Offset | Field Pad | Field Tag | Field Exported | Field Embedded | Field Doc | Field Comment |\n")
+		_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |\n")
 		for _, st := range sts {
 			// go through all fields
 			// and write then one by one
@@ -111,7 +115,7 @@ func Mdtb(sts []gopium.Struct) ([]byte, error) {
 				// checked as it uses
 				// buffered writer
 				_, _ = buf.WriteString(
-					fmt.Sprintf("| %s | %s | %s | %s | %s | %d | %d | %d | %s | %s | %s | %s | %s |\n",
+					fmt.Sprintf("| %s | %s | %s | %s | %s | %d | %d | %d | %d | %d | %s | %s | %s | %s | %s |\n",
 						st.Name,
 						strings.Join(st.Doc, " "),
 						strings.Join(st.Comment, " "),
@@ -120,6 +124,8 @@ func Mdtb(sts []gopium.Struct) ([]byte, error) {
 						f.Size,
 						f.Align,
 						f.Ptr,
+						f.Offset,
+						f.Pad,
 						f.Tag,
 						strconv.FormatBool(f.Exported),
 						strconv.FormatBool(f.Embedded),
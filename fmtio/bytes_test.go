@@ -60,11 +60,12 @@ func TestBytes(t *testing.T) {
 							Comment:  []string{"fcomtest"},
 						},
 						{
-							Name:  "test-2",
-							Type:  "test_type",
-							Size:  12,
-							Align: 4,
-							Ptr:   4,
+							Name:   "test-2",
+							Type:   "test_type",
+							Size:   12,
+							Align:  4,
+							Ptr:    4,
+							Offset: 16,
 						},
 					},
 				},
@@ -94,6 +95,8 @@ func TestBytes(t *testing.T) {
 				"Size": 1,
 				"Align": 1,
 				"Ptr": 1,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -117,6 +120,8 @@ func TestBytes(t *testing.T) {
 				"Size": 16,
 				"Align": 8,
 				"Ptr": 8,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "test-tag",
 				"Exported": true,
 				"Embedded": true,
@@ -133,6 +138,8 @@ func TestBytes(t *testing.T) {
 				"Size": 12,
 				"Align": 4,
 				"Ptr": 4,
+				"Offset": 16,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -179,11 +186,12 @@ func TestBytes(t *testing.T) {
 							Comment:  []string{"fcomtest"},
 						},
 						{
-							Name:  "test-2",
-							Type:  "test_type",
-							Size:  12,
-							Align: 4,
-							Ptr:   4,
+							Name:   "test-2",
+							Type:   "test_type",
+							Size:   12,
+							Align:  4,
+							Ptr:    4,
+							Offset: 16,
 						},
 					},
 				},
@@ -209,6 +217,8 @@ func TestBytes(t *testing.T) {
 		<Size>1</Size>
 		<Align>1</Align>
 		<Ptr>1</Ptr>
+		<Offset>0</Offset>
+		<Pad>0</Pad>
 		<Tag></Tag>
 		<Exported>false</Exported>
 		<Embedded>false</Embedded>
@@ -224,6 +234,8 @@ func TestBytes(t *testing.T) {
 		<Size>16</Size>
 		<Align>8</Align>
 		<Ptr>8</Ptr>
+		<Offset>0</Offset>
+		<Pad>0</Pad>
 		<Tag>test-tag</Tag>
 		<Exported>true</Exported>
 		<Embedded>true</Embedded>
@@ -236,6 +248,8 @@ func TestBytes(t *testing.T) {
 		<Size>12</Size>
 		<Align>4</Align>
 		<Ptr>4</Ptr>
+		<Offset>16</Offset>
+		<Pad>0</Pad>
 		<Tag></Tag>
 		<Exported>false</Exported>
 		<Embedded>false</Embedded>
@@ -252,7 +266,7 @@ func TestBytes(t *testing.T) {
 			fmt: Csvb(Buffer()),
 			f:   collections.Flat{"test": gopium.Struct{}},
 			r: []byte(`
-Struct Name,Struct Doc,Struct Comment,Field Name,Field Type,Field Size,Field Align,Field Ptr,Field Tag,Field Exported,Field Embedded,Field Doc,Field Comment
+Struct Name,Struct Doc,Struct Comment,Field Name,Field Type,Field Size,Field Align,Field Ptr,Field Offset,Field Pad,Field Tag,Field Exported,Field Embedded,Field Doc,Field Comment
 `),
 		},
 		"csv should return error on writer error": {
@@ -276,11 +290,12 @@ Struct Name,Struct Doc,Struct Comment,Field Name,Field Type,Field Size,Field Ali
 							Comment:  []string{"fcomtest"},
 						},
 						{
-							Name:  "test-2",
-							Type:  "test_type",
-							Size:  12,
-							Align: 4,
-							Ptr:   4,
+							Name:   "test-2",
+							Type:   "test_type",
+							Size:   12,
+							Align:  4,
+							Ptr:    4,
+							Offset: 16,
 						},
 					},
 				},
@@ -320,11 +335,12 @@ Struct Name,Struct Doc,Struct Comment,Field Name,Field Type,Field Size,Field Ali
 							Comment:  []string{"fcomtest"},
 						},
 						{
-							Name:  "test-2",
-							Type:  "test_type",
-							Size:  12,
-							Align: 4,
-							Ptr:   4,
+							Name:   "test-2",
+							Type:   "test_type",
+							Size:   12,
+							Align:  4,
+							Ptr:    4,
+							Offset: 16,
 						},
 					},
 				},
@@ -342,10 +358,10 @@ Struct Name,Struct Doc,Struct Comment,Field Name,Field Type,Field Size,Field Ali
 				},
 			},
 			r: []byte(`
-Struct Name,Struct Doc,Struct Comment,Field Name,Field Type,Field Size,Field Align,Field Ptr,Field Tag,Field Exported,Field Embedded,Field Doc,Field Comment
-Test-1,,,test-3,test,1,1,1,,false,false,,
-Test,doctest,comtest,test-1,string,16,8,8,test-tag,true,true,fdoctest,fcomtest
-Test,doctest,comtest,test-2,test_type,12,4,4,,false,false,,
+Struct Name,Struct Doc,Struct Comment,Field Name,Field Type,Field Size,Field Align,Field Ptr,Field Offset,Field Pad,Field Tag,Field Exported,Field Embedded,Field Doc,Field Comment
+Test-1,,,test-3,test,1,1,1,0,0,,false,false,,
+Test,doctest,comtest,test-1,string,16,8,8,0,0,test-tag,true,true,fdoctest,fcomtest
+Test,doctest,comtest,test-2,test_type,12,4,4,16,0,,false,false,,
 `),
 		},
 		"md table should return expected result for empty collection": {
@@ -357,8 +373,8 @@ Test,doctest,comtest,test-2,test_type,12,4,4,,false,false,,
 			fmt: Mdtb,
 			f:   collections.Flat{"test": gopium.Struct{}},
 			r: []byte(`
-| Struct Name | Struct Doc | Struct Comment | Field Name | Field Type | Field Size | Field Align | Field Ptr | Field Tag | Field Exported | Field Embedded | Field Doc | Field Comment |
-| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| Struct Name | Struct Doc | Struct Comment | Field Name | Field Type | Field Size | Field Align | Field Ptr | Field Offset | Field Pad | Field Tag | Field Exported | Field Embedded | Field Doc | Field Comment |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
 `),
 		},
 		"md table should return expected result for non empty collection": {
@@ -382,11 +398,12 @@ Test,doctest,comtest,test-2,test_type,12,4,4,,false,false,,
 							Comment:  []string{"fcomtest"},
 						},
 						{
-							Name:  "test-2",
-							Type:  "test_type",
-							Size:  12,
-							Align: 4,
-							Ptr:   4,
+							Name:   "test-2",
+							Type:   "test_type",
+							Size:   12,
+							Align:  4,
+							Ptr:    4,
+							Offset: 16,
 						},
 					},
 				},
@@ -404,11 +421,11 @@ Test,doctest,comtest,test-2,test_type,12,4,4,,false,false,,
 				},
 			},
 			r: []byte(`
-| Struct Name | Struct Doc | Struct Comment | Field Name | Field Type | Field Size | Field Align | Field Ptr | Field Tag | Field Exported | Field Embedded | Field Doc | Field Comment |
-| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
-| Test-1 |  |  | test-3 | test | 1 | 1 | 1 |  | false | false |  |  |
-| Test | doctest | comtest | test-1 | string | 16 | 8 | 8 | test-tag | true | true | fdoctest | fcomtest |
-| Test | doctest | comtest | test-2 | test_type | 12 | 4 | 4 |  | false | false |  |  |
+| Struct Name | Struct Doc | Struct Comment | Field Name | Field Type | Field Size | Field Align | Field Ptr | Field Offset | Field Pad | Field Tag | Field Exported | Field Embedded | Field Doc | Field Comment |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| Test-1 |  |  | test-3 | test | 1 | 1 | 1 | 0 | 0 |  | false | false |  |  |
+| Test | doctest | comtest | test-1 | string | 16 | 8 | 8 | 0 | 0 | test-tag | true | true | fdoctest | fcomtest |
+| Test | doctest | comtest | test-2 | test_type | 12 | 4 | 4 | 16 | 0 |  | false | false |  |  |
 `),
 		},
 	}
@@ -43,7 +43,7 @@ const (
 	</head>
 	<body>
 		<div class="accordion" id="structs">
-		{{- range $struct, $fields := . }}
+		{{- range $struct, $data := . }}
 		<div class="card">
 			<div class="card-header" id="heading{{$struct}}">
 				<h2 class="mb-0">
@@ -58,6 +58,11 @@ const (
 						{{$struct}}
 					</button>
 				</h2>
+				<p class="mb-0">
+					Size with Pad: {{$data.OldSize}} -> {{$data.NewSize}} bytes;
+					Size Class: {{$data.OldClass}} -> {{$data.NewClass}} bytes;
+					Size Class Waste: {{$data.OldWaste}} -> {{$data.NewWaste}} bytes
+				</p>
 			</div>
 			<div id="collapse{{$struct}}" class="collapse" aria-labelledby="heading{{$struct}}" data-parent="#structs">
 				<table class="table">
@@ -77,7 +82,7 @@ const (
 						</tr>
 					</thead>
 					<tbody>
-						{{- range $fields }}
+						{{- range $data.Fields }}
 						<tr class="{{.Class}}">
 							<th scope="row">{{.Index}}</th>
 							{{- if eq .Class "diff" }}
@@ -135,13 +140,14 @@ func SizeAlignMdt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
 	var buf bytes.Buffer
 	var tsizeo, tsizer int64
 	var tptro, tptrr int64
+	var tclasso, tclassr int64
 	fo, fr := o.Full(), r.Full()
 	// write header
 	// no error should be
 	// checked as it uses
 	// buffered writer
-	_, _ = buf.WriteString("| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference |\n")
-	_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |\n")
+	_, _ = buf.WriteString("| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference | Original Size Class | Current Size Class | Original Size Class Waste | Current Size Class Waste |\n")
+	_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |\n")
 	for id, sto := range fo {
 		// if both collections contains
 		// struct, compare them
@@ -149,13 +155,15 @@ func SizeAlignMdt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
 			// get aligned size and align
 			sizeo, _, ptro := collections.SizeAlignPtr(sto)
 			sizer, _, ptrr := collections.SizeAlignPtr(stf)
+			// get allocator size classes
+			classo, classr := collections.SizeClass(sizeo), collections.SizeClass(sizer)
 			// write diff info
 			// no error should be
 			// checked as it uses
 			// buffered writer
 			_, _ = buf.WriteString(
 				fmt.Sprintf(
-					"| %s | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %+d bytes | %+.2f%% |\n",
+					"| %s | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %d bytes | %d bytes |\n",
 					sto.Name,
 					sizeo,
 					sizer,
@@ -165,6 +173,10 @@ func SizeAlignMdt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
 					ptrr,
 					ptrr-ptro,
 					float64(ptrr-ptro)/float64(ptro)*100.0,
+					classo,
+					classr,
+					classo-sizeo,
+					classr-sizer,
 				),
 			)
 			// increment total sizes
@@ -172,6 +184,8 @@ func SizeAlignMdt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
 			tsizer += sizer
 			tptro += ptro
 			tptrr += ptrr
+			tclasso += classo
+			tclassr += classr
 		}
 	}
 	// zero divide guard
@@ -182,7 +196,7 @@ func SizeAlignMdt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
 		// buffered writer
 		_, _ = buf.WriteString(
 			fmt.Sprintf(
-				"| %s | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %+d bytes | %+.2f%% |\n",
+				"| %s | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %d bytes | %d bytes |\n",
 				"Total",
 				tsizeo,
 				tsizer,
@@ -192,6 +206,10 @@ func SizeAlignMdt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
 				tptrr,
 				tptrr-tptro,
 				float64(tptrr-tptro)/float64(tptro)*100.0,
+				tclasso,
+				tclassr,
+				tclasso-tsizeo,
+				tclassr-tsizer,
 			),
 		)
 	}
@@ -206,7 +224,7 @@ func FieldsHtmlt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
 	var buf bytes.Buffer
 	fo, fr := o.Full(), r.Full()
 	// prepare data set for template
-	data := make(map[string][]interface{}, len(fo))
+	data := make(map[string]interface{}, len(fo))
 	// go through original collection
 	for id, sto := range fo {
 		// if both collections contains
@@ -287,8 +305,28 @@ func FieldsHtmlt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
 					NewComment:  fmt.Sprintf("%q", strings.Join(fr.Comment, " ")),
 				})
 			}
+			// get aligned sizes and allocator size classes
+			sizeo, _, _ := collections.SizeAlignPtr(sto)
+			sizer, _, _ := collections.SizeAlignPtr(stf)
+			classo, classr := collections.SizeClass(sizeo), collections.SizeClass(sizer)
 			// set struct template data bucket
-			data[sto.Name] = fields
+			data[sto.Name] = struct {
+				Fields   []interface{}
+				OldSize  int64
+				OldClass int64
+				OldWaste int64
+				NewSize  int64
+				NewClass int64
+				NewWaste int64
+			}{
+				Fields:   fields,
+				OldSize:  sizeo,
+				OldClass: classo,
+				OldWaste: classo - sizeo,
+				NewSize:  sizer,
+				NewClass: classr,
+				NewWaste: classr - sizer,
+			}
 		}
 	}
 	// parse and execute template
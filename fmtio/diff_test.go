@@ -108,6 +108,30 @@ func TestDiff(t *testing.T) {
 			},
 		},
 	})
+	ohw := collections.NewHierarchic("")
+	rhw := collections.NewHierarchic("")
+	ohw.Push("test", "test", gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "test1",
+				Type:  "[52]byte",
+				Size:  52,
+				Align: 1,
+			},
+		},
+	})
+	rhw.Push("test", "test", gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "test1",
+				Type:  "[49]byte",
+				Size:  49,
+				Align: 1,
+			},
+		},
+	})
 	table := map[string]struct {
 		fmt gopium.Diff
 		o   gopium.Categorized
@@ -120,8 +144,8 @@ func TestDiff(t *testing.T) {
 			o:   collections.NewHierarchic(""),
 			r:   collections.NewHierarchic(""),
 			b: []byte(`
-| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference |
-| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference | Original Size Class | Current Size Class | Original Size Class Waste | Current Size Class Waste |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
 `),
 		},
 		"size align md table should return expected result for non empty collections": {
@@ -129,10 +153,10 @@ func TestDiff(t *testing.T) {
 			o:   oh,
 			r:   rh,
 			b: []byte(`
-| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference |
-| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
-| test | 24 bytes | 16 bytes | -8 bytes | -33.33% | 17 bytes | 12 bytes | -5 bytes | -29.41% |
-| Total | 24 bytes | 16 bytes | -8 bytes | -33.33% | 17 bytes | 12 bytes | -5 bytes | -29.41% |
+| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference | Original Size Class | Current Size Class | Original Size Class Waste | Current Size Class Waste |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| test | 24 bytes | 16 bytes | -8 bytes | -33.33% | 17 bytes | 12 bytes | -5 bytes | -29.41% | 24 bytes | 16 bytes | 0 bytes | 0 bytes |
+| Total | 24 bytes | 16 bytes | -8 bytes | -33.33% | 17 bytes | 12 bytes | -5 bytes | -29.41% | 24 bytes | 16 bytes | 0 bytes | 0 bytes |
 `),
 		},
 		"size align md table should return expected result for non empty overlapping collections": {
@@ -140,10 +164,21 @@ func TestDiff(t *testing.T) {
 			o:   oh,
 			r:   rhb,
 			b: []byte(`
-| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference |
-| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
-| test | 24 bytes | 32 bytes | +8 bytes | +33.33% | 17 bytes | 30 bytes | +13 bytes | +76.47% |
-| Total | 24 bytes | 32 bytes | +8 bytes | +33.33% | 17 bytes | 30 bytes | +13 bytes | +76.47% |
+| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference | Original Size Class | Current Size Class | Original Size Class Waste | Current Size Class Waste |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| test | 24 bytes | 32 bytes | +8 bytes | +33.33% | 17 bytes | 30 bytes | +13 bytes | +76.47% | 24 bytes | 32 bytes | 0 bytes | 0 bytes |
+| Total | 24 bytes | 32 bytes | +8 bytes | +33.33% | 17 bytes | 30 bytes | +13 bytes | +76.47% | 24 bytes | 32 bytes | 0 bytes | 0 bytes |
+`),
+		},
+		"size align md table should return expected result for collections with size class waste": {
+			fmt: SizeAlignMdt,
+			o:   ohw,
+			r:   rhw,
+			b: []byte(`
+| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference | Original Size Class | Current Size Class | Original Size Class Waste | Current Size Class Waste |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| test | 52 bytes | 49 bytes | -3 bytes | -5.77% | 0 bytes | 0 bytes | +0 bytes | +NaN% | 64 bytes | 64 bytes | 12 bytes | 15 bytes |
+| Total | 52 bytes | 49 bytes | -3 bytes | -5.77% | 0 bytes | 0 bytes | +0 bytes | +NaN% | 64 bytes | 64 bytes | 12 bytes | 15 bytes |
 `),
 		},
 		"fields html table should return expected result for empty collections": {
@@ -227,6 +262,11 @@ func TestDiff(t *testing.T) {
 						test
 					</button>
 				</h2>
+				<p class="mb-0">
+					Size with Pad: 24 -> 16 bytes;
+					Size Class: 24 -> 16 bytes;
+					Size Class Waste: 0 -> 0 bytes
+				</p>
 			</div>
 			<div id="collapsetest" class="collapse" aria-labelledby="headingtest" data-parent="#structs">
 				<table class="table">
@@ -339,6 +379,11 @@ func TestDiff(t *testing.T) {
 						test
 					</button>
 				</h2>
+				<p class="mb-0">
+					Size with Pad: 24 -> 32 bytes;
+					Size Class: 24 -> 32 bytes;
+					Size Class Waste: 0 -> 0 bytes
+				</p>
 			</div>
 			<div id="collapsetest" class="collapse" aria-labelledby="headingtest" data-parent="#structs">
 				<table class="table">
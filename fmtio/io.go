@@ -15,6 +15,7 @@ const (
 	CSV    = "csv"
 	MD     = "md"
 	HTML   = "html"
+	PATCH  = "patch"
 )
 
 // stdout defines tiny wrapper for
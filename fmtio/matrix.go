@@ -0,0 +1,223 @@
+package fmtio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+const (
+	mhtmltmpl = `
+<html>
+	<head>
+		<link
+			href="https://stackpath.bootstrapcdn.com/bootstrap/4.5.0/css/bootstrap.min.css"
+			rel="stylesheet"
+			integrity="sha384-9aIt2nRpC12Uk9gS9baDl411NQApFmC26EwAOH8WgZl5MYYxFfc+NcPb1dKGj7Sk"
+			crossorigin="anonymous"
+		>
+		<style>
+			.diff{ background: yellow; }
+		</style>
+	</head>
+	<body>
+		<table class="table table-sm">
+			<thead>
+				<tr>
+					<th scope="col">Struct Name</th>
+					{{- range .Platforms }}
+					<th scope="col">{{.}}</th>
+					{{- end }}
+				</tr>
+			</thead>
+			<tbody>
+				{{- range .Rows }}
+				<tr>
+					<th scope="row">{{.Struct}}</th>
+					{{- range .Platforms }}
+					<td{{ if ne .Original .Current }} class="diff"{{ end }}>
+						{{.Original.Size}}/{{.Original.Align}}/{{.Original.Pad}}/{{.Original.Ptr}}
+						-> {{.Current.Size}}/{{.Current.Align}}/{{.Current.Pad}}/{{.Current.Ptr}}
+					</td>
+					{{- end }}
+				</tr>
+				{{- end }}
+			</tbody>
+		</table>
+		<p>Each cell contains original -> current struct size/align/pad/ptr scan size in bytes.</p>
+	<body>
+</html>
+`
+)
+
+// mlayout defines struct layout
+// data transfer object on single platform
+type mlayout struct {
+	Size  int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Align int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Pad   int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Ptr   int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// mcell defines struct original and current
+// layouts data transfer object on single platform
+type mcell struct {
+	Platform string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Original mlayout  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Current  mlayout  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [48]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 8 bytes; - 🌺 gopium @1pkg
+
+// mrow defines struct layouts
+// data transfer object on all platforms
+type mrow struct {
+	Struct    string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Platforms []mcell  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_         [24]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 24 bytes; - 🌺 gopium @1pkg
+
+// SizeAlignMatrixMdt defines matrix implementation
+// which compares categorized collections on list of platforms
+// to formatted struct x platform markdown table byte slice
+func SizeAlignMatrixMdt(platforms []string, o []gopium.Categorized, r []gopium.Categorized) ([]byte, error) {
+	// prepare buffer and matrix rows
+	var buf bytes.Buffer
+	rows := matrix(platforms, o, r)
+	// write header
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	_, _ = buf.WriteString("| Struct Name |")
+	for _, platform := range platforms {
+		_, _ = buf.WriteString(fmt.Sprintf(" %s |", platform))
+	}
+	_, _ = buf.WriteString("\n| :---: |")
+	for range platforms {
+		_, _ = buf.WriteString(" :---: |")
+	}
+	_, _ = buf.WriteString("\n")
+	// write all rows
+	// no error should be
+	// checked as it uses
+	// buffered writer
+	for _, row := range rows {
+		_, _ = buf.WriteString(fmt.Sprintf("| %s |", row.Struct))
+		for _, cell := range row.Platforms {
+			_, _ = buf.WriteString(
+				fmt.Sprintf(
+					" %d/%d/%d/%d -> %d/%d/%d/%d |",
+					cell.Original.Size,
+					cell.Original.Align,
+					cell.Original.Pad,
+					cell.Original.Ptr,
+					cell.Current.Size,
+					cell.Current.Align,
+					cell.Current.Pad,
+					cell.Current.Ptr,
+				),
+			)
+		}
+		_, _ = buf.WriteString("\n")
+	}
+	// write cells legend
+	// only for non empty table
+	if len(rows) > 0 {
+		_, _ = buf.WriteString("\nEach cell contains original -> current struct size/align/pad/ptr scan size in bytes.\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// SizeAlignMatrixHtmlt defines matrix implementation
+// which compares categorized collections on list of platforms
+// to formatted struct x platform html table byte slice
+func SizeAlignMatrixHtmlt(platforms []string, o []gopium.Categorized, r []gopium.Categorized) ([]byte, error) {
+	// prepare buffer and data set for template
+	var buf bytes.Buffer
+	data := struct {
+		Platforms []string
+		Rows      []mrow
+	}{
+		Platforms: platforms,
+		Rows:      matrix(platforms, o, r),
+	}
+	// parse and execute template
+	tmpl := template.Must(template.New("tmpl").Parse(mhtmltmpl))
+	err := tmpl.Execute(&buf, data)
+	return buf.Bytes(), err
+}
+
+// SizeAlignMatrixJsonb defines matrix implementation
+// which compares categorized collections on list of platforms
+// and uses json marshal with indent to serialize them to byte slice
+func SizeAlignMatrixJsonb(platforms []string, o []gopium.Categorized, r []gopium.Categorized) ([]byte, error) {
+	// just use json marshal with indent
+	return json.MarshalIndent(matrix(platforms, o, r), "", "\t")
+}
+
+// matrix helps to build struct x platform
+// rows from original and resulted collections
+// on each platform, structs missing on any
+// platform are skipped from the matrix
+func matrix(platforms []string, o []gopium.Categorized, r []gopium.Categorized) []mrow {
+	// skip inconsistent collections
+	if len(platforms) == 0 || len(o) != len(platforms) || len(r) != len(platforms) {
+		return nil
+	}
+	// collect all full collections
+	fos := make([]map[string]gopium.Struct, 0, len(platforms))
+	frs := make([]map[string]gopium.Struct, 0, len(platforms))
+	for i := range platforms {
+		fos = append(fos, o[i].Full())
+		frs = append(frs, r[i].Full())
+	}
+	// sort structs ids the same way as structs
+	// by using flat collection of ids named structs
+	ids := make(collections.Flat, len(fos[0]))
+	for id := range fos[0] {
+		ids[id] = gopium.Struct{Name: id}
+	}
+	rows := make([]mrow, 0, len(ids))
+loop:
+	for _, sid := range ids.Sorted() {
+		id := sid.Name
+		row := mrow{Struct: fos[0][id].Name, Platforms: make([]mcell, 0, len(platforms))}
+		for i, platform := range platforms {
+			// if any collection misses
+			// the struct skip it
+			sto, ok := fos[i][id]
+			if !ok {
+				continue loop
+			}
+			str, ok := frs[i][id]
+			if !ok {
+				continue loop
+			}
+			row.Platforms = append(row.Platforms, mcell{
+				Platform: platform,
+				Original: layout(sto),
+				Current:  layout(str),
+			})
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// layout helps to calculate struct
+// aligned size, align, pad and ptr size
+func layout(st gopium.Struct) mlayout {
+	size, align, ptr := collections.SizeAlignPtr(st)
+	// pad is everything in aligned size
+	// that isn't occupied by non pad fields
+	pad := size
+	for _, f := range st.Fields {
+		if f.Name != "_" {
+			pad -= f.Size
+		}
+	}
+	return mlayout{Size: size, Align: align, Pad: pad, Ptr: ptr}
+}
@@ -0,0 +1,220 @@
+package fmtio
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestMatrix(t *testing.T) {
+	// prepare
+	oh64 := collections.NewHierarchic("")
+	rh64 := collections.NewHierarchic("")
+	oh32 := collections.NewHierarchic("")
+	rh32 := collections.NewHierarchic("")
+	oh64.Push("test:1", "test", gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "a", Type: "bool", Size: 1, Align: 1},
+			{Name: "b", Type: "*int", Size: 8, Align: 8, Ptr: 8},
+			{Name: "c", Type: "bool", Size: 1, Align: 1},
+		},
+	})
+	rh64.Push("test:1", "test", gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "b", Type: "*int", Size: 8, Align: 8, Ptr: 8},
+			{Name: "a", Type: "bool", Size: 1, Align: 1},
+			{Name: "c", Type: "bool", Size: 1, Align: 1},
+			{Name: "_", Type: "[6]byte", Size: 6, Align: 1},
+		},
+	})
+	oh32.Push("test:1", "test", gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "a", Type: "bool", Size: 1, Align: 1},
+			{Name: "b", Type: "*int", Size: 4, Align: 4, Ptr: 4},
+			{Name: "c", Type: "bool", Size: 1, Align: 1},
+		},
+	})
+	rh32.Push("test:1", "test", gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "b", Type: "*int", Size: 4, Align: 4, Ptr: 4},
+			{Name: "a", Type: "bool", Size: 1, Align: 1},
+			{Name: "c", Type: "bool", Size: 1, Align: 1},
+		},
+	})
+	oh32.Push("test:2", "test", gopium.Struct{Name: "missing"})
+	platforms := []string{"gc/amd64", "gc/386"}
+	os := []gopium.Categorized{oh64, oh32}
+	rs := []gopium.Categorized{rh64, rh32}
+	table := map[string]struct {
+		fmt       gopium.Matrix
+		platforms []string
+		o         []gopium.Categorized
+		r         []gopium.Categorized
+		b         []byte
+		err       error
+	}{
+		"size align matrix md table should return expected result for empty collections": {
+			fmt:       SizeAlignMatrixMdt,
+			platforms: platforms,
+			o:         []gopium.Categorized{collections.NewHierarchic(""), collections.NewHierarchic("")},
+			r:         []gopium.Categorized{collections.NewHierarchic(""), collections.NewHierarchic("")},
+			b: []byte(`
+| Struct Name | gc/amd64 | gc/386 |
+| :---: | :---: | :---: |
+`),
+		},
+		"size align matrix md table should return expected result for inconsistent collections": {
+			fmt:       SizeAlignMatrixMdt,
+			platforms: platforms,
+			o:         os[:1],
+			r:         rs,
+			b: []byte(`
+| Struct Name | gc/amd64 | gc/386 |
+| :---: | :---: | :---: |
+`),
+		},
+		"size align matrix md table should return expected result for non empty collections": {
+			fmt:       SizeAlignMatrixMdt,
+			platforms: platforms,
+			o:         os,
+			r:         rs,
+			b: []byte(`
+| Struct Name | gc/amd64 | gc/386 |
+| :---: | :---: | :---: |
+| test | 24/8/14/16 -> 16/8/6/8 | 12/4/6/8 -> 8/4/2/4 |
+
+Each cell contains original -> current struct size/align/pad/ptr scan size in bytes.
+`),
+		},
+		"size align matrix json should return expected result for non empty collections": {
+			fmt:       SizeAlignMatrixJsonb,
+			platforms: platforms,
+			o:         os,
+			r:         rs,
+			b: []byte(`
+[
+	{
+		"Struct": "test",
+		"Platforms": [
+			{
+				"Platform": "gc/amd64",
+				"Original": {
+					"Size": 24,
+					"Align": 8,
+					"Pad": 14,
+					"Ptr": 16
+				},
+				"Current": {
+					"Size": 16,
+					"Align": 8,
+					"Pad": 6,
+					"Ptr": 8
+				}
+			},
+			{
+				"Platform": "gc/386",
+				"Original": {
+					"Size": 12,
+					"Align": 4,
+					"Pad": 6,
+					"Ptr": 8
+				},
+				"Current": {
+					"Size": 8,
+					"Align": 4,
+					"Pad": 2,
+					"Ptr": 4
+				}
+			}
+		]
+	}
+]
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			b, err := tcase.fmt(tcase.platforms, tcase.o, tcase.r)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// format actual and expected identically
+			actual := strings.Trim(string(b), "\n")
+			expected := strings.Trim(string(tcase.b), "\n")
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("actual %v doesn't equal to expected %v", actual, expected)
+			}
+		})
+	}
+}
+
+func TestMatrixHtmlt(t *testing.T) {
+	// prepare
+	oh := collections.NewHierarchic("")
+	rh := collections.NewHierarchic("")
+	oh.Push("test:1", "test", gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "a", Type: "bool", Size: 1, Align: 1},
+			{Name: "b", Type: "int64", Size: 8, Align: 8},
+			{Name: "c", Type: "bool", Size: 1, Align: 1},
+		},
+	})
+	rh.Push("test:1", "test", gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "b", Type: "int64", Size: 8, Align: 8},
+			{Name: "a", Type: "bool", Size: 1, Align: 1},
+			{Name: "c", Type: "bool", Size: 1, Align: 1},
+		},
+	})
+	table := map[string]struct {
+		o     gopium.Categorized
+		r     gopium.Categorized
+		parts []string
+	}{
+		"size align matrix html table should contain expected cells for same layouts": {
+			o: oh,
+			r: oh,
+			parts: []string{
+				`<th scope="col">gc/amd64</th>`,
+				`<th scope="row">test</th>`,
+				"<td>\n\t\t\t\t\t\t24/8/14/0\n\t\t\t\t\t\t-> 24/8/14/0\n\t\t\t\t\t</td>",
+			},
+		},
+		"size align matrix html table should contain expected cells for different layouts": {
+			o: oh,
+			r: rh,
+			parts: []string{
+				`<th scope="col">gc/amd64</th>`,
+				`<th scope="row">test</th>`,
+				"<td class=\"diff\">\n\t\t\t\t\t\t24/8/14/0\n\t\t\t\t\t\t-> 16/8/6/0\n\t\t\t\t\t</td>",
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			b, err := SizeAlignMatrixHtmlt([]string{"gc/amd64"}, []gopium.Categorized{tcase.o}, []gopium.Categorized{tcase.r})
+			// check
+			if !reflect.DeepEqual(err, nil) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			for _, part := range tcase.parts {
+				if !strings.Contains(string(b), part) {
+					t.Errorf("actual %v doesn't contain expected %v", string(b), part)
+				}
+			}
+		})
+	}
+}
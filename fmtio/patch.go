@@ -0,0 +1,229 @@
+package fmtio
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+)
+
+// hunkctx defines number of unchanged
+// lines that surround each patch hunk
+const hunkctx = 3
+
+// list of line edit kinds
+const (
+	eq = iota
+	del
+	ins
+)
+
+// edit defines single line edit
+// with its kind and lines indexes
+// inside origin and result sources
+type edit struct {
+	kind int     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	o    int     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	r    int     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [8]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Unified formats `git apply` compatible
+// unified diff between origin and result sources
+// for provided file path, empty result is returned
+// if origin and result sources are identical
+func Unified(path string, origin []byte, result []byte) []byte {
+	// skip identical sources
+	if bytes.Equal(origin, result) {
+		return nil
+	}
+	// split both sources to lines
+	// and calculate lines edits
+	olines, rlines := lines(origin), lines(result)
+	edits := myers(olines, rlines)
+	// write diff header
+	// with slash separated path
+	var buf bytes.Buffer
+	path = filepath.ToSlash(path)
+	fmt.Fprintf(&buf, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&buf, "--- a/%s\n", path)
+	fmt.Fprintf(&buf, "+++ b/%s\n", path)
+	// go through all edits
+	// and group them to hunks
+	for i := 0; i < len(edits); {
+		// skip unchanged lines
+		if edits[i].kind == eq {
+			i++
+			continue
+		}
+		// find hunk start including
+		// leading context lines
+		start := i - hunkctx
+		if start < 0 {
+			start = 0
+		}
+		// find hunk end including trailing
+		// context lines, merge hunks
+		// if they are close enough
+		end := i
+		for end < len(edits) {
+			if edits[end].kind != eq {
+				end++
+				continue
+			}
+			// count unchanged lines
+			// after current change
+			next := end
+			for next < len(edits) && edits[next].kind == eq {
+				next++
+			}
+			if next == len(edits) || next-end > 2*hunkctx {
+				end += hunkctx
+				if end > len(edits) {
+					end = len(edits)
+				}
+				break
+			}
+			end = next
+		}
+		// write hunk itself
+		hunk(&buf, olines, rlines, edits[start:end])
+		i = end
+	}
+	return buf.Bytes()
+}
+
+// hunk helps to write single patch hunk
+// with its header and lines to buffer
+func hunk(buf *bytes.Buffer, olines []string, rlines []string, edits []edit) {
+	// calculate hunk header
+	// starts and lengths
+	ostart, rstart := edits[0].o, edits[0].r
+	var olen, rlen int
+	for _, e := range edits {
+		switch e.kind {
+		case eq:
+			olen++
+			rlen++
+		case del:
+			olen++
+		case ins:
+			rlen++
+		}
+	}
+	// lines numbers are one based
+	// unless hunk side is empty
+	if olen > 0 {
+		ostart++
+	}
+	if rlen > 0 {
+		rstart++
+	}
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", ostart, olen, rstart, rlen)
+	// write all hunk lines
+	for _, e := range edits {
+		var prefix, line string
+		switch e.kind {
+		case eq:
+			prefix, line = " ", olines[e.o]
+		case del:
+			prefix, line = "-", olines[e.o]
+		case ins:
+			prefix, line = "+", rlines[e.r]
+		}
+		buf.WriteString(prefix)
+		buf.WriteString(line)
+		// mark lines without
+		// trailing new line
+		if line[len(line)-1] != '\n' {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// lines splits source to lines
+// keeping their trailing new lines
+func lines(src []byte) []string {
+	lines := make([]string, 0, bytes.Count(src, []byte{'\n'})+1)
+	for len(src) > 0 {
+		i := bytes.IndexByte(src, '\n')
+		if i < 0 {
+			i = len(src) - 1
+		}
+		lines = append(lines, string(src[:i+1]))
+		src = src[i+1:]
+	}
+	return lines
+}
+
+// myers calculates shortest lines edits script
+// between origin and result lines
+// by using myers diff algorithm
+func myers(olines []string, rlines []string) []edit {
+	n, m := len(olines), len(rlines)
+	nm := n + m
+	// v keeps furthest reaching x
+	// for each diagonal k
+	// trace keeps v snapshot for each d
+	v := make([]int, 2*nm+2)
+	trace := make([][]int, 0)
+	var d int
+loop:
+	for d = 0; d <= nm; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			// choose to move either down or right
+			var x int
+			if k == -d || (k != d && v[nm+k-1] < v[nm+k+1]) {
+				x = v[nm+k+1]
+			} else {
+				x = v[nm+k-1] + 1
+			}
+			// follow diagonal snake
+			y := x - k
+			for x < n && y < m && olines[x] == rlines[y] {
+				x++
+				y++
+			}
+			v[nm+k] = x
+			// stop on reaching the end
+			if x >= n && y >= m {
+				break loop
+			}
+		}
+	}
+	// backtrack the trace
+	// to collect edits in reverse order
+	edits := make([]edit, 0, nm)
+	x, y := n, m
+	for ; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var pk int
+		if k == -d || (k != d && v[nm+k-1] < v[nm+k+1]) {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		px := v[nm+pk]
+		py := px - pk
+		for x > px && y > py {
+			x--
+			y--
+			edits = append(edits, edit{kind: eq, o: x, r: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == px {
+			edits = append(edits, edit{kind: ins, o: px, r: py})
+		} else {
+			edits = append(edits, edit{kind: del, o: px, r: py})
+		}
+		x, y = px, py
+	}
+	// reverse collected edits
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
@@ -0,0 +1,91 @@
+package fmtio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		path   string
+		origin []byte
+		result []byte
+		patch  []byte
+	}{
+		"identical sources should return empty patch": {
+			path:   "test.go",
+			origin: []byte("a\nb\nc\n"),
+			result: []byte("a\nb\nc\n"),
+		},
+		"changed line should return expected patch": {
+			path:   "test.go",
+			origin: []byte("a\nb\nc\n"),
+			result: []byte("a\nd\nc\n"),
+			patch: []byte(`diff --git a/test.go b/test.go
+--- a/test.go
++++ b/test.go
+@@ -1,3 +1,3 @@
+ a
+-b
++d
+ c
+`),
+		},
+		"empty origin should return expected patch": {
+			path:   "test.go",
+			result: []byte("a\nb\n"),
+			patch: []byte(`diff --git a/test.go b/test.go
+--- a/test.go
++++ b/test.go
+@@ -0,0 +1,2 @@
++a
++b
+`),
+		},
+		"missing trailing new line should return expected patch": {
+			path:   "test.go",
+			origin: []byte("a\nb\n"),
+			result: []byte("a\nb"),
+			patch: []byte(`diff --git a/test.go b/test.go
+--- a/test.go
++++ b/test.go
+@@ -1,2 +1,2 @@
+ a
+-b
++b
+\ No newline at end of file
+`),
+		},
+		"distant changes should return expected multiple hunks patch": {
+			path:   "dir/test.go",
+			origin: []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"),
+			result: []byte("0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n12\n"),
+			patch: []byte(`diff --git a/dir/test.go b/dir/test.go
+--- a/dir/test.go
++++ b/dir/test.go
+@@ -1,3 +1,4 @@
++0
+ 1
+ 2
+ 3
+@@ -8,5 +9,4 @@
+ 8
+ 9
+ 10
+-11
+ 12
+`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			patch := Unified(tcase.path, tcase.origin, tcase.result)
+			// check
+			if !reflect.DeepEqual(string(patch), string(tcase.patch)) {
+				t.Errorf("actual %v doesn't equal to expected %v", string(patch), string(tcase.patch))
+			}
+		})
+	}
+}
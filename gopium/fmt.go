@@ -18,6 +18,11 @@ type Ast func(*ast.TypeSpec, Struct) error
 // gopium collections difference to byte slice
 type Diff func(Categorized, Categorized) ([]byte, error)
 
+// Matrix defines abstraction for formatting
+// gopium collections original and resulted
+// on list of platforms to byte slice
+type Matrix func([]string, []Categorized, []Categorized) ([]byte, error)
+
 // Apply defines abstraction for
 // formatting original ast package by
 // applying custom action accordingly to
@@ -3,11 +3,19 @@ package gopium
 import "go/types"
 
 // Curator defines system level info curator abstraction
-// to expose system word, aligment and cache levels sizes
+// to expose system word, aligment, cache levels lines sizes,
+// cache levels total sizes and associativities (zero if unknown)
+// and destructive and constructive interference sizes
+// (min offset between objects to avoid false sharing
+// and max size of memory to promote true sharing)
 type Curator interface {
 	SysWord() int64
 	SysAlign() int64
 	SysCache(level uint) int64
+	SysCacheSize(level uint) int64
+	SysCacheWays(level uint) int64
+	SysDestructive() int64
+	SysConstructive() int64
 }
 
 // Exposer defines type info exposer abstraction
@@ -25,3 +33,13 @@ type Maven interface {
 	Curator
 	Exposer
 }
+
+// Portable defines exposer abstraction
+// that is able to expose type info on list
+// of target platforms additionally to its own,
+// the first platform is the exposer own platform
+type Portable interface {
+	Exposer
+	Platforms() []string
+	Platform(string) (Exposer, bool)
+}
@@ -14,6 +14,13 @@ type Locator interface {
 	Loc(token.Pos) string
 	Locator(string) (Locator, bool)
 	Fset(string, *token.FileSet) (*token.FileSet, bool)
+	Source(string, []byte) ([]byte, bool)
+	Pin(token.Pos, string) (string, bool)
+	Instances(token.Pos, *types.Named) ([]*types.Named, bool)
+	Anonymous(*types.Struct, *ast.TypeSpec) (*ast.TypeSpec, bool)
+	CoAccess(token.Pos, ...string) (CoAccess, bool)
+	Hotness(token.Pos, string, int64) (Hotness, bool)
+	Atomic(token.Pos, int64, ...string) (Atomics, bool)
 	Root() *token.FileSet
 }
 
@@ -35,3 +42,10 @@ type Parser interface {
 	TypeParser
 	AstParser
 }
+
+// Expander defines abstraction for
+// multi packages parsing processor
+// that expands itself to single packages parsers
+type Expander interface {
+	Expand(context.Context) ([]Parser, error)
+}
@@ -18,3 +18,105 @@ type StrategyName string
 type StrategyBuilder interface {
 	Build(...StrategyName) (Strategy, error)
 }
+
+// Platform defines single structure variant
+// on named target platform data transfer object
+type Platform struct {
+	Name   string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Struct Struct `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 104 bytes; struct align: 8 bytes; struct aligned size: 104 bytes; struct ptr scan size: 88 bytes; - 🌺 gopium @1pkg
+
+// platformsKey defines context key
+// for structure platforms variants
+type platformsKey struct{}
+
+// WithPlatforms returns copy of provided context
+// that carries structure variants on target platforms
+// to strategies that are able to consider them
+func WithPlatforms(ctx context.Context, platforms []Platform) context.Context {
+	return context.WithValue(ctx, platformsKey{}, platforms)
+}
+
+// Platforms returns structure variants
+// on target platforms carried by context if any
+func Platforms(ctx context.Context) []Platform {
+	platforms, _ := ctx.Value(platformsKey{}).([]Platform)
+	return platforms
+}
+
+// CoAccess defines structure fields co-access graph
+// that holds number of functions accessing
+// each pair of structure fields together
+type CoAccess map[string]map[string]int64
+
+// Weight returns number of functions
+// accessing both provided fields together
+func (ca CoAccess) Weight(f1, f2 string) int64 {
+	return ca[f1][f2]
+}
+
+// coaccessKey defines context key
+// for structure fields co-access graph
+type coaccessKey struct{}
+
+// WithCoAccess returns copy of provided context
+// that carries structure fields co-access graph
+// to strategies that are able to consider it
+func WithCoAccess(ctx context.Context, ca CoAccess) context.Context {
+	return context.WithValue(ctx, coaccessKey{}, ca)
+}
+
+// CoAccessed returns structure fields
+// co-access graph carried by context if any
+func CoAccessed(ctx context.Context) CoAccess {
+	ca, _ := ctx.Value(coaccessKey{}).(CoAccess)
+	return ca
+}
+
+// Hotness defines structure fields hotness
+// that holds number of profile samples
+// of functions accessing each structure field
+type Hotness map[string]int64
+
+// hotnessKey defines context key
+// for structure fields hotness
+type hotnessKey struct{}
+
+// WithHotness returns copy of provided context
+// that carries structure fields hotness
+// to strategies that are able to consider it
+func WithHotness(ctx context.Context, h Hotness) context.Context {
+	return context.WithValue(ctx, hotnessKey{}, h)
+}
+
+// Profiled returns structure fields
+// hotness carried by context if any
+func Profiled(ctx context.Context) Hotness {
+	h, _ := ctx.Value(hotnessKey{}).(Hotness)
+	return h
+}
+
+// Atomics defines structure atomic fields
+// that holds max bits width of atomic operations
+// on each field accessed by atomic operations
+// or having atomic types, zero width stands
+// for platform word width operations
+type Atomics map[string]int64
+
+// atomicsKey defines context key
+// for structure atomic fields
+type atomicsKey struct{}
+
+// WithAtomics returns copy of provided context
+// that carries structure atomic fields
+// to strategies that are able to consider it
+func WithAtomics(ctx context.Context, a Atomics) context.Context {
+	return context.WithValue(ctx, atomicsKey{}, a)
+}
+
+// AtomicAccessed returns structure atomic
+// fields carried by context if any
+func AtomicAccessed(ctx context.Context) Atomics {
+	a, _ := ctx.Value(atomicsKey{}).(Atomics)
+	return a
+}
@@ -2,17 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"os/signal"
-	"path/filepath"
 	"runtime"
 	"strings"
 
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/runners"
+	"github.com/1pkg/gopium/typepkg"
 	"github.com/spf13/cobra"
 )
 
@@ -21,17 +22,25 @@ var (
 	// cli command iteself
 	cli *cobra.Command
 	// target platform vars
-	tcompiler string
-	tarch     string
-	tcpulines []int
+	tcompiler      string
+	tarch          string
+	ttarget        string
+	tcpulines      []string
+	tinterferences []int
+	tvarchs        []string
+	tparchs        []string
 	// package parser vars
-	ppath   string
-	pbenvs  []string
-	pbflags []string
+	ppath      string
+	pbenvs     []string
+	pbflags    []string
+	poverlay   string
+	pprofile   string
+	pplatforms []string
 	// gopium walker vars
 	wregex   string
 	wdeep    bool
 	wbackref bool
+	wworkers int
 	// gopium printer vars
 	pindent   int
 	ptabwidth int
@@ -58,7 +67,7 @@ Gopium is the tool that was designed to automate and simplify some common perfor
 - auto annotation
 - generic fields management, etc.
 
-In order to use gopium cli you need to provide at least package name (full package name is expected),
+In order to use gopium cli you need to provide at least package (either import path or directory is expected),
 list of strategies which is applied one by one and single walker.
 Outcome of execution is fully defined by list of strategies and walker combination.
 List of strategies modifies structs inside the package, walker facilitates and insures,
@@ -70,6 +79,11 @@ Gopium provides next walkers:
  - ast_go_tree (directly syncs result as go code to copy package)
  - ast_std (prints result as go code to stdout)
  - ast_gopium (directly syncs result as go code to copy gopium files)
+ - ast_go_arch (directly syncs result as go code to orinal file and moves structs which results differ
+	across target_variants_architectures to build constrained per architecture files)
+ - ast_patch (prints git apply compatible unified diff of result as go code to stdout)
+ - ast_patch_file (prints git apply compatible unified diff of result as go code to single patch file
+	inside package directory)
  - file_json (prints json encoded results to single file inside package directory)
  - file_xml (prints xml encoded results to single file inside package directory)
  - file_csv (prints csv encoded results to single file inside package directory)
@@ -78,6 +92,14 @@ Gopium provides next walkers:
 	inside package directory)
  - fields_file_html_table (prints html encoded table of fields difference for results to single file
 	inside package directory)
+ - size_align_matrix_file_md_table (prints markdown encoded matrix of sizes, aligns, paddings and ptr sizes for results
+	on all compiler/arch platforms to single file inside package directory)
+ - size_align_matrix_file_html_table (prints html encoded matrix of sizes, aligns, paddings and ptr sizes for results
+	on all compiler/arch platforms to single file inside package directory)
+ - size_align_matrix_file_json (prints json encoded matrix of sizes, aligns, paddings and ptr sizes for results
+	on all compiler/arch platforms to single file inside package directory)
+ - check (prints compiler like list of structs that differ from results to stdout
+	and exits with distinct non zero code 3 if any found, useful for CI)
 
 Gopium provides next strategies:
 
@@ -85,6 +107,19 @@ Gopium provides next strategies:
 	on different groups and then combine results in single struct result)
  - memory_pack (rearranges structure fields to obtain optimal memory utilization)
  - memory_unpack (rearranges structure field list to obtain inflated memory utilization)
+ - memory_pack_optimal (rearranges structure fields to obtain minimal memory utilization by exhaustive search)
+ - hot_cold_profile (rearranges structure fields to place the hottest by package_profile fields
+	within cpu cache line #1 first and annotates fields with their hotness)
+ - split_cold (moves structure cold fields from group:cold tag group or with zero package_profile hotness
+	to generated companion structure referenced by cold pointer field, works only with ast walkers)
+ - memory_pack_coaccess (rearranges structure fields to place frequently co-accessed fields together
+	within cpu cache line #1 without padding worse than memory_pack)
+ - memory_pack_coaccess_tolerance_bytes_{{uint}} (rearranges structure fields to place frequently co-accessed fields
+	together within cpu cache line #1 without padding worse than memory_pack by more than specified bytes)
+ - memory_pack_portable (rearranges structure fields to obtain optimal total memory utilization
+	across target_architecture and target_portable_architectures)
+ - memory_pack_portable_worst (rearranges structure fields to obtain optimal worst case memory utilization
+	across target_architecture and target_portable_architectures)
  - cache_rounding_cpu_l1_discrete (fits structure into cpu cache line #1 by adding bottom partial rounding cpu cache padding)
  - cache_rounding_cpu_l2_discrete (fits structure into cpu cache line #2 by adding bottom partial rounding cpu cache padding)
  - cache_rounding_cpu_l3_discrete (fits structure into cpu cache line #3 by adding bottom partial rounding cpu cache padding)
@@ -95,6 +130,10 @@ Gopium provides next strategies:
  - cache_rounding_cpu_l3_full (fits structure into full cpu cache line #3 by adding bottom rounding cpu cache padding)
  - cache_rounding_bytes_{{uint}}_full (fits structure into full provided number of bytes by adding bottom rounding
 	bytes cache padding)
+ - cache_rounding_constructive_interference_discrete (fits structure into constructive interference size by adding
+	bottom partial rounding padding)
+ - cache_rounding_constructive_interference_full (fits structure into full constructive interference size by adding
+	bottom rounding padding)
  - false_sharing_cpu_l1 (guards structure from false sharing by adding extra cpu cache line #1 paddings
 	for each structure field)
  - false_sharing_cpu_l2 (guards structure from false sharing by adding extra cpu cache line #1 paddings
@@ -103,6 +142,18 @@ Gopium provides next strategies:
 	for each structure field)
 - false_sharing_bytes_{{uint}} (guards structure from false sharing by adding extra provided number of bytes paddings
 	for each structure field)
+ - false_sharing_contended_cpu_l1 (guards structure contended fields from false sharing by grouping fields
+	of the same writer and adding extra cpu cache line #1 paddings after each group)
+ - false_sharing_contended_cpu_l2 (guards structure contended fields from false sharing by grouping fields
+	of the same writer and adding extra cpu cache line #2 paddings after each group)
+ - false_sharing_contended_cpu_l3 (guards structure contended fields from false sharing by grouping fields
+	of the same writer and adding extra cpu cache line #3 paddings after each group)
+ - false_sharing_contended_bytes_{{uint}} (guards structure contended fields from false sharing by grouping fields
+	of the same writer and adding extra provided number of bytes paddings after each group)
+ - false_sharing_destructive_interference (guards structure from false sharing by adding extra destructive
+	interference size paddings for each structure field)
+ - false_sharing_contended_destructive_interference (guards structure contended fields from false sharing by grouping
+	fields of the same writer and adding extra destructive interference size paddings after each group)
  - separate_padding_system_alignment_top (separates structure with extra system alignment padding by adding
 	the padding at the top)
 - separate_padding_system_alignment_bottom (separates structure with extra system alignment padding by adding
@@ -115,6 +166,8 @@ Gopium provides next strategies:
 	the padding at the top)
 - separate_padding_bytes_{{uint}_top (separates structure with extra provided number of bytes padding by adding
 	the padding at the top)
+ - separate_padding_destructive_interference_top (separates structure with extra destructive interference size padding
+	by adding the padding at the top)
  - separate_padding_cpu_l1_bottom (separates structure with extra cpu cache line #1 padding by adding
 	the padding at the bottom)
  - separate_padding_cpu_l2_bottom (separates structure with extra cpu cache line #2 padding by adding
@@ -123,10 +176,14 @@ Gopium provides next strategies:
 	the padding at the bottom)
 - separate_padding_bytes_{{uint}_bottom (separates structure with extra provided number of bytes padding by adding
 	the padding at the bottom)
+ - separate_padding_destructive_interference_bottom (separates structure with extra destructive interference size
+	padding by adding the padding at the bottom)
  - explicit_paddings_system_alignment (explicitly aligns each structure field to system alignment padding by adding
 	missing paddings for each field)
  - explicit_paddings_type_natural (explicitly aligns each structure field to max type alignment padding by adding
 	missing paddings for each field)
+ - atomic_align_64 (guarantees 8 bytes alignment of structure fields used by sync/atomic 64-bit operations
+	by placing them first and adding missing paddings if any of them is misaligned)
  - add_tag_group_soft (adds gopium fields tags annotation if no previous annotation found)
  - add_tag_group_force (adds gopium fields tags annotation if previous annotation found overwrites it)
  - add_tag_group_discrete (discretely adds gopium fields tags annotation if no previous annotation found)
@@ -137,6 +194,8 @@ Gopium provides next strategies:
  - fields_annotate_comment adds align and size comment annotation for each structure field)
  - struct_annotate_doc (adds aggregated align and size doc annotation for structure)
  - struct_annotate_comment (adds aggregated align and size comment annotation for structure)
+ - size_class_fit (adds go runtime allocator size class, size class waste and bytes to trim to previous size class
+	comment annotation for structure)
  - name_lexicographical_ascending (sorts fields accordingly to their names in ascending order)
  - name_lexicographical_descending (sorts fields accordingly to their names descending order)
  - type_lexicographical_ascending (sorts fields accordingly to their types in ascending order)
@@ -149,9 +208,79 @@ Notes:
  - process_tag_group currently supports only next fields tags annotation formats:
   - gopium:"stg,stg,stg" processed as default group
   - gopium:"group:def;stg,stg,stg" processed as named group
+  - gopium:"group:def" processed as named group without strategies
  - by specifying tag_type you can automatically generate fields tags annotation suitable for process_tag_group.
  - add_tag_* strategies just add list of applied transformations to structure fields tags and NOT change results of
 	other strategies, you can execute process_tag_group strategy afterwards to reuse saved strategies list.
+ - ast_* walkers convert unkeyed composite literals of transformed structures inside the package to keyed literals,
+	unkeyed literals that can't be fixed (including literals inside other packages) are reported to stderr.
+ - structures which layout is relied on inside the package (encoding/binary, unsafe, cgo, go:linkname or reflect usages)
+	are pinned, all walkers keep fields of pinned structures untouched and report the pinning reasons to stderr.
+ - generic structures are visited only through their concrete instantiations used inside the package,
+	each instantiation is reported separately and generic structure gets the fields order optimal across all of them.
+ - anonymous structures (inline struct types of fields, variables and literals) are visited with their enclosing
+	declarations, named after them (like Config.inner) and rewritten in place.
+ - package name accepts go list patterns (like ./... or example.com/mod/...), all matched packages are loaded at once
+	and visited concurrently by walker_workers.
+ - packages are resolved by import path or directory (like . or ./pkg) using go.mod and go.work metadata
+	from package_path directory, so gopium works from any directory of a module or workspace.
+ - package_overlay (like gopls or go build -overlay) replaces files contents on disk with provided contents
+	(or adds new files) both for types checking and ast parsing, so editors get results for unsaved buffers.
+ - ast and types use exactly the same package files, files excluded by build constraints are never touched,
+	package_platforms loads package for several goos/goarch platforms and each file is processed for the first
+	platform that compiles it (structs with unkeyed literals in files of other platforms are pinned),
+	only ast_std, ast_go, ast_go_tree, ast_gopium, ast_patch and check walkers support it.
+ - ast_go_arch visits package once per target_variants_architectures architecture (with strategies built for
+	the architecture), structs which results differ are moved from file.go to file_{{arch}}.go files constrained
+	by //go:build and to fallback file_other.go file with original structs for all other architectures,
+	moved structs are appended to already existing files with equivalent build constraints.
+ - size_align_matrix_* walkers visit package once per each gc and gccgo architecture supported by types.SizesFor
+	(reusing target_cpu_cache_lines_sizes, target_interference_sizes and rebuilding strategies for each platform) and report struct x platform
+	matrix, each cell contains original -> current struct size/align/pad/ptr scan size in bytes.
+ - memory_pack_portable* strategies evaluate original fields order and memory_pack fields orders of
+	target_architecture and each target_portable_architectures architecture on all of them, choose the order
+	with the smallest total (or worst case) aligned size and annotate structure with per architecture sizes,
+	without target_portable_architectures they behave exactly as memory_pack.
+ - memory_pack_optimal strategy searches all fields orders by branch and bound over fields alignment classes
+	and picks the order with minimal aligned size, then minimal ptr scan size and then minimal distance from original
+	fields order, so its result is never worse than memory_pack result, structures with more than 12 fields
+	are rearranged by memory_pack instead.
+ - size_align_file_md_table and fields_file_html_table walkers report go runtime allocator size class of structures
+	and per object waste inside the size class, as shrinking structure inside the same size class saves no heap memory,
+	size_class_fit strategy annotates structure with number of bytes to trim to fit into previous size class.
+ - memory_pack_coaccess* strategies build fields co-access graph from fields selector expressions in package
+	functions bodies (weight of fields pair is number of functions accessing both fields), greedily group
+	the most co-accessed fields into memory sorted groups fitting target_cpu_cache_lines_sizes #1 and place groups
+	from the hottest to the coldest, if resulted padding exceeds memory_pack padding by more than tolerance bytes
+	or structure has no co-access graph they behave exactly as memory_pack.
+ - package_profile accepts gzipped or raw pprof profile (like go default.pgo cpu profile), each sample is counted
+	by its first value and attributed to the innermost function of its leaf location, structure field hotness is
+	total samples of package functions accessing the field, hot_cold_profile strategy places the hottest fields
+	that fit within target_cpu_cache_lines_sizes #1 first and the rest of fields after them.
+ - split_cold strategy replaces cold fields with cold pointer field to generated {{struct}}Cold companion structure,
+	ast walkers append companion structures to the end of structures files, rewrite cold fields selectors and
+	literals in the package (literals always allocate companion structure), split fails if zero values of split
+	structures or literals that can't be rewritten are used as they don't allocate companion structure, values
+	copies and accesses that can't be rewritten are reported to stderr, anonymous and local structures can't be split.
+ - atomic_align_64 strategy considers fields addressed by package sync/atomic 64-bit functions calls or having
+	atomic.Int64, atomic.Uint64 types atomic, places misaligned atomic fields first with paddings and rounds
+	structures sizes to 8 bytes, together with check walker and 32-bit target_architecture it flags existing
+	structures violating the rule.
+ - false_sharing_contended_* strategies consider fields accessed by sync/atomic functions, having sync/atomic,
+	sync.Mutex or sync.RWMutex types or from named group:{{owner}} tag group contended, fields of the same tag
+	group share cache lines, uncontended fields are placed first and each group is padded to cache line.
+ - *_interference_* strategies use target_interference_sizes, destructive size (minimum distance between fields
+	to avoid false sharing) for false sharing and separate strategies and constructive size (maximum size of
+	memory promoting true sharing) for cache rounding strategies, by default sizes are defined per
+	target_architecture (e.g. 128,64 for amd64 and arm64, 128,128 for ppc64, 256,256 for s390x);
+	explicit destructive size is also used instead of cpu cache lines sizes by false_sharing_cpu_l*,
+	false_sharing_contended_cpu_l* and separate_padding_cpu_l* strategies.
+ - target_description replaces target_compiler and target_architecture with custom target description for platforms
+	unknown to go types (like tinygo targets), layouts follow gc rules with natural basic types sizes aligned up to
+	max_align unless overridden in basics by types names, description caches override target_cpu_cache_lines_sizes.
+ - target_cpu_cache_lines_sizes set to auto reads host cpu caches hierarchy from linux sysfs
+	/sys/devices/system/cpu/cpu0/cache/index* (instruction caches are skipped), caches lines sizes are used
+	as cpu cache lines sizes and caches sizes and associativities are exposed to strategies alongside them.
 		`,
 		Args: cobra.MinimumNArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -160,17 +289,26 @@ Notes:
 				// target platform vars
 				tcompiler,
 				tarch,
+				ttarget,
 				tcpulines,
+				typepkg.SysRoot,
+				tinterferences,
+				tvarchs,
+				tparchs,
 				// package parser vars
 				args[1], // package name
 				ppath,
 				defenv(cmd.Context(), pbenvs, "GOPATH", "GOCACHE", "GOTMPDIR"),
 				pbflags,
+				poverlay,
+				pprofile,
+				pplatforms,
 				// gopium walker vars
 				args[0], // single walker
 				wregex,
 				wdeep,
 				wbackref,
+				wworkers,
 				args[2:], // strategies slice
 				// gopium printer vars
 				pindent,
@@ -183,6 +321,9 @@ Notes:
 			if err != nil {
 				return err
 			}
+			// all args are valid at this point
+			// so don't print usage on run errors
+			cmd.SilenceUsage = true
 			// execute app
 			return cli.Run(cmd.Context())
 		},
@@ -203,15 +344,62 @@ Notes:
 		"amd64",
 		"Gopium target platform architecture, possible values are: 386, arm, arm64, amd64, mips, etc.",
 	)
+	// set target_description flag
+	cli.Flags().StringVarP(
+		&ttarget,
+		"target_description",
+		"u",
+		"",
+		`
+Gopium target platform description, path to json encoded custom target description is expected
+(like {"word_size": 4, "max_align": 4, "basics": {"int64": {"size": 8, "align": 4}}, "caches": [32]}).
+Target description is used instead of target_compiler and target_architecture for platforms
+unknown to go types (like tinygo targets), its caches override target_cpu_cache_lines_sizes.
+		`,
+	)
 	// set target_cpu_cache_lines_sizes flag
-	cli.Flags().IntSliceVarP(
+	cli.Flags().StringSliceVarP(
 		&tcpulines,
 		"target_cpu_cache_lines_sizes",
 		"l",
-		[]int{64, 64, 64},
+		[]string{"64", "64", "64"},
 		`
 Gopium target platform CPU cache line sizes in bytes, cache line size is set one by one l1,l2,l3,...
 For now only 3 lines of cache are supported by strategies.
+Use auto to detect host CPU caches hierarchy (line size, size, associativity) from linux sysfs.
+		`,
+	)
+	// set target_interference_sizes flag
+	cli.Flags().IntSliceVarP(
+		&tinterferences,
+		"target_interference_sizes",
+		"n",
+		[]int{},
+		`
+Gopium target platform hardware interference sizes in bytes, sizes are set as destructive,constructive.
+By default interference sizes are defined by target platform architecture.
+		`,
+	)
+	// set target_variants_architectures flag
+	cli.Flags().StringSliceVarP(
+		&tvarchs,
+		"target_variants_architectures",
+		"x",
+		[]string{},
+		`
+Gopium target platform architectures for ast_go_arch walker, like: amd64,arm64,386.
+Structs which results differ across the architectures are moved to per architecture build constrained files.
+		`,
+	)
+	// set target_portable_architectures flag
+	cli.Flags().StringSliceVarP(
+		&tparchs,
+		"target_portable_architectures",
+		"y",
+		[]string{},
+		`
+Gopium target platform portable architectures for memory_pack_portable strategies, like: arm,386.
+Structs fields orders are evaluated on the target architecture and on all of the portable architectures.
 		`,
 	)
 	// set package_path flag
@@ -219,11 +407,10 @@ For now only 3 lines of cache are supported by strategies.
 		&ppath,
 		"package_path",
 		"p",
-		filepath.Join("src", "{{package}}"),
+		"",
 		`
-Gopium go package path, either relative or absolute path to root of the package is expected.
-To obtain full path from relative, package path is concatenated with current GOPATH env var.
-Template {{package}} part is replaced with package name.
+Gopium go package path, either relative or absolute path to directory inside go module or workspace is expected.
+Package is resolved from this directory using go.mod and go.work metadata, by default current directory is used.
 		`,
 	)
 	// set package_build_envs flag
@@ -242,6 +429,39 @@ Template {{package}} part is replaced with package name.
 		[]string{},
 		"Gopium go package build flags, additional list of building flags is expected.",
 	)
+	// set package_overlay flag
+	cli.Flags().StringVarP(
+		&poverlay,
+		"package_overlay",
+		"o",
+		"",
+		`
+Gopium go package overlay, path to json encoded map of files paths to files contents is expected, - stands for stdin.
+Overlay contents are used instead of files contents on disk, useful for editors unsaved buffers.
+		`,
+	)
+	// set package_profile flag
+	cli.Flags().StringVarP(
+		&pprofile,
+		"package_profile",
+		"q",
+		"",
+		`
+Gopium go package profile, path to pprof profile (like go default.pgo cpu profile) is expected.
+Profile functions samples are attributed to structs fields accessed by these functions.
+		`,
+	)
+	// set package_platforms flag
+	cli.Flags().StringSliceVarP(
+		&pplatforms,
+		"package_platforms",
+		"m",
+		[]string{},
+		`
+Gopium go package platforms, list of goos/goarch platforms to load package for is expected (like linux/amd64,windows/386).
+Each package file is processed for the first platform that compiles it and results are merged per file.
+		`,
+	)
 	// set walker_regexp flag
 	cli.Flags().StringVarP(
 		&wregex,
@@ -275,6 +495,17 @@ Gopium walker backref flag, flag that defines type of names referencing.
 By default any previous visited types have affect on future relevant visits.
 		`,
 	)
+	// set walker_workers flag
+	cli.Flags().IntVarP(
+		&wworkers,
+		"walker_workers",
+		"j",
+		runtime.NumCPU(),
+		`
+Gopium walker workers, number of packages visited concurrently for multi packages patterns.
+Number of workers is unlimited if value isn't greater than 0.
+		`,
+	)
 	// set printer_indent flag
 	cli.Flags().IntVarP(
 		&pindent,
@@ -348,6 +579,13 @@ func main() {
 	// execute cobra cli command
 	// with ctx and log error if any
 	if err := cli.ExecuteContext(ctx); err != nil {
+		// in case runner provided
+		// distinct exit code use it
+		var eerr runners.ExitError
+		if errors.As(err, &eerr) {
+			log.Println(err)
+			os.Exit(eerr.Code)
+		}
 		log.Fatal(err)
 		os.Exit(1)
 	}
@@ -2,11 +2,12 @@ package runners
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"go/build"
 	"go/parser"
-	"path/filepath"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +20,29 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
+// CheckExitCode defines distinct cli exit code
+// that is used when check walker found any struct
+// that differs from strategies results
+const CheckExitCode = 3
+
+// ExitError defines cli runner error
+// that carries process exit code with it
+type ExitError struct {
+	Err  error   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Code int     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [8]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Error exit error implementation
+func (err ExitError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns underlying error back
+func (err ExitError) Unwrap() error {
+	return err.Err
+}
+
 // Cli defines cli runner implementation
 // that is able to run full gopium cli application
 type Cli struct {
@@ -35,18 +59,27 @@ type Cli struct {
 func NewCli(
 	// target platform vars
 	compiler,
-	arch string,
-	cpucaches []int,
+	arch,
+	target string,
+	cpucaches []string,
+	sysroot string,
+	interferences []int,
+	varchs,
+	parchs []string,
 	// package parser vars
 	pkg,
 	path string,
 	benvs,
 	bflags []string,
+	overlay,
+	profile string,
+	platforms []string,
 	// gopium walker vars
 	walker,
 	regex string,
 	deep,
 	backref bool,
+	workers int,
 	stgs []string,
 	// gopium printer vars
 	indent,
@@ -56,34 +89,95 @@ func NewCli(
 	// gopium global vars
 	timeout int,
 ) (*Cli, error) {
-	// cast caches to int64
-	caches := make([]int64, 0, len(cpucaches))
-	for _, cache := range cpucaches {
-		caches = append(caches, int64(cache))
+	// read cpu caches either
+	// from provided lines sizes
+	// or from host sysfs root for auto
+	caches, hierarchy, err := readCaches(cpucaches, sysroot)
+	if err != nil {
+		return nil, fmt.Errorf("can't read cpu caches %v", err)
+	}
+	// cast interference sizes to int64
+	// destructive size goes first
+	// and constructive size goes second
+	if len(interferences) > 2 {
+		return nil, fmt.Errorf("can't set up interference sizes %v only destructive and constructive sizes are expected", interferences)
+	}
+	var destructive, constructive int64
+	if len(interferences) > 0 {
+		destructive = int64(interferences[0])
+	}
+	if len(interferences) > 1 {
+		constructive = int64(interferences[1])
 	}
 	// set up maven
-	m, err := typepkg.NewMavenGoTypes(compiler, arch, caches...)
+	// portable maven is used
+	// only for portable target architectures
+	var m gopium.Maven
+	if target != "" {
+		// custom target description
+		// defines the only target architecture
+		if len(parchs) > 0 {
+			return nil, fmt.Errorf("can't set up maven target description %q can't be used with portable architectures", target)
+		}
+		mt, err := readTarget(target, caches...)
+		if err != nil {
+			return nil, fmt.Errorf("can't set up maven %v", err)
+		}
+		m = mt.Interference(destructive, constructive).Hierarchy(hierarchy...)
+	} else if len(parchs) > 0 {
+		mp, err := typepkg.NewMavenPortable(compiler, arch, parchs, caches...)
+		if err != nil {
+			return nil, fmt.Errorf("can't set up maven %v", err)
+		}
+		m = mp.Interference(destructive, constructive).Hierarchy(hierarchy...)
+	} else {
+		mg, err := typepkg.NewMavenGoTypes(compiler, arch, caches...)
+		if err != nil {
+			return nil, fmt.Errorf("can't set up maven %v", err)
+		}
+		m = mg.Interference(destructive, constructive).Hierarchy(hierarchy...)
+	}
+	// read editor overlay
+	// either from stdin or file
+	ovl, err := readOverlay(overlay)
 	if err != nil {
-		return nil, fmt.Errorf("can't set up maven %v", err)
+		return nil, fmt.Errorf("can't read overlay %v", err)
 	}
-	// replace package template
-	path = strings.Replace(path, "{{package}}", pkg, 1)
-	// set root to gopath only if
-	// not absolute path has been provided
-	var root string
-	if !filepath.IsAbs(path) {
-		root = build.Default.GOPATH
+	// read pprof profile
+	// from file if any
+	prof, err := readProfile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("can't read profile %v", err)
 	}
 	// set up parser
-	xp := &typepkg.ParserXToolPackagesAst{
+	// package is resolved from provided path
+	// using go modules and workspaces metadata
+	// or from current directory if path is empty
+	xp := typepkg.ParserXToolPackagesAst{
 		Pattern: pkg,
-		Root:    root,
 		Path:    path,
 		//nolint
 		ModeTypes:  packages.LoadAllSyntax,
 		ModeAst:    parser.ParseComments | parser.AllErrors,
 		BuildEnv:   benvs,
 		BuildFlags: bflags,
+		Overlay:    ovl,
+		Profile:    prof,
+	}
+	// use multi packages parser
+	// for multi packages patterns
+	// or multiple platforms
+	var xpp gopium.Parser = &xp
+	if typepkg.MultiPattern(pkg) || len(platforms) > 0 {
+		// only walkers that keep results per file
+		// could merge results of several platforms
+		if len(platforms) > 0 && !walkers.PerFile(gopium.WalkerName(walker)) {
+			return nil, fmt.Errorf("walker %q can't merge results of several platforms", walker)
+		}
+		xpp = &typepkg.ParserXToolPackagesAstMulti{
+			ParserXToolPackagesAst: xp,
+			Platforms:              platforms,
+		}
 	}
 	// set up printer
 	var p gopium.Printer
@@ -104,20 +198,59 @@ func NewCli(
 		regex:   cregex,
 		timeout: stimeout,
 	}
-	// set walker and strategy builders
-	wb := walkers.Builder{
-		Parser:  xp,
-		Exposer: m,
-		Printer: p,
-		Deep:    deep,
-		Bref:    backref,
-	}
-	sb := strategies.Builder{Curator: m}
 	// cast strategies strings to strategy names
 	snames := make([]gopium.StrategyName, 0, len(stgs))
 	for _, strategy := range stgs {
 		snames = append(snames, gopium.StrategyName(strategy))
 	}
+	// set up mavens options
+	// shared by variants target architectures
+	// and matrix platforms mavens
+	mopts := walkers.MavenOptions{
+		Caches:       caches,
+		Hierarchy:    hierarchy,
+		Destructive:  destructive,
+		Constructive: constructive,
+	}
+	// set up variants target architectures
+	// each with its own maven and strategy
+	// as strategies rely on curator
+	var targets []walkers.Target
+	seen := make(map[string]bool, len(varchs))
+	for _, varch := range varchs {
+		// skip duplicated architectures
+		if seen[varch] {
+			continue
+		}
+		seen[varch] = true
+		vm, err := mopts.Maven(compiler, varch)
+		if err != nil {
+			return nil, fmt.Errorf("can't set up maven %v", err)
+		}
+		vstg, err := strategies.Builder{Curator: vm, Interference: destructive > 0}.Build(snames...)
+		if err != nil {
+			return nil, fmt.Errorf("can't build such strategy %v %v", snames, err)
+		}
+		targets = append(targets, walkers.Target{
+			Exposer:  vm,
+			Strategy: vstg,
+			Arch:     varch,
+		})
+	}
+	// set walker and strategy builders
+	wb := walkers.Builder{
+		Parser:     xpp,
+		Exposer:    m,
+		Printer:    p,
+		Targets:    targets,
+		Strategies: snames,
+		Mavens:     mopts,
+		Warn:       os.Stderr,
+		Workers:    workers,
+		Deep:       deep,
+		Bref:       backref,
+	}
+	sb := strategies.Builder{Curator: m, Interference: destructive > 0}
 	// cast walker string to walker name
 	wname := gopium.WalkerName(walker)
 	// combine cli runner
@@ -130,6 +263,80 @@ func NewCli(
 	}, nil
 }
 
+// readCaches reads cpu caches lines sizes
+// from provided list, `auto` stands for
+// host cpu caches hierarchy from sysfs root
+func readCaches(cpucaches []string, sysroot string) ([]int64, []typepkg.SysCache, error) {
+	if len(cpucaches) == 1 && strings.TrimSpace(cpucaches[0]) == "auto" {
+		hierarchy, err := typepkg.ReadSysCaches(sysroot)
+		if err != nil {
+			return nil, nil, err
+		}
+		caches := make([]int64, 0, len(hierarchy))
+		for _, cache := range hierarchy {
+			caches = append(caches, cache.Line)
+		}
+		return caches, hierarchy, nil
+	}
+	// cast caches to int64
+	caches := make([]int64, 0, len(cpucaches))
+	for _, cache := range cpucaches {
+		size, err := strconv.ParseInt(strings.TrimSpace(cache), 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't parse cache line size %q %v", cache, err)
+		}
+		caches = append(caches, size)
+	}
+	return caches, nil, nil
+}
+
+// readTarget reads custom target description
+// from provided file and creates maven from it
+func readTarget(name string, caches ...int64) (typepkg.MavenGoTypes, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return typepkg.MavenGoTypes{}, err
+	}
+	defer f.Close()
+	t, err := typepkg.ReadTarget(f)
+	if err != nil {
+		return typepkg.MavenGoTypes{}, err
+	}
+	return typepkg.NewMavenTarget(t, caches...)
+}
+
+// readOverlay reads editor overlay from provided file,
+// `-` stands for stdin and empty name stands for no overlay
+func readOverlay(name string) (map[string][]byte, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "-":
+		return typepkg.ReadOverlay(os.Stdin)
+	default:
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return typepkg.ReadOverlay(f)
+	}
+}
+
+// readProfile reads pprof profile from provided file,
+// empty name stands for no profile
+func readProfile(name string) (map[string]int64, error) {
+	if name == "" {
+		return nil, nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return typepkg.ReadProfile(f)
+}
+
 // Run cli implementation
 func (cli *Cli) Run(ctx context.Context) error {
 	// build strategy
@@ -143,5 +350,14 @@ func (cli *Cli) Run(ctx context.Context) error {
 		return err
 	}
 	// run visitor visiting
-	return cli.v.visit(ctx, w, stg)
+	// and in case check walker
+	// found any difference
+	// wrap it with distinct exit code
+	if err := cli.v.visit(ctx, w, stg); err != nil {
+		if errors.Is(err, walkers.ErrCheck) {
+			return ExitError{Err: err, Code: CheckExitCode}
+		}
+		return err
+	}
+	return nil
 }
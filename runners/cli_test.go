@@ -2,10 +2,12 @@ package runners
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"go/build"
 	"go/parser"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"testing"
@@ -28,21 +30,94 @@ func TestNewCli(t *testing.T) {
 	if !reflect.DeepEqual(err, nil) {
 		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
 	}
+	m386, err := typepkg.NewMavenGoTypes("gc", "386", 2, 4, 8)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	mp, err := typepkg.NewMavenPortable("gc", "amd64", []string{"386", "arm"}, 2, 4, 8)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	stg, err := strategies.Builder{Curator: m}.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	stg386, err := strategies.Builder{Curator: m386}.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	ovldir := t.TempDir()
+	ovlpath := filepath.Join(ovldir, "overlay.json")
+	ovlfile := filepath.Join(ovldir, "file.go")
+	ovl, err := json.Marshal(map[string]string{ovlfile: "package test"})
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	if err := os.WriteFile(ovlpath, ovl, 0600); !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	prfpath := filepath.Join(tests.Gopium, "tests", "data", "coaccess", "default.pgo")
+	prffile, err := os.Open(prfpath)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	defer prffile.Close()
+	prf, err := typepkg.ReadProfile(prffile)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	mi := m.Interference(256, 32)
+	sysroot := filepath.Join(tests.Gopium, "tests", "data", "sysfs")
+	hierarchy, err := typepkg.ReadSysCaches(sysroot)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	ma, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	ma = ma.Hierarchy(hierarchy...)
+	tgtpath := filepath.Join(tests.Gopium, "tests", "data", "target", "avr.json")
+	tgtfile, err := os.Open(tgtpath)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	defer tgtfile.Close()
+	tgt, err := typepkg.ReadTarget(tgtfile)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	mt, err := typepkg.NewMavenTarget(tgt, 2, 4, 8)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	mopts := walkers.MavenOptions{Caches: []int64{2, 4, 8}}
+	moptsi := walkers.MavenOptions{Caches: []int64{2, 4, 8}, Destructive: 256, Constructive: 32}
+	moptsa := walkers.MavenOptions{Caches: []int64{64, 64, 64}, Hierarchy: hierarchy}
 	table := map[string]struct {
 		// target platform vars
-		compiler  string
-		arch      string
-		cpucaches []int
+		compiler      string
+		arch          string
+		target        string
+		cpucaches     []string
+		sysroot       string
+		interferences []int
+		varchs        []string
+		parchs        []string
 		// package parser vars
-		pkg    string
-		path   string
-		benvs  []string
-		bflags []string
+		pkg       string
+		path      string
+		benvs     []string
+		bflags    []string
+		overlay   string
+		profile   string
+		platforms []string
 		// walker vars
 		walker  string
 		regex   string
 		deep    bool
 		backref bool
+		workers int
 		stgs    []string
 		// printer vars
 		indent   int
@@ -59,11 +134,374 @@ func TestNewCli(t *testing.T) {
 			// target platform vars
 			compiler:  "gc",
 			arch:      "amd64",
-			cpucaches: []int{2, 4, 8},
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern: "test-pkg",
+						Path:    "test-path",
+						//nolint
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer:    m,
+					Printer:    fmtio.NewGoprinter(4, 4, true),
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return expected cli on valid parameters with interference sizes": {
+			// target platform vars
+			compiler:      "gc",
+			arch:          "amd64",
+			cpucaches:     []string{"2", "4", "8"},
+			interferences: []int{256, 32},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern: "test-pkg",
+						Path:    "test-path",
+						//nolint
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer:    mi,
+					Printer:    fmtio.NewGoprinter(4, 4, true),
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     moptsi,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
+				},
+				sb:     strategies.Builder{Curator: mi, Interference: true},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return expected cli on valid parameters with auto cpu caches": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"auto"},
+			sysroot:   sysroot,
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern: "test-pkg",
+						Path:    "test-path",
+						//nolint
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer:    ma,
+					Printer:    fmtio.NewGoprinter(4, 4, true),
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     moptsa,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
+				},
+				sb:     strategies.Builder{Curator: ma},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return expected cli on valid parameters with target description": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			target:    tgtpath,
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern: "test-pkg",
+						Path:    "test-path",
+						//nolint
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer:    mt,
+					Printer:    fmtio.NewGoprinter(4, 4, true),
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
+				},
+				sb:     strategies.Builder{Curator: mt},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return error on target description with portable architectures": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			target:    tgtpath,
+			cpucaches: []string{"2", "4", "8"},
+			parchs:    []string{"386"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: fmt.Errorf("can't set up maven target description %q can't be used with portable architectures", tgtpath),
+		},
+		"new cli should return error on missing target description": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			target:    "test-target",
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New("can't set up maven open test-target: no such file or directory"),
+		},
+		"new cli should return error on invalid cpu caches": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"64", "test"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New(`can't read cpu caches can't parse cache line size "test" strconv.ParseInt: parsing "test": invalid syntax`),
+		},
+		"new cli should return error on invalid interference sizes": {
+			// target platform vars
+			compiler:      "gc",
+			arch:          "amd64",
+			cpucaches:     []string{"2", "4", "8"},
+			interferences: []int{256, 32, 16},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New("can't set up interference sizes [256 32 16] only destructive and constructive sizes are expected"),
+		},
+		"new cli should return expected cli on valid parameters with gofmt": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			usegofmt: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern: "test-pkg",
+						Path:    "test-path",
+						//nolint
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer:    m,
+					Printer:    fmtio.Gofmt{},
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return expected cli on valid parameters with abs path": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
 			// package parser vars
 			pkg:    "test-pkg",
-			path:   "test-path",
-			benvs:  []string{},
+			path:   tests.OnOS("windows", "c:\\test-path", "/test-path").(string),
+			benvs:  []string{"env"},
 			bflags: []string{},
 			// walker vars
 			walker:  "test-w",
@@ -86,32 +524,34 @@ func TestNewCli(t *testing.T) {
 				wb: walkers.Builder{
 					Parser: &typepkg.ParserXToolPackagesAst{
 						Pattern: "test-pkg",
-						Root:    build.Default.GOPATH,
-						Path:    "test-path",
+						Path:    tests.OnOS("windows", "c:\\test-path", "/test-path").(string),
 						//nolint
 						ModeTypes:  packages.LoadAllSyntax,
 						ModeAst:    parser.ParseComments | parser.AllErrors,
-						BuildEnv:   []string{},
+						BuildEnv:   []string{"env"},
 						BuildFlags: []string{},
 					},
-					Exposer: m,
-					Printer: fmtio.NewGoprinter(4, 4, true),
-					Deep:    true,
-					Bref:    true,
+					Exposer:    m,
+					Printer:    fmtio.NewGoprinter(4, 4, true),
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
 				},
 				sb:     strategies.Builder{Curator: m},
 				wname:  "test-w",
 				snames: []gopium.StrategyName{"test-stg"},
 			},
 		},
-		"new cli should return expected cli on valid parameters with gofmt": {
+		"new cli should return expected cli on valid parameters with multi packages pattern": {
 			// target platform vars
 			compiler:  "gc",
 			arch:      "amd64",
-			cpucaches: []int{2, 4, 8},
+			cpucaches: []string{"2", "4", "8"},
 			// package parser vars
-			pkg:    "test-pkg",
-			path:   "test-path",
+			pkg:    "./...",
+			path:   "",
 			benvs:  []string{},
 			bflags: []string{},
 			// walker vars
@@ -119,11 +559,61 @@ func TestNewCli(t *testing.T) {
 			regex:   `.*`,
 			deep:    true,
 			backref: true,
+			workers: 4,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			usegofmt: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAstMulti{
+						ParserXToolPackagesAst: typepkg.ParserXToolPackagesAst{
+							Pattern: "./...",
+							//nolint
+							ModeTypes:  packages.LoadAllSyntax,
+							ModeAst:    parser.ParseComments | parser.AllErrors,
+							BuildEnv:   []string{},
+							BuildFlags: []string{},
+						},
+					},
+					Exposer:    m,
+					Printer:    fmtio.Gofmt{},
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Workers:    4,
+					Deep:       true,
+					Bref:       true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "test-w",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return expected cli on valid parameters with overlay": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:     "test-pkg",
+			path:    "test-path",
+			benvs:   []string{},
+			bflags:  []string{},
+			overlay: ovlpath,
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
 			stgs:    []string{"test-stg"},
 			// printer vars
-			indent:   4,
-			tabwidth: 4,
-			usespace: true,
 			usegofmt: true,
 			// global vars
 			timeout: 5,
@@ -136,34 +626,38 @@ func TestNewCli(t *testing.T) {
 				wb: walkers.Builder{
 					Parser: &typepkg.ParserXToolPackagesAst{
 						Pattern: "test-pkg",
-						Root:    build.Default.GOPATH,
 						Path:    "test-path",
 						//nolint
 						ModeTypes:  packages.LoadAllSyntax,
 						ModeAst:    parser.ParseComments | parser.AllErrors,
 						BuildEnv:   []string{},
 						BuildFlags: []string{},
+						Overlay:    map[string][]byte{ovlfile: []byte("package test")},
 					},
-					Exposer: m,
-					Printer: fmtio.Gofmt{},
-					Deep:    true,
-					Bref:    true,
+					Exposer:    m,
+					Printer:    fmtio.Gofmt{},
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
 				},
 				sb:     strategies.Builder{Curator: m},
 				wname:  "test-w",
 				snames: []gopium.StrategyName{"test-stg"},
 			},
 		},
-		"new cli should return expected cli on valid parameters with abs path": {
+		"new cli should return expected cli on valid parameters with profile": {
 			// target platform vars
 			compiler:  "gc",
 			arch:      "amd64",
-			cpucaches: []int{2, 4, 8},
+			cpucaches: []string{"2", "4", "8"},
 			// package parser vars
-			pkg:    "test-pkg",
-			path:   tests.OnOS("windows", "c:\\test-path", "/test-path").(string),
-			benvs:  []string{"env"},
-			bflags: []string{},
+			pkg:     "test-pkg",
+			path:    "test-path",
+			benvs:   []string{},
+			bflags:  []string{},
+			profile: prfpath,
 			// walker vars
 			walker:  "test-w",
 			regex:   `.*`,
@@ -171,9 +665,7 @@ func TestNewCli(t *testing.T) {
 			backref: true,
 			stgs:    []string{"test-stg"},
 			// printer vars
-			indent:   4,
-			tabwidth: 4,
-			usespace: true,
+			usegofmt: true,
 			// global vars
 			timeout: 5,
 			// test vars
@@ -185,28 +677,323 @@ func TestNewCli(t *testing.T) {
 				wb: walkers.Builder{
 					Parser: &typepkg.ParserXToolPackagesAst{
 						Pattern: "test-pkg",
-						Path:    tests.OnOS("windows", "c:\\test-path", "/test-path").(string),
+						Path:    "test-path",
 						//nolint
 						ModeTypes:  packages.LoadAllSyntax,
 						ModeAst:    parser.ParseComments | parser.AllErrors,
-						BuildEnv:   []string{"env"},
+						BuildEnv:   []string{},
 						BuildFlags: []string{},
+						Profile:    prf,
 					},
-					Exposer: m,
-					Printer: fmtio.NewGoprinter(4, 4, true),
-					Deep:    true,
-					Bref:    true,
+					Exposer:    m,
+					Printer:    fmtio.Gofmt{},
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
 				},
 				sb:     strategies.Builder{Curator: m},
 				wname:  "test-w",
 				snames: []gopium.StrategyName{"test-stg"},
 			},
 		},
+		"new cli should return expected cli on valid parameters with platforms": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:       "test-pkg",
+			path:      "test-path",
+			benvs:     []string{},
+			bflags:    []string{},
+			platforms: []string{"linux/amd64", "windows/386"},
+			// walker vars
+			walker:  "check",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			usegofmt: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAstMulti{
+						ParserXToolPackagesAst: typepkg.ParserXToolPackagesAst{
+							Pattern: "test-pkg",
+							Path:    "test-path",
+							//nolint
+							ModeTypes:  packages.LoadAllSyntax,
+							ModeAst:    parser.ParseComments | parser.AllErrors,
+							BuildEnv:   []string{},
+							BuildFlags: []string{},
+						},
+						Platforms: []string{"linux/amd64", "windows/386"},
+					},
+					Exposer:    m,
+					Printer:    fmtio.Gofmt{},
+					Strategies: []gopium.StrategyName{"test-stg"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "check",
+				snames: []gopium.StrategyName{"test-stg"},
+			},
+		},
+		"new cli should return error on platforms with non per file walker": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:       "test-pkg",
+			path:      "test-path",
+			benvs:     []string{},
+			bflags:    []string{},
+			platforms: []string{"linux/amd64", "windows/386"},
+			// walker vars
+			walker:  "file_json",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New(`walker "file_json" can't merge results of several platforms`),
+		},
+		"new cli should return expected cli on valid parameters with target architectures": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			varchs:    []string{"amd64", "386", "amd64"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "ast_go_arch",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"memory_pack"},
+			// printer vars
+			usegofmt: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern: "test-pkg",
+						Path:    "test-path",
+						//nolint
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer:    m,
+					Printer:    fmtio.Gofmt{},
+					Strategies: []gopium.StrategyName{"memory_pack"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Targets: []walkers.Target{
+						{Exposer: m, Strategy: stg, Arch: "amd64"},
+						{Exposer: m386, Strategy: stg386, Arch: "386"},
+					},
+					Deep: true,
+					Bref: true,
+				},
+				sb:     strategies.Builder{Curator: m},
+				wname:  "ast_go_arch",
+				snames: []gopium.StrategyName{"memory_pack"},
+			},
+		},
+		"new cli should return error on invalid target architecture": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			varchs:    []string{"amd64", "64amd64"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker: "ast_go_arch",
+			regex:  `.*`,
+			stgs:   []string{"memory_pack"},
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New(`can't set up maven unsuported compiler "gc" arch "64amd64" combination`),
+		},
+		"new cli should return error on invalid target architecture strategy": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			varchs:    []string{"386"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker: "ast_go_arch",
+			regex:  `.*`,
+			stgs:   []string{"test-stg"},
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New(`can't build such strategy [test-stg] strategy "test-stg" wasn't found`),
+		},
+		"new cli should return expected cli on valid parameters with portable architectures": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			parchs:    []string{"386", "amd64", "arm"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "ast_std",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"memory_pack_portable"},
+			// printer vars
+			usegofmt: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			cli: &Cli{
+				v: visitor{
+					regex:   regexp.MustCompile(`.*`),
+					timeout: 5 * time.Second,
+				},
+				wb: walkers.Builder{
+					Parser: &typepkg.ParserXToolPackagesAst{
+						Pattern: "test-pkg",
+						Path:    "test-path",
+						//nolint
+						ModeTypes:  packages.LoadAllSyntax,
+						ModeAst:    parser.ParseComments | parser.AllErrors,
+						BuildEnv:   []string{},
+						BuildFlags: []string{},
+					},
+					Exposer:    mp,
+					Printer:    fmtio.Gofmt{},
+					Strategies: []gopium.StrategyName{"memory_pack_portable"},
+					Mavens:     mopts,
+					Warn:       os.Stderr,
+					Deep:       true,
+					Bref:       true,
+				},
+				sb:     strategies.Builder{Curator: mp},
+				wname:  "ast_std",
+				snames: []gopium.StrategyName{"memory_pack_portable"},
+			},
+		},
+		"new cli should return error on invalid portable architecture": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			parchs:    []string{"386", "64amd64"},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker: "ast_std",
+			regex:  `.*`,
+			stgs:   []string{"memory_pack_portable"},
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New(`can't set up maven unsuported compiler "gc" arch "64amd64" combination`),
+		},
+		"new cli should return error on missing overlay": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:     "test-pkg",
+			path:    "test-path",
+			benvs:   []string{},
+			bflags:  []string{},
+			overlay: filepath.Join(ovldir, "missing.json"),
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// global vars
+			timeout: 5,
+			// test vars
+			err: tests.OnOS(
+				"windows",
+				fmt.Errorf("can't read overlay open %s: The system cannot find the file specified.", filepath.Join(ovldir, "missing.json")),
+				fmt.Errorf("can't read overlay open %s: no such file or directory", filepath.Join(ovldir, "missing.json")),
+			).(error),
+		},
+		"new cli should return error on missing profile": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []string{"2", "4", "8"},
+			// package parser vars
+			pkg:     "test-pkg",
+			path:    "test-path",
+			benvs:   []string{},
+			bflags:  []string{},
+			profile: filepath.Join(ovldir, "missing.pgo"),
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// global vars
+			timeout: 5,
+			// test vars
+			err: tests.OnOS(
+				"windows",
+				fmt.Errorf("can't read profile open %s: The system cannot find the file specified.", filepath.Join(ovldir, "missing.pgo")),
+				fmt.Errorf("can't read profile open %s: no such file or directory", filepath.Join(ovldir, "missing.pgo")),
+			).(error),
+		},
 		"new cli should return error on invalid compiler arch combination": {
 			// target platform vars
 			compiler:  "cg",
 			arch:      "64amd64",
-			cpucaches: []int{2, 4, 8},
+			cpucaches: []string{"2", "4", "8"},
 			// package parser vars
 			pkg:    "test-pkg",
 			path:   "test-path",
@@ -231,7 +1018,7 @@ func TestNewCli(t *testing.T) {
 			// target platform vars
 			compiler:  "gc",
 			arch:      "amd64",
-			cpucaches: []int{2, 4, 8},
+			cpucaches: []string{"2", "4", "8"},
 			// package parser vars
 			pkg:    "test-pkg",
 			path:   "test-path",
@@ -259,15 +1046,24 @@ func TestNewCli(t *testing.T) {
 			cli, err := NewCli(
 				tcase.compiler,
 				tcase.arch,
+				tcase.target,
 				tcase.cpucaches,
+				tcase.sysroot,
+				tcase.interferences,
+				tcase.varchs,
+				tcase.parchs,
 				tcase.pkg,
 				tcase.path,
 				tcase.benvs,
 				tcase.bflags,
+				tcase.overlay,
+				tcase.profile,
+				tcase.platforms,
 				tcase.walker,
 				tcase.regex,
 				tcase.deep,
 				tcase.backref,
+				tcase.workers,
 				tcase.stgs,
 				tcase.indent,
 				tcase.tabwidth,
@@ -289,8 +1085,9 @@ func TestNewCli(t *testing.T) {
 func TestCliRun(t *testing.T) {
 	// prepare
 	table := map[string]struct {
-		cli *Cli
-		err error
+		cli  *Cli
+		code int
+		err  error
 	}{
 		"cli should return error on strategy builder error": {
 			cli: &Cli{
@@ -323,6 +1120,15 @@ func TestCliRun(t *testing.T) {
 			},
 			err: errors.New("visiting error happened context deadline exceeded"),
 		},
+		"cli should return exit error on check walker difference": {
+			cli: &Cli{
+				v:  visitor{},
+				sb: mocks.StrategyBuilder{Strategy: &mocks.Strategy{}},
+				wb: mocks.WalkerBuilder{Walker: mocks.Walker{Err: walkers.ErrCheck}},
+			},
+			code: CheckExitCode,
+			err:  errors.New("visiting error happened structs layouts differ from strategy results"),
+		},
 		"cli should return expected results on visiting": {
 			cli: &Cli{
 				v:  visitor{},
@@ -339,6 +1145,13 @@ func TestCliRun(t *testing.T) {
 			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
 				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
 			}
+			var code int
+			if eerr, ok := err.(ExitError); ok {
+				code = eerr.Code
+			}
+			if !reflect.DeepEqual(code, tcase.code) {
+				t.Errorf("actual %v doesn't equal to expected %v", code, tcase.code)
+			}
 		})
 	}
 }
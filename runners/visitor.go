@@ -52,7 +52,7 @@ func (v visitor) visit(ctx context.Context, w gopium.Walker, stg gopium.Strategy
 	}
 	// exec visit on walker with strategy
 	if err := w.Visit(ctx, v.regex, stg); err != nil {
-		return fmt.Errorf("visiting error happened %v", err)
+		return fmt.Errorf("visiting error happened %w", err)
 	}
 	return nil
 }
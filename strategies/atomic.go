@@ -0,0 +1,97 @@
+package strategies
+
+import (
+	"context"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of atomic presets
+var (
+	atom64 = atomic64{}
+)
+
+// atomic64 defines strategy implementation
+// that guarantees 8 bytes alignment of structure
+// fields accessed by 64-bit atomic operations
+// or having 64-bit atomic types, which is required
+// by sync/atomic on 32-bit platforms, structures
+// with all atomic fields aligned are kept intact,
+// otherwise atomic fields are placed first and
+// explicitly aligned with paddings if needed,
+// structure size is then rounded to 8 bytes
+// so atomic fields stay aligned inside arrays
+// note: first word of allocated structure
+// is relied on to be 8 bytes aligned
+type atomic64 struct{}
+
+// Apply atomic64 implementation
+func (stg atomic64) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// in case structure has no fields
+	// or no atomic fields just skip it
+	a := gopium.AtomicAccessed(ctx)
+	if !stg.atomic(a, r.Fields) {
+		return r, ctx.Err()
+	}
+	// in case any atomic field is misaligned
+	// place atomic fields first keeping their order
+	// and pad them to 8 bytes alignment if needed
+	if !stg.aligned(a, r) {
+		atomics := make([]gopium.Field, 0, len(r.Fields))
+		rest := make([]gopium.Field, 0, len(r.Fields))
+		for _, f := range r.Fields {
+			if a[f.Name] == 64 {
+				atomics = append(atomics, f)
+				continue
+			}
+			rest = append(rest, f)
+		}
+		var offset int64
+		r.Fields = make([]gopium.Field, 0, len(r.Fields)+len(atomics))
+		for _, f := range atomics {
+			// pad current offset to 8 bytes
+			// before misaligned atomic field
+			if pad := collections.Align(offset, 8) - offset; pad > 0 {
+				r.Fields = append(r.Fields, collections.PadField(pad))
+				offset += pad
+			}
+			if f.Align > 0 {
+				offset = collections.Align(offset, f.Align)
+			}
+			offset += f.Size
+			r.Fields = append(r.Fields, f)
+		}
+		r.Fields = append(r.Fields, rest...)
+	}
+	// round structure size to 8 bytes
+	// so that atomic fields stay aligned
+	// inside arrays and outer structures
+	if size, _, _ := collections.SizeAlignPtr(r); size%8 != 0 {
+		r.Fields = append(r.Fields, collections.PadField(collections.Align(size, 8)-size))
+	}
+	return r, ctx.Err()
+}
+
+// atomic checks if any of fields is 64-bit atomic
+func (stg atomic64) atomic(a gopium.Atomics, fields []gopium.Field) bool {
+	for _, f := range fields {
+		if a[f.Name] == 64 {
+			return true
+		}
+	}
+	return false
+}
+
+// aligned checks if all structure 64-bit
+// atomic fields are 8 bytes aligned
+func (stg atomic64) aligned(a gopium.Atomics, st gopium.Struct) bool {
+	for _, f := range collections.OffsetStruct(st).Fields {
+		if a[f.Name] == 64 && f.Offset%8 != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,283 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestAtomic64(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a := gopium.Atomics{
+		"hits":  64,
+		"inner": 64,
+		"a":     32,
+	}
+	table := map[string]struct {
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to empty struct": {
+			ctx: gopium.WithAtomics(context.Background(), a),
+		},
+		"struct without atomic fields should be applied to itself": {
+			ctx: gopium.WithAtomics(context.Background(), a),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct with aligned atomic fields should be applied to itself": {
+			ctx: gopium.WithAtomics(context.Background(), a),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "a",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "b",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "a",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "b",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct with aligned atomic fields should be applied to size rounded struct": {
+			ctx: gopium.WithAtomics(context.Background(), a),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "_",
+						Type:  "[4]byte",
+						Size:  4,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with misaligned atomic fields should be applied to aligned struct": {
+			ctx: gopium.WithAtomics(context.Background(), a),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "inner",
+						Type:  "Inner",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "b",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "inner",
+						Type:  "Inner",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "_",
+						Type:  "[4]byte",
+						Size:  4,
+						Align: 1,
+					},
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct with misaligned atomic fields should be applied to aligned struct on canceled context": {
+			ctx: gopium.WithAtomics(cctx, a),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "_",
+						Type:  "[4]byte",
+						Size:  4,
+						Align: 1,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := atom64.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
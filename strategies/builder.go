@@ -11,16 +11,32 @@ import (
 // list of registered strategies names
 const (
 	// pack/unpack mem util
-	Pack   gopium.StrategyName = "memory_pack"
-	Unpack gopium.StrategyName = "memory_unpack"
+	Pack       gopium.StrategyName = "memory_pack"
+	Unpack     gopium.StrategyName = "memory_unpack"
+	PackPort   gopium.StrategyName = "memory_pack_portable"
+	PackPortW  gopium.StrategyName = "memory_pack_portable_worst"
+	PackOpt    gopium.StrategyName = "memory_pack_optimal"
+	PackCoAcc  gopium.StrategyName = "memory_pack_coaccess"
+	PackCoAccT gopium.StrategyName = "memory_pack_coaccess_tolerance_bytes_%d"
+	// profile guided layouts
+	HotCold   gopium.StrategyName = "hot_cold_profile"
+	SplitCold gopium.StrategyName = "split_cold"
 	// explicit sys/type pads
 	PadSys  gopium.StrategyName = "explicit_paddings_system_alignment"
 	PadTnat gopium.StrategyName = "explicit_paddings_type_natural"
+	// atomic alignment guards
+	Atomic64 gopium.StrategyName = "atomic_align_64"
 	// false sharing guards
-	FShareL1 gopium.StrategyName = "false_sharing_cpu_l1"
-	FShareL2 gopium.StrategyName = "false_sharing_cpu_l2"
-	FShareL3 gopium.StrategyName = "false_sharing_cpu_l3"
-	FShareB  gopium.StrategyName = "false_sharing_bytes_%d"
+	FShareL1  gopium.StrategyName = "false_sharing_cpu_l1"
+	FShareL2  gopium.StrategyName = "false_sharing_cpu_l2"
+	FShareL3  gopium.StrategyName = "false_sharing_cpu_l3"
+	FShareB   gopium.StrategyName = "false_sharing_bytes_%d"
+	FShareCL1 gopium.StrategyName = "false_sharing_contended_cpu_l1"
+	FShareCL2 gopium.StrategyName = "false_sharing_contended_cpu_l2"
+	FShareCL3 gopium.StrategyName = "false_sharing_contended_cpu_l3"
+	FShareCB  gopium.StrategyName = "false_sharing_contended_bytes_%d"
+	FShareD   gopium.StrategyName = "false_sharing_destructive_interference"
+	FShareCD  gopium.StrategyName = "false_sharing_contended_destructive_interference"
 	// cache line pad roundings
 	CacheL1D gopium.StrategyName = "cache_rounding_cpu_l1_discrete"
 	CacheL2D gopium.StrategyName = "cache_rounding_cpu_l2_discrete"
@@ -30,6 +46,8 @@ const (
 	CacheL2F gopium.StrategyName = "cache_rounding_cpu_l2_full"
 	CacheL3F gopium.StrategyName = "cache_rounding_cpu_l3_full"
 	CacheBF  gopium.StrategyName = "cache_rounding_bytes_%d_full"
+	CacheCD  gopium.StrategyName = "cache_rounding_constructive_interference_discrete"
+	CacheCF  gopium.StrategyName = "cache_rounding_constructive_interference_full"
 	// top, bottom separate pads
 	SepSysT gopium.StrategyName = "separate_padding_system_alignment_top"
 	SepSysB gopium.StrategyName = "separate_padding_system_alignment_bottom"
@@ -41,6 +59,8 @@ const (
 	SepL2B  gopium.StrategyName = "separate_padding_cpu_l2_bottom"
 	SepL3B  gopium.StrategyName = "separate_padding_cpu_l3_bottom"
 	SepBB   gopium.StrategyName = "separate_padding_bytes_%d_bottom"
+	SepDT   gopium.StrategyName = "separate_padding_destructive_interference_top"
+	SepDB   gopium.StrategyName = "separate_padding_destructive_interference_bottom"
 	// tag processors and modifiers
 	ProcTag  gopium.StrategyName = "process_tag_group"
 	AddTagS  gopium.StrategyName = "add_tag_group_soft"
@@ -53,6 +73,7 @@ const (
 	FNoteCom  gopium.StrategyName = "fields_annotate_comment"
 	StNoteDoc gopium.StrategyName = "struct_annotate_doc"
 	StNoteCom gopium.StrategyName = "struct_annotate_comment"
+	SClassFit gopium.StrategyName = "size_class_fit"
 	// lexicographical, length, embedded, exported sorts
 	NLexAsc  gopium.StrategyName = "name_lexicographical_ascending"
 	NLexDesc gopium.StrategyName = "name_lexicographical_descending"
@@ -64,10 +85,15 @@ const (
 )
 
 // Builder defines types gopium.StrategyBuilder implementation
-// that uses gopium.Curator as an exposer and related strategies
+// that uses gopium.Curator as an exposer and related strategies,
+// interference flag makes cpu cache lines false sharing
+// and separate padding strategies use curator
+// destructive interference size instead of cache lines sizes
 type Builder struct {
-	Curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+	Curator      gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Interference bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_            [15]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
 
 // Build Builder implementation
 func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
@@ -82,24 +108,62 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 			stg = pck
 		case b.marchp(name, Unpack):
 			stg = unpck
+		case b.marchp(name, PackPort):
+			stg = pckport
+		case b.marchp(name, PackPortW):
+			stg = pckportw
+		case b.marchp(name, PackOpt):
+			stg = pckopt
+		case b.marchp(name, PackCoAcc):
+			stg = pckcoacc.Curator(b.Curator)
+		case b.marchp(name, PackCoAccT):
+			var bytes uint
+			if err := b.scanp(name, PackCoAccT, &bytes); err != nil {
+				return nil, err
+			}
+			stg = pckcoacc.Bytes(bytes).Curator(b.Curator)
+		// profile guided layouts
+		case b.marchp(name, HotCold):
+			stg = hcold.Curator(b.Curator)
+		case b.marchp(name, SplitCold):
+			stg = splcold.Curator(b.Curator)
 		// explicit sys/type pads
 		case b.marchp(name, PadSys):
 			stg = padsys.Curator(b.Curator)
 		case b.marchp(name, PadTnat):
 			stg = padtnat.Curator(b.Curator)
+		// atomic alignment guards
+		case b.marchp(name, Atomic64):
+			stg = atom64
 		// false sharing guards
 		case b.marchp(name, FShareL1):
-			stg = fsharel1.Curator(b.Curator)
+			stg = fsharel1.Curator(b.destructive())
 		case b.marchp(name, FShareL2):
-			stg = fsharel2.Curator(b.Curator)
+			stg = fsharel2.Curator(b.destructive())
 		case b.marchp(name, FShareL3):
-			stg = fsharel3.Curator(b.Curator)
+			stg = fsharel3.Curator(b.destructive())
 		case b.marchp(name, FShareB):
 			var bytes uint
 			if err := b.scanp(name, FShareB, &bytes); err != nil {
 				return nil, err
 			}
 			stg = fshareb.Bytes(bytes).Curator(b.Curator)
+		case b.marchp(name, FShareCL1):
+			stg = contendl1.Curator(b.destructive())
+		case b.marchp(name, FShareCL2):
+			stg = contendl2.Curator(b.destructive())
+		case b.marchp(name, FShareCL3):
+			stg = contendl3.Curator(b.destructive())
+		case b.marchp(name, FShareCB):
+			var bytes uint
+			if err := b.scanp(name, FShareCB, &bytes); err != nil {
+				return nil, err
+			}
+			stg = contendb.Bytes(bytes).Curator(b.Curator)
+		case b.marchp(name, FShareD):
+			stg = fsharel1.Curator(interference{Curator: b.Curator, destructive: true})
+		case b.marchp(name, FShareCD):
+			stg = contendl1.Curator(interference{Curator: b.Curator, destructive: true})
 		// cache line pad roundings
 		case b.marchp(name, CacheL1D):
 			stg = cachel1d.Curator(b.Curator)
@@ -125,17 +189,21 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 				return nil, err
 			}
 			stg = cachebf.Bytes(bytes).Curator(b.Curator)
+		case b.marchp(name, CacheCD):
+			stg = cachel1d.Curator(interference{Curator: b.Curator})
+		case b.marchp(name, CacheCF):
+			stg = cachel1f.Curator(interference{Curator: b.Curator})
 		// top, bottom separate pads
 		case b.marchp(name, SepSysT):
 			stg = sepsyst.Curator(b.Curator)
 		case b.marchp(name, SepSysB):
 			stg = sepsysb.Curator(b.Curator)
 		case b.marchp(name, SepL1T):
-			stg = sepl1t.Curator(b.Curator)
+			stg = sepl1t.Curator(b.destructive())
 		case b.marchp(name, SepL2T):
-			stg = sepl2t.Curator(b.Curator)
+			stg = sepl2t.Curator(b.destructive())
 		case b.marchp(name, SepL3T):
-			stg = sepl3t.Curator(b.Curator)
+			stg = sepl3t.Curator(b.destructive())
 		case b.marchp(name, SepBT):
 			var bytes uint
 			if err := b.scanp(name, SepBT, &bytes); err != nil {
@@ -143,17 +211,21 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 			}
 			stg = sepbt.Bytes(bytes).Curator(b.Curator)
 		case b.marchp(name, SepL1B):
-			stg = sepl1b.Curator(b.Curator)
+			stg = sepl1b.Curator(b.destructive())
 		case b.marchp(name, SepL2B):
-			stg = sepl2b.Curator(b.Curator)
+			stg = sepl2b.Curator(b.destructive())
 		case b.marchp(name, SepL3B):
-			stg = sepl3b.Curator(b.Curator)
+			stg = sepl3b.Curator(b.destructive())
 		case b.marchp(name, SepBB):
 			var bytes uint
 			if err := b.scanp(name, SepBB, &bytes); err != nil {
 				return nil, err
 			}
 			stg = sepbb.Bytes(bytes).Curator(b.Curator)
+		case b.marchp(name, SepDT):
+			stg = sepl1t.Curator(interference{Curator: b.Curator, destructive: true})
+		case b.marchp(name, SepDB):
+			stg = sepl1b.Curator(interference{Curator: b.Curator, destructive: true})
 		// tag processors and modifiers
 		case b.marchp(name, ProcTag):
 			stg = ptag.Builder(b)
@@ -176,6 +248,8 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 			stg = stnotedoc
 		case b.marchp(name, StNoteCom):
 			stg = stnotecom
+		case b.marchp(name, SClassFit):
+			stg = scfit
 		// lexicographical, length, embedded, exported sorts
 		case b.marchp(name, NLexAsc):
 			stg = nlexasc
@@ -199,6 +273,17 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 	return p, nil
 }
 
+// destructive returns builder curator
+// that exposes destructive interference size
+// as cache line size of any level if builder
+// interference flag is set, otherwise builder curator
+func (b Builder) destructive() gopium.Curator {
+	if b.Interference {
+		return interference{Curator: b.Curator, destructive: true}
+	}
+	return b.Curator
+}
+
 // marchp checks if strahtegy name matches pattern
 func (b Builder) marchp(name gopium.StrategyName, pattern gopium.StrategyName) bool {
 	// for matching we need to use regex
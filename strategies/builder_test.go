@@ -1,11 +1,13 @@
 package strategies
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"testing"
 
+	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/tests/mocks"
 )
@@ -27,6 +29,39 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{Unpack},
 			stg:   pipe([]gopium.Strategy{unpck}),
 		},
+		"`memory_pack_portable` name should return expected strategy": {
+			names: []gopium.StrategyName{PackPort},
+			stg:   pipe([]gopium.Strategy{pckport}),
+		},
+		"`memory_pack_portable_worst` name should return expected strategy": {
+			names: []gopium.StrategyName{PackPortW},
+			stg:   pipe([]gopium.Strategy{pckportw}),
+		},
+		"`memory_pack_optimal` name should return expected strategy": {
+			names: []gopium.StrategyName{PackOpt},
+			stg:   pipe([]gopium.Strategy{pckopt}),
+		},
+		"`memory_pack_coaccess` name should return expected strategy": {
+			names: []gopium.StrategyName{PackCoAcc},
+			stg:   pipe([]gopium.Strategy{pckcoacc.Curator(b.Curator)}),
+		},
+		"`memory_pack_coaccess_tolerance_bytes_8` name should return expected strategy": {
+			names: []gopium.StrategyName{"memory_pack_coaccess_tolerance_bytes_8"},
+			stg:   pipe([]gopium.Strategy{pckcoacc.Bytes(8).Curator(b.Curator)}),
+		},
+		"`memory_pack_coaccess_tolerance_bytes_err` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_coaccess_tolerance_bytes_err"},
+			err:   errors.New(`pattern "memory_pack_coaccess_tolerance_bytes_%d" can't be scanned for strategy "memory_pack_coaccess_tolerance_bytes_err" expected integer`),
+		},
+		// profile guided layouts
+		"`hot_cold_profile` name should return expected strategy": {
+			names: []gopium.StrategyName{HotCold},
+			stg:   pipe([]gopium.Strategy{hcold.Curator(b.Curator)}),
+		},
+		"`split_cold` name should return expected strategy": {
+			names: []gopium.StrategyName{SplitCold},
+			stg:   pipe([]gopium.Strategy{splcold.Curator(b.Curator)}),
+		},
 		// explicit sys/type pads
 		"`explicit_paddings_system_alignment` name should return expected strategy": {
 			names: []gopium.StrategyName{PadSys},
@@ -36,6 +71,11 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{PadTnat},
 			stg:   pipe([]gopium.Strategy{padtnat.Curator(b.Curator)}),
 		},
+		// atomic alignment guards
+		"`atomic_align_64` name should return expected strategy": {
+			names: []gopium.StrategyName{Atomic64},
+			stg:   pipe([]gopium.Strategy{atom64}),
+		},
 		// false sharing guards
 		"`false_sharing_cpu_l1` name should return expected strategy": {
 			names: []gopium.StrategyName{FShareL1},
@@ -61,6 +101,34 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{"false_sharing_bytes_err"},
 			err:   errors.New(`pattern "false_sharing_bytes_%d" can't be scanned for strategy "false_sharing_bytes_err" expected integer`),
 		},
+		"`false_sharing_contended_cpu_l1` name should return expected strategy": {
+			names: []gopium.StrategyName{FShareCL1},
+			stg:   pipe([]gopium.Strategy{contendl1.Curator(b.Curator)}),
+		},
+		"`false_sharing_contended_cpu_l2` name should return expected strategy": {
+			names: []gopium.StrategyName{FShareCL2},
+			stg:   pipe([]gopium.Strategy{contendl2.Curator(b.Curator)}),
+		},
+		"`false_sharing_contended_cpu_l3` name should return expected strategy": {
+			names: []gopium.StrategyName{FShareCL3},
+			stg:   pipe([]gopium.Strategy{contendl3.Curator(b.Curator)}),
+		},
+		"`false_sharing_contended_bytes_32` name should return expected strategy": {
+			names: []gopium.StrategyName{"false_sharing_contended_bytes_32"},
+			stg:   pipe([]gopium.Strategy{contendb.Bytes(32).Curator(b.Curator)}),
+		},
+		"`false_sharing_contended_bytes_err` name should return expected error": {
+			names: []gopium.StrategyName{"false_sharing_contended_bytes_err"},
+			err:   errors.New(`pattern "false_sharing_contended_bytes_%d" can't be scanned for strategy "false_sharing_contended_bytes_err" expected integer`),
+		},
+		"`false_sharing_destructive_interference` name should return expected strategy": {
+			names: []gopium.StrategyName{FShareD},
+			stg:   pipe([]gopium.Strategy{fsharel1.Curator(interference{Curator: b.Curator, destructive: true})}),
+		},
+		"`false_sharing_contended_destructive_interference` name should return expected strategy": {
+			names: []gopium.StrategyName{FShareCD},
+			stg:   pipe([]gopium.Strategy{contendl1.Curator(interference{Curator: b.Curator, destructive: true})}),
+		},
 		// cache line pad roundings
 		"`cache_rounding_cpu_l1_discrete` name should return expected strategy": {
 			names: []gopium.StrategyName{CacheL1D},
@@ -102,6 +170,14 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{"cache_rounding_bytes_err_full"},
 			err:   errors.New(`pattern "cache_rounding_bytes_%d_full" can't be scanned for strategy "cache_rounding_bytes_err_full" expected integer`),
 		},
+		"`cache_rounding_constructive_interference_discrete` name should return expected strategy": {
+			names: []gopium.StrategyName{CacheCD},
+			stg:   pipe([]gopium.Strategy{cachel1d.Curator(interference{Curator: b.Curator})}),
+		},
+		"`cache_rounding_constructive_interference_full` name should return expected strategy": {
+			names: []gopium.StrategyName{CacheCF},
+			stg:   pipe([]gopium.Strategy{cachel1f.Curator(interference{Curator: b.Curator})}),
+		},
 		// top, bottom separate pads
 		"`separate_padding_system_alignment_top` name should return expected strategy": {
 			names: []gopium.StrategyName{SepSysT},
@@ -151,6 +227,14 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{"separate_padding_bytes_err_bottom"},
 			err:   errors.New(`pattern "separate_padding_bytes_%d_bottom" can't be scanned for strategy "separate_padding_bytes_err_bottom" expected integer`),
 		},
+		"`separate_padding_destructive_interference_top` name should return expected strategy": {
+			names: []gopium.StrategyName{SepDT},
+			stg:   pipe([]gopium.Strategy{sepl1t.Curator(interference{Curator: b.Curator, destructive: true})}),
+		},
+		"`separate_padding_destructive_interference_bottom` name should return expected strategy": {
+			names: []gopium.StrategyName{SepDB},
+			stg:   pipe([]gopium.Strategy{sepl1b.Curator(interference{Curator: b.Curator, destructive: true})}),
+		},
 		// tag processors and modifiers
 		"`process_tag_group` name should return expected strategy": {
 			names: []gopium.StrategyName{ProcTag},
@@ -193,6 +277,10 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{StNoteCom},
 			stg:   pipe([]gopium.Strategy{stnotecom}),
 		},
+		"`size_class_fit` name should return expected strategy": {
+			names: []gopium.StrategyName{SClassFit},
+			stg:   pipe([]gopium.Strategy{scfit}),
+		},
 		// lexicographical, length, embedded, exported sorts
 		"`name_lexicographical_ascending` name should return expected strategy": {
 			names: []gopium.StrategyName{NLexAsc},
@@ -249,3 +337,128 @@ func TestBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilderInterference(t *testing.T) {
+	// prepare
+	m := mocks.Maven{SCache: []int64{64, 64, 64}, SDestr: 128, SConst: 32}
+	f := gopium.Field{Name: "mu", Type: "sync.Mutex", Size: 8, Align: 8}
+	o := gopium.Struct{Name: "test", Fields: []gopium.Field{f}}
+	table := map[string]struct {
+		b    Builder
+		name gopium.StrategyName
+		r    gopium.Struct
+	}{
+		"`false_sharing_cpu_l1` name should use cache line size without interference": {
+			b:    Builder{Curator: m},
+			name: FShareL1,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(56)}},
+		},
+		"`false_sharing_cpu_l1` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: FShareL1,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(120)}},
+		},
+		"`false_sharing_cpu_l2` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: FShareL2,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(120)}},
+		},
+		"`false_sharing_cpu_l3` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: FShareL3,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(120)}},
+		},
+		"`false_sharing_bytes_16` name should use custom bytes with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: "false_sharing_bytes_16",
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(8)}},
+		},
+		"`false_sharing_contended_cpu_l1` name should use cache line size without interference": {
+			b:    Builder{Curator: m},
+			name: FShareCL1,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(56)}},
+		},
+		"`false_sharing_contended_cpu_l1` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: FShareCL1,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(120)}},
+		},
+		"`false_sharing_contended_cpu_l2` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: FShareCL2,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(120)}},
+		},
+		"`false_sharing_contended_cpu_l3` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: FShareCL3,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(120)}},
+		},
+		"`separate_padding_cpu_l1_top` name should use cache line size without interference": {
+			b:    Builder{Curator: m},
+			name: SepL1T,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{collections.PadField(64), f}},
+		},
+		"`separate_padding_cpu_l1_top` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: SepL1T,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{collections.PadField(128), f}},
+		},
+		"`separate_padding_cpu_l2_top` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: SepL2T,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{collections.PadField(128), f}},
+		},
+		"`separate_padding_cpu_l3_top` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: SepL3T,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{collections.PadField(128), f}},
+		},
+		"`separate_padding_cpu_l1_bottom` name should use cache line size without interference": {
+			b:    Builder{Curator: m},
+			name: SepL1B,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(64)}},
+		},
+		"`separate_padding_cpu_l1_bottom` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: SepL1B,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(128)}},
+		},
+		"`separate_padding_cpu_l2_bottom` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: SepL2B,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(128)}},
+		},
+		"`separate_padding_cpu_l3_bottom` name should use destructive interference size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: SepL3B,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(128)}},
+		},
+		"`separate_padding_system_alignment_top` name should use system alignment with interference": {
+			b:    Builder{Curator: mocks.Maven{SAlign: 8, SDestr: 128}, Interference: true},
+			name: SepSysT,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{collections.PadField(8), f}},
+		},
+		"`cache_rounding_cpu_l1_full` name should use cache line size with interference": {
+			b:    Builder{Curator: m, Interference: true},
+			name: CacheL1F,
+			r:    gopium.Struct{Name: "test", Fields: []gopium.Field{f, collections.PadField(56)}},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			stg, err := tcase.b.Build(tcase.name)
+			if err != nil {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			r, err := stg.Apply(context.Background(), o)
+			// check
+			if tr := collections.OffsetStruct(tcase.r); !reflect.DeepEqual(r, tr) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tr)
+			}
+			if err != nil {
+				t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+			}
+		})
+	}
+}
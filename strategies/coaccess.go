@@ -0,0 +1,160 @@
+package strategies
+
+import (
+	"context"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of coaccess presets
+var (
+	pckcoacc = coaccess{}
+)
+
+// coaccess defines strategy implementation
+// that rearranges structure fields
+// accordingly to structure fields co-access graph
+// by grouping frequently co-accessed fields together
+// into groups that fit within cpu l1 cache line,
+// groups are placed from the hottest to the coldest
+// and fields inside groups are memory sorted,
+// if resulted structure size exceeds memory pack
+// structure size by more than tolerance bytes
+// memory pack structure is used instead
+type coaccess struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [8]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Bytes erich coaccess strategy with custom tolerance bytes
+func (stg coaccess) Bytes(bytes uint) coaccess {
+	stg.bytes = bytes
+	return stg
+}
+
+// Curator erich coaccess strategy with curator instance
+func (stg coaccess) Curator(curator gopium.Curator) coaccess {
+	stg.curator = curator
+	return stg
+}
+
+// Apply coaccess implementation
+func (stg coaccess) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// use memory pack structure as baseline
+	p, err := pck.Apply(ctx, o)
+	// in case structure has no fields, no co-access
+	// graph or cache line size is invalid just use it
+	ca, cachel := gopium.CoAccessed(ctx), stg.curator.SysCache(1)
+	if err != nil || len(o.Fields) == 0 || len(ca) == 0 || cachel <= 0 {
+		return p, err
+	}
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	flen := len(r.Fields)
+	// calculate fields hotness as total
+	// co-access weight with other fields
+	// and visit fields from the hottest
+	hot := make([]int64, flen)
+	order := make([]int, 0, flen)
+	for i := range r.Fields {
+		for j := range r.Fields {
+			if i != j {
+				hot[i] += stg.weight(ca, r, i, j)
+			}
+		}
+		order = append(order, i)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return hot[order[i]] > hot[order[j]]
+	})
+	// greedily group fields starting from the hottest
+	// ungrouped field and adding ungrouped field
+	// with the biggest co-access weight to the group
+	// while the group fits within cache line
+	grouped := make([]bool, flen)
+	groups := make([][]int, 0, flen)
+	var cold []int
+	for _, i := range order {
+		if grouped[i] {
+			continue
+		}
+		grouped[i] = true
+		// fields that aren't co-accessed
+		// are placed together at the end
+		if hot[i] == 0 {
+			cold = append(cold, i)
+			continue
+		}
+		group := []int{i}
+		for {
+			best, bweight := -1, int64(0)
+			for _, j := range order {
+				if grouped[j] {
+					continue
+				}
+				var w int64
+				for _, k := range group {
+					w += stg.weight(ca, r, k, j)
+				}
+				if w <= bweight {
+					continue
+				}
+				candidate := append(group[:len(group):len(group)], j)
+				if size, _, _ := collections.SizeAlignPtr(stg.group(r, candidate)); size <= cachel {
+					best, bweight = j, w
+				}
+			}
+			if best < 0 {
+				break
+			}
+			grouped[best] = true
+			group = append(group, best)
+		}
+		groups = append(groups, group)
+	}
+	if len(cold) > 0 {
+		groups = append(groups, cold)
+	}
+	// place memory sorted groups one after another
+	fields := make([]gopium.Field, 0, flen)
+	for _, group := range groups {
+		fields = append(fields, stg.group(r, group).Fields...)
+	}
+	r.Fields = fields
+	// in case resulted structure padding is worse
+	// than memory pack structure padding
+	// more than tolerance bytes use memory pack
+	rsize, _, _ := collections.SizeAlignPtr(r)
+	psize, _, _ := collections.SizeAlignPtr(p)
+	if rsize > psize+int64(stg.bytes) {
+		return p, ctx.Err()
+	}
+	return r, ctx.Err()
+}
+
+// weight helps to get co-access weight
+// of two structure fields by their indexes
+// note: blank fields are never co-accessed
+func (stg coaccess) weight(ca gopium.CoAccess, st gopium.Struct, i int, j int) int64 {
+	fi, fj := st.Fields[i], st.Fields[j]
+	if fi.Name == "_" || fj.Name == "_" {
+		return 0
+	}
+	return ca.Weight(fi.Name, fj.Name)
+}
+
+// group helps to build memory sorted
+// structure from provided fields indexes
+func (stg coaccess) group(st gopium.Struct, idxs []int) gopium.Struct {
+	r := gopium.Struct{Name: st.Name, Fields: make([]gopium.Field, 0, len(idxs))}
+	for _, i := range idxs {
+		r.Fields = append(r.Fields, st.Fields[i])
+	}
+	sort.SliceStable(r.Fields, func(i, j int) bool {
+		return pck.less(r.Fields[i], r.Fields[j])
+	})
+	return r
+}
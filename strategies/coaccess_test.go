@@ -0,0 +1,207 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestCoaccess(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a := gopium.Field{
+		Name:  "a",
+		Type:  "bool",
+		Size:  1,
+		Align: 1,
+	}
+	b := gopium.Field{
+		Name:  "b",
+		Type:  "int64",
+		Size:  8,
+		Align: 8,
+	}
+	c := gopium.Field{
+		Name:  "c",
+		Type:  "bool",
+		Size:  1,
+		Align: 1,
+	}
+	d := gopium.Field{
+		Name:  "d",
+		Type:  "int64",
+		Size:  8,
+		Align: 8,
+	}
+	x := gopium.Field{
+		Name:  "x",
+		Type:  "[40]byte",
+		Size:  40,
+		Align: 1,
+	}
+	y := gopium.Field{
+		Name:  "y",
+		Type:  "[40]byte",
+		Size:  40,
+		Align: 1,
+	}
+	o := gopium.Struct{
+		Name:   "test",
+		Fields: []gopium.Field{a, b, c, d},
+	}
+	pairs := gopium.CoAccess{
+		"a": {"b": 1},
+		"b": {"a": 1},
+		"c": {"d": 1},
+		"d": {"c": 1},
+	}
+	table := map[string]struct {
+		coaccess coaccess
+		c        gopium.Curator
+		ctx      context.Context
+		o        gopium.Struct
+		r        gopium.Struct
+		err      error
+	}{
+		"empty struct should be applied to empty struct": {
+			coaccess: pckcoacc,
+			c:        mocks.Maven{SCache: []int64{64}},
+			ctx:      gopium.WithCoAccess(context.Background(), pairs),
+		},
+		"struct without co-access graph should be applied to packed struct": {
+			coaccess: pckcoacc,
+			c:        mocks.Maven{SCache: []int64{64}},
+			ctx:      context.Background(),
+			o:        o,
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{b, d, a, c},
+			},
+		},
+		"struct with invalid cache line should be applied to packed struct": {
+			coaccess: pckcoacc,
+			c:        mocks.Maven{},
+			ctx:      gopium.WithCoAccess(context.Background(), pairs),
+			o:        o,
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{b, d, a, c},
+			},
+		},
+		"struct with co-access graph should be applied to co-accessed struct": {
+			coaccess: pckcoacc,
+			c:        mocks.Maven{SCache: []int64{64}},
+			ctx: gopium.WithCoAccess(context.Background(), gopium.CoAccess{
+				"a": {"c": 2, "d": 1},
+				"c": {"a": 2},
+				"d": {"a": 1},
+			}),
+			o: o,
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, a, c, b},
+			},
+		},
+		"struct with co-access graph exceeding tolerance should be applied to packed struct": {
+			coaccess: pckcoacc.Bytes(7),
+			c:        mocks.Maven{SCache: []int64{64}},
+			ctx:      gopium.WithCoAccess(context.Background(), pairs),
+			o:        o,
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{b, d, a, c},
+			},
+		},
+		"struct with co-access graph within tolerance should be applied to co-accessed struct": {
+			coaccess: pckcoacc.Bytes(8),
+			c:        mocks.Maven{SCache: []int64{64}},
+			ctx:      gopium.WithCoAccess(context.Background(), pairs),
+			o:        o,
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{b, a, d, c},
+			},
+		},
+		"struct with co-access graph should be applied to struct with groups fitting cache line": {
+			coaccess: pckcoacc,
+			c:        mocks.Maven{SCache: []int64{64}},
+			ctx: gopium.WithCoAccess(context.Background(), gopium.CoAccess{
+				"x": {"y": 2, "a": 1},
+				"y": {"x": 2},
+				"a": {"x": 1},
+			}),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{x, y, a},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{x, a, y},
+			},
+		},
+		"struct with co-accessed blank fields should be applied to packed struct": {
+			coaccess: pckcoacc,
+			c:        mocks.Maven{SCache: []int64{64}},
+			ctx: gopium.WithCoAccess(context.Background(), gopium.CoAccess{
+				"_": {"c": 1},
+				"c": {"_": 1},
+			}),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "_",
+						Type:  "[2]byte",
+						Size:  2,
+						Align: 1,
+					},
+					c,
+					a,
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "_",
+						Type:  "[2]byte",
+						Size:  2,
+						Align: 1,
+					},
+					c,
+					a,
+				},
+			},
+		},
+		"struct with co-access graph should be applied to packed struct on canceled context": {
+			coaccess: pckcoacc,
+			c:        mocks.Maven{SCache: []int64{64}},
+			ctx:      gopium.WithCoAccess(cctx, pairs),
+			o:        o,
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{b, d, a, c},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			coaccess := tcase.coaccess.Curator(tcase.c)
+			// exec
+			r, err := coaccess.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
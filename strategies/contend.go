@@ -0,0 +1,125 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of contend presets
+var (
+	contendl1 = contend{line: 1}
+	contendl2 = contend{line: 2}
+	contendl3 = contend{line: 3}
+	contendb  = contend{}
+)
+
+// contend defines strategy implementation
+// that guards structure from false sharing
+// only between contended fields by grouping
+// fields owned by the same writer together
+// and adding extra cpu cache line paddings
+// after each group, contended fields are
+// either fields from named tag group (owner)
+// or fields accessed by atomic operations
+// or having atomic and sync mutex types (own owner),
+// uncontended fields are placed first
+// as shared group and keep their order
+// note: existing blank fields are dropped
+// as paddings are calculated again
+type contend struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	line    uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Bytes erich contend strategy with custom bytes
+func (stg contend) Bytes(bytes uint) contend {
+	stg.bytes = bytes
+	return stg
+}
+
+// Curator erich contend strategy with curator instance
+func (stg contend) Curator(curator gopium.Curator) contend {
+	stg.curator = curator
+	return stg
+}
+
+// Apply contend implementation
+func (stg contend) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// check that cache line size or bytes are valid
+	cachel := stg.curator.SysCache(stg.line)
+	if stg.line == 0 {
+		cachel = int64(stg.bytes)
+	}
+	if len(r.Fields) == 0 || cachel <= 0 {
+		return r, ctx.Err()
+	}
+	// split fields to shared group
+	// and writers groups by their owners
+	// keeping groups order of first appearance
+	a := gopium.AtomicAccessed(ctx)
+	var shared []gopium.Field
+	var groups [][]gopium.Field
+	owners := make(map[string]int)
+	for _, f := range r.Fields {
+		if f.Name == "_" {
+			continue
+		}
+		owner, ok := stg.owner(a, f)
+		if !ok {
+			shared = append(shared, f)
+			continue
+		}
+		i, ok := owners[owner]
+		if !ok || owner == "" {
+			i = len(groups)
+			owners[owner] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], f)
+	}
+	// in case there are no contended
+	// fields just skip structure
+	if len(groups) == 0 {
+		return r, ctx.Err()
+	}
+	// place shared group first
+	// and pad each group to cache line
+	if len(shared) > 0 {
+		groups = append([][]gopium.Field{shared}, groups...)
+	}
+	r.Fields = make([]gopium.Field, 0, len(r.Fields)+len(groups))
+	for _, g := range groups {
+		r.Fields = append(r.Fields, g...)
+		// calculate current structure end
+		// and pad it to cache line if needed
+		st := collections.OffsetStruct(gopium.Struct{Fields: r.Fields})
+		last := st.Fields[len(st.Fields)-1]
+		end := last.Offset + last.Size
+		if pad := collections.Align(end, cachel) - end; pad > 0 {
+			r.Fields = append(r.Fields, collections.PadField(pad))
+		}
+	}
+	return r, ctx.Err()
+}
+
+// owner returns contended field writer owner,
+// fields from named tag group are owned by the group,
+// atomic and sync mutex fields are owned by themselves
+// which is denoted by empty owner
+func (stg contend) owner(a gopium.Atomics, f gopium.Field) (string, bool) {
+	if _, ok := reflect.StructTag(f.Tag).Lookup(gopium.NAME); ok {
+		if group, _, err := tgroup(f); err == nil && group != "" {
+			return group, true
+		}
+	}
+	if _, ok := a[f.Name]; ok {
+		return "", true
+	}
+	return "", f.Type == "sync.Mutex" || f.Type == "sync.RWMutex"
+}
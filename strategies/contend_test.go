@@ -0,0 +1,230 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestContend(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a := gopium.Atomics{
+		"hits": 64,
+	}
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "a",
+				Type:  "bool",
+				Size:  1,
+				Align: 1,
+			},
+			{
+				Name:  "b",
+				Type:  "int32",
+				Size:  4,
+				Align: 4,
+				Tag:   `gopium:"group:writer;memory_pack"`,
+			},
+			{
+				Name:  "mu",
+				Type:  "sync.Mutex",
+				Size:  8,
+				Align: 4,
+			},
+			{
+				Name:  "hits",
+				Type:  "int64",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:  "c",
+				Type:  "int64",
+				Size:  8,
+				Align: 8,
+				Tag:   `gopium:"group:writer;memory_pack"`,
+			},
+			{
+				Name:  "_",
+				Type:  "[8]byte",
+				Size:  8,
+				Align: 1,
+			},
+		},
+	}
+	table := map[string]struct {
+		contend contend
+		c       gopium.Curator
+		ctx     context.Context
+		o       gopium.Struct
+		r       gopium.Struct
+		err     error
+	}{
+		"empty struct should be applied to empty struct": {
+			contend: contendl1,
+			c:       mocks.Maven{SCache: []int64{32}},
+			ctx:     gopium.WithAtomics(context.Background(), a),
+		},
+		"struct without contended fields should be applied to itself": {
+			contend: contendl1,
+			c:       mocks.Maven{SCache: []int64{32}},
+			ctx:     gopium.WithAtomics(context.Background(), a),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "_",
+						Type:  "[7]byte",
+						Size:  7,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "_",
+						Type:  "[7]byte",
+						Size:  7,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with invalid cache line should be applied to itself": {
+			contend: contendb,
+			c:       mocks.Maven{SCache: []int64{32}},
+			ctx:     gopium.WithAtomics(context.Background(), a),
+			o:       o,
+			r:       o,
+		},
+		"struct with contended fields should be applied to expected guarded struct": {
+			contend: contendl1,
+			c:       mocks.Maven{SCache: []int64{32}},
+			ctx:     gopium.WithAtomics(context.Background(), a),
+			o:       o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					collections.PadField(31),
+					{
+						Name:  "b",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"group:writer;memory_pack"`,
+					},
+					{
+						Name:  "c",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:writer;memory_pack"`,
+					},
+					collections.PadField(16),
+					{
+						Name:  "mu",
+						Type:  "sync.Mutex",
+						Size:  8,
+						Align: 4,
+					},
+					collections.PadField(24),
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					collections.PadField(24),
+				},
+			},
+		},
+		"struct with contended fields should be applied to expected guarded struct on canceled context": {
+			contend: contendb.Bytes(16),
+			c:       mocks.Maven{},
+			ctx:     cctx,
+			o:       o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "hits",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "b",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"group:writer;memory_pack"`,
+					},
+					{
+						Name:  "c",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:writer;memory_pack"`,
+					},
+					{
+						Name:  "mu",
+						Type:  "sync.Mutex",
+						Size:  8,
+						Align: 4,
+					},
+					collections.PadField(8),
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			contend := tcase.contend.Curator(tcase.c)
+			// exec
+			r, err := contend.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
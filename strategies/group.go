@@ -28,6 +28,7 @@ var (
 // note: supports only next fields tags annotation formats
 // `gopium:"stg,stg,stg"` processed as `default` group
 // `gopium:"group:def;stg,stg,stg"` processed as named group
+// `gopium:"group:def"` processed as named group without strategies
 type group struct {
 	builder Builder `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
@@ -109,13 +110,15 @@ func (stg group) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, err
 	for i := range containers {
 		r.Fields = append(r.Fields, containers[i].r.Fields...)
 	}
-	return r, ctx.Err()
+	// recalculate combined struct fields offsets
+	return collections.OffsetStruct(r), ctx.Err()
 }
 
 // parse helps to parse structure fields tags
 // into groups container or returns parse error
 // - `gopium:"stg,stg,stg"` parsed to `default` group
 // - `gopium:"group:def;stg,stg,stg"` parsed to named group
+// - `gopium:"group:def"` parsed to named group without strategies
 // - otherwise a parse error returned
 func (stg group) parse(st gopium.Struct) ([]container, error) {
 	// setup temporary groups maps
@@ -133,53 +136,39 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 			gfields["-"] = append(gfields["-"], f)
 			continue
 		}
-		// trim all excess separators
-		tag = strings.Trim(tag, ";")
 		// otherwise parse the tag
-		tokens := strings.Split(tag, ";")
-		switch tlen := len(tokens); tlen {
-		case 1:
-			stgs := tokens[0]
-			// check that strategies list is consistent
-			if gstg, ok := gstrategiesnames[""]; ok && gstg != stgs {
+		group, stgs, err := tgroup(f)
+		if err != nil {
+			return nil, err
+		}
+		// check that strategies list is consistent
+		if gstg, ok := gstrategiesnames[group]; ok && gstg != stgs {
+			if group == "" {
 				return nil, fmt.Errorf(
 					"inconsistent strategies list %q for field %q in default group",
 					stgs,
 					f.Name,
 				)
 			}
-			// collect strategies and fields
-			gstrategiesnames[""] = stgs
-			gfields[""] = append(gfields[""], collections.CopyField(f))
-		case 2:
-			group := tokens[0]
-			stgs := tokens[1]
-			// check that tag contains group anchor
-			if !strings.Contains(group, "group:") {
-				return nil, fmt.Errorf("tag %q can't be parsed, named group `group:` anchor wasn't found", f.Tag)
-			}
-			// remove group anchor
-			group = strings.Replace(group, "group:", "", 1)
-			// check that strategies list is consistent
-			if gstg, ok := gstrategiesnames[group]; ok && gstg != stgs {
-				return nil, fmt.Errorf(
-					"inconsistent strategies list %q for field %q in group %q",
-					stgs,
-					f.Name,
-					group,
-				)
-			}
-			// collect strategies and fields
-			gstrategiesnames[group] = stgs
-			gfields[group] = append(gfields[group], collections.CopyField(f))
-		default:
-			// return parsing error msg
-			return nil, fmt.Errorf("tag %q can't be parsed, neither as `default` nor named group", f.Tag)
+			return nil, fmt.Errorf(
+				"inconsistent strategies list %q for field %q in group %q",
+				stgs,
+				f.Name,
+				group,
+			)
 		}
+		// collect strategies and fields
+		gstrategiesnames[group] = stgs
+		gfields[group] = append(gfields[group], collections.CopyField(f))
 	}
 	// go through all collected group strategies names
 	// and build pipe strategy from them
 	for grp, gstgs := range gstrategiesnames {
+		// named groups without strategies
+		// are ignored as is
+		if grp != "" && gstgs == "" {
+			continue
+		}
 		// prepare strategy pipe
 		names := strings.Split(gstgs, ",")
 		p := make(pipe, 0, len(names))
@@ -223,3 +212,40 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 	// return result containers
 	return containers, nil
 }
+
+// tgroup helps to parse field tag
+// into group name and strategies list
+// or returns parse error, default
+// group is denoted by empty name
+// - `gopium:"stg,stg,stg"` parsed to `default` group
+// - `gopium:"group:def;stg,stg,stg"` parsed to named group
+// - `gopium:"group:def"` parsed to named group without strategies
+// - otherwise a parse error returned
+func tgroup(f gopium.Field) (string, string, error) {
+	// grab the field tag
+	// and trim all excess separators
+	tag, _ := reflect.StructTag(f.Tag).Lookup(gopium.NAME)
+	tag = strings.Trim(tag, ";")
+	tokens := strings.Split(tag, ";")
+	switch len(tokens) {
+	case 1:
+		// check if tag contains
+		// only named group anchor
+		if group := strings.TrimSpace(tokens[0]); strings.HasPrefix(group, "group:") {
+			return strings.TrimPrefix(group, "group:"), "", nil
+		}
+		return "", tokens[0], nil
+	case 2:
+		group := tokens[0]
+		stgs := tokens[1]
+		// check that tag contains group anchor
+		if !strings.Contains(group, "group:") {
+			return "", "", fmt.Errorf("tag %q can't be parsed, named group `group:` anchor wasn't found", f.Tag)
+		}
+		// remove group anchor
+		return strings.TrimSpace(strings.Replace(group, "group:", "", 1)), stgs, nil
+	default:
+		// return parsing error msg
+		return "", "", fmt.Errorf("tag %q can't be parsed, neither as `default` nor named group", f.Tag)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/tests/mocks"
 )
@@ -177,6 +176,32 @@ func TestGroup(t *testing.T) {
 				},
 			},
 		},
+		"non empty struct with named group tag without strategies should be applied to itself": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"group:def"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"group:def"`,
+					},
+				},
+			},
+		},
 		"non empty struct with invalid tag should be applied to itself with error": {
 			b:   Builder{Curator: mocks.Maven{}},
 			ctx: context.Background(),
@@ -399,28 +424,56 @@ func TestGroup(t *testing.T) {
 						Align: 4,
 						Tag:   `gopium:"cache_rounding_cpu_l1_discrete,explicit_paddings_system_alignment"`,
 					},
-					collections.PadField(4),
 					{
-						Name:  "test-5",
-						Size:  8,
-						Align: 4,
-						Tag:   `gopium:"cache_rounding_cpu_l1_discrete,explicit_paddings_system_alignment"`,
+						Name:   "_",
+						Type:   "[4]byte",
+						Size:   4,
+						Align:  1,
+						Offset: 8,
 					},
-					collections.PadField(4),
-					collections.PadField(8),
-					collections.PadField(4),
 					{
-						Name:  "test-2",
-						Size:  8,
-						Align: 4,
-						Tag:   `gopium:"group:def-1;fields_annotate_doc,struct_annotate_doc"`,
-						Doc:   []string{"// field size: 8 bytes; field align: 4 bytes; field ptr: 0 bytes; - 🌺 gopium @1pkg"},
+						Name:   "test-5",
+						Size:   8,
+						Align:  4,
+						Offset: 12,
+						Tag:    `gopium:"cache_rounding_cpu_l1_discrete,explicit_paddings_system_alignment"`,
+					},
+					{
+						Name:   "_",
+						Type:   "[4]byte",
+						Size:   4,
+						Align:  1,
+						Offset: 20,
+					},
+					{
+						Name:   "_",
+						Type:   "[8]byte",
+						Size:   8,
+						Align:  1,
+						Offset: 24,
+					},
+					{
+						Name:   "_",
+						Type:   "[4]byte",
+						Size:   4,
+						Align:  1,
+						Offset: 32,
+					},
+					{
+						Name:   "test-2",
+						Size:   8,
+						Align:  4,
+						Offset: 36,
+						Tag:    `gopium:"group:def-1;fields_annotate_doc,struct_annotate_doc"`,
+						Doc:    []string{"// field size: 8 bytes; field align: 4 bytes; field ptr: 0 bytes; - 🌺 gopium @1pkg"},
 					},
 					{
 						Name:    "test-1",
 						Size:    12,
 						Align:   8,
 						Ptr:     4,
+						Offset:  48,
+						Pad:     4,
 						Tag:     `gopium:"group:def-2;fields_annotate_comment,explicit_paddings_system_alignment,cache_rounding_cpu_l1_discrete"`,
 						Comment: []string{"// field size: 12 bytes; field align: 8 bytes; field ptr: 4 bytes; - 🌺 gopium @1pkg"},
 					},
@@ -429,10 +482,17 @@ func TestGroup(t *testing.T) {
 						Size:    8,
 						Align:   4,
 						Ptr:     4,
+						Offset:  60,
 						Tag:     `gopium:"group:def-2;fields_annotate_comment,explicit_paddings_system_alignment,cache_rounding_cpu_l1_discrete"`,
 						Comment: []string{"// field size: 8 bytes; field align: 4 bytes; field ptr: 4 bytes; - 🌺 gopium @1pkg"},
 					},
-					collections.PadField(4),
+					{
+						Name:   "_",
+						Type:   "[4]byte",
+						Size:   4,
+						Align:  1,
+						Offset: 68,
+					},
 				},
 			},
 		},
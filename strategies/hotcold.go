@@ -0,0 +1,102 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of hotcold presets
+var (
+	hcold = hotcold{}
+)
+
+// hotcold defines strategy implementation
+// that rearranges structure fields
+// accordingly to structure fields profile hotness
+// by placing the hottest fields that fit
+// within cpu l1 cache line first
+// and the rest of fields after them,
+// both hot and cold fields are memory sorted
+// and each field is annotated with its hotness
+type hotcold struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Curator erich hotcold strategy with curator instance
+func (stg hotcold) Curator(curator gopium.Curator) hotcold {
+	stg.curator = curator
+	return stg
+}
+
+// Apply hotcold implementation
+func (stg hotcold) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// in case structure has no fields
+	// or no fields hotness just skip it
+	h := gopium.Profiled(ctx)
+	if len(r.Fields) == 0 || len(h) == 0 {
+		return r, ctx.Err()
+	}
+	// visit fields from the hottest
+	fields := make([]gopium.Field, len(r.Fields))
+	copy(fields, r.Fields)
+	sort.SliceStable(fields, func(i, j int) bool {
+		return stg.hotness(h, fields[i]) > stg.hotness(h, fields[j])
+	})
+	// greedily fill cache line with the hottest
+	// fields while memory sorted hot fields fit
+	// within cache line, invalid cache line
+	// size means that all hot fields fit
+	cachel := stg.curator.SysCache(1)
+	hot := gopium.Struct{Name: r.Name}
+	cold := gopium.Struct{Name: r.Name}
+	for _, f := range fields {
+		if stg.hotness(h, f) > 0 {
+			candidate := gopium.Struct{Name: r.Name, Fields: append(hot.Fields[:len(hot.Fields):len(hot.Fields)], f)}
+			if size, _, _ := collections.SizeAlignPtr(stg.pack(candidate)); cachel <= 0 || size <= cachel {
+				hot = candidate
+				continue
+			}
+		}
+		cold.Fields = append(cold.Fields, f)
+	}
+	// place memory sorted hot fields
+	// first and cold fields after them
+	// and note each field with hotness comment
+	r.Fields = append(stg.pack(hot).Fields, stg.pack(cold).Fields...)
+	for i := range r.Fields {
+		f := &r.Fields[i]
+		if f.Name == "_" {
+			continue
+		}
+		note := fmt.Sprintf(
+			"// field hotness: %d samples; - %s",
+			stg.hotness(h, *f),
+			gopium.STAMP,
+		)
+		f.Comment = append(f.Comment, note)
+	}
+	return r, ctx.Err()
+}
+
+// hotness helps to get field hotness
+// note: blank fields are never hot
+func (stg hotcold) hotness(h gopium.Hotness, f gopium.Field) int64 {
+	if f.Name == "_" {
+		return 0
+	}
+	return h[f.Name]
+}
+
+// pack helps to memory sort structure fields
+func (stg hotcold) pack(st gopium.Struct) gopium.Struct {
+	sort.SliceStable(st.Fields, func(i, j int) bool {
+		return pck.less(st.Fields[i], st.Fields[j])
+	})
+	return st
+}
@@ -0,0 +1,252 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestHotcold(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "a",
+				Type:  "bool",
+				Size:  1,
+				Align: 1,
+			},
+			{
+				Name:  "big",
+				Type:  "[56]byte",
+				Size:  56,
+				Align: 1,
+			},
+			{
+				Name:  "b",
+				Type:  "int64",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:  "c",
+				Type:  "bool",
+				Size:  1,
+				Align: 1,
+			},
+			{
+				Name:  "d",
+				Type:  "int64",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:  "_",
+				Type:  "[8]byte",
+				Size:  8,
+				Align: 1,
+			},
+		},
+	}
+	h := gopium.Hotness{
+		"a":   17,
+		"c":   17,
+		"big": 10,
+		"b":   10,
+		"d":   3,
+		"_":   100,
+	}
+	table := map[string]struct {
+		c   gopium.Curator
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to empty struct": {
+			c:   mocks.Maven{SCache: []int64{64}},
+			ctx: gopium.WithHotness(context.Background(), h),
+		},
+		"struct without fields hotness should be applied to itself": {
+			c:   mocks.Maven{SCache: []int64{64}},
+			ctx: context.Background(),
+			o:   o,
+			r:   o,
+		},
+		"struct with fields hotness should be applied to hot cold struct": {
+			c:   mocks.Maven{SCache: []int64{64}},
+			ctx: gopium.WithHotness(context.Background(), h),
+			o:   o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:    "big",
+						Type:    "[56]byte",
+						Size:    56,
+						Align:   1,
+						Comment: []string{"// field hotness: 10 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "a",
+						Type:    "bool",
+						Size:    1,
+						Align:   1,
+						Comment: []string{"// field hotness: 17 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "c",
+						Type:    "bool",
+						Size:    1,
+						Align:   1,
+						Comment: []string{"// field hotness: 17 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "b",
+						Type:    "int64",
+						Size:    8,
+						Align:   8,
+						Comment: []string{"// field hotness: 10 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "d",
+						Type:    "int64",
+						Size:    8,
+						Align:   8,
+						Comment: []string{"// field hotness: 3 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:  "_",
+						Type:  "[8]byte",
+						Size:  8,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with fields hotness and invalid cache line should be applied to hot cold struct": {
+			c:   mocks.Maven{},
+			ctx: gopium.WithHotness(context.Background(), h),
+			o:   o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:    "b",
+						Type:    "int64",
+						Size:    8,
+						Align:   8,
+						Comment: []string{"// field hotness: 10 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "d",
+						Type:    "int64",
+						Size:    8,
+						Align:   8,
+						Comment: []string{"// field hotness: 3 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "big",
+						Type:    "[56]byte",
+						Size:    56,
+						Align:   1,
+						Comment: []string{"// field hotness: 10 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "a",
+						Type:    "bool",
+						Size:    1,
+						Align:   1,
+						Comment: []string{"// field hotness: 17 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "c",
+						Type:    "bool",
+						Size:    1,
+						Align:   1,
+						Comment: []string{"// field hotness: 17 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:  "_",
+						Type:  "[8]byte",
+						Size:  8,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with fields hotness should be applied to hot cold struct on canceled context": {
+			c:   mocks.Maven{SCache: []int64{16}},
+			ctx: gopium.WithHotness(cctx, h),
+			o:   o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:    "b",
+						Type:    "int64",
+						Size:    8,
+						Align:   8,
+						Comment: []string{"// field hotness: 10 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "a",
+						Type:    "bool",
+						Size:    1,
+						Align:   1,
+						Comment: []string{"// field hotness: 17 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "c",
+						Type:    "bool",
+						Size:    1,
+						Align:   1,
+						Comment: []string{"// field hotness: 17 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "d",
+						Type:    "int64",
+						Size:    8,
+						Align:   8,
+						Comment: []string{"// field hotness: 3 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "big",
+						Type:    "[56]byte",
+						Size:    56,
+						Align:   1,
+						Comment: []string{"// field hotness: 10 samples; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:  "_",
+						Type:  "[8]byte",
+						Size:  8,
+						Align: 1,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			hotcold := hcold.Curator(tcase.c)
+			// exec
+			r, err := hotcold.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
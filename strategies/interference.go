@@ -0,0 +1,23 @@
+package strategies
+
+import "github.com/1pkg/gopium/gopium"
+
+// interference defines curator implementation
+// that exposes either destructive or constructive
+// interference size of wrapped curator
+// as cache line size of any level,
+// so cache line aware strategies
+// could use interference sizes instead
+type interference struct {
+	gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	destructive    bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_              [15]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// SysCache interference implementation
+func (c interference) SysCache(uint) int64 {
+	if c.destructive {
+		return c.SysDestructive()
+	}
+	return c.SysConstructive()
+}
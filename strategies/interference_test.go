@@ -0,0 +1,39 @@
+package strategies
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestInterference(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		c     gopium.Curator
+		level uint
+		size  int64
+	}{
+		"destructive interference should return destructive size for any cache level": {
+			c:     interference{Curator: mocks.Maven{SCache: []int64{64}, SDestr: 128, SConst: 64}, destructive: true},
+			level: 1,
+			size:  128,
+		},
+		"constructive interference should return constructive size for any cache level": {
+			c:     interference{Curator: mocks.Maven{SCache: []int64{32}, SDestr: 128, SConst: 64}},
+			level: 3,
+			size:  64,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			size := tcase.c.SysCache(tcase.level)
+			// check
+			if !reflect.DeepEqual(size, tcase.size) {
+				t.Errorf("actual %v doesn't equal to expected %v", size, tcase.size)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package strategies
+
+import (
+	"context"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of optimal presets
+var (
+	pckopt = optimal{limit: 12}
+)
+
+// optimal defines strategy implementation
+// that rearranges structure fields
+// to obtain minimal memory utilization
+// by exhaustive branch and bound search
+// over fields alignment classes orders
+// which minimizes structure aligned size first,
+// then ptr scan size and then distance from
+// original fields order, structures with
+// more fields than the limit are packed instead
+type optimal struct {
+	limit int `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 8 bytes; struct align: 8 bytes; struct aligned size: 8 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// oclass defines fields alignment class
+// of fields with the same size, align and ptr size
+// that keeps fields original indexes in order
+type oclass struct {
+	idxs  []int   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	size  int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	align int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ptr   int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	next  int     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [8]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 8 bytes; - 🌺 gopium @1pkg
+
+// ocost defines fields order cost
+// of aligned size, ptr scan size
+// and distance from original order
+type ocost struct {
+	size int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ptr  int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	dist int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [8]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// less checks if cost is
+// lexicographically less than other cost
+func (c ocost) less(oc ocost) bool {
+	if c.size != oc.size {
+		return c.size < oc.size
+	}
+	if c.ptr != oc.ptr {
+		return c.ptr < oc.ptr
+	}
+	return c.dist < oc.dist
+}
+
+// Apply optimal implementation
+func (stg optimal) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// in case structure has no fields
+	// or too many fields just fallback to pack
+	flen := len(o.Fields)
+	if flen == 0 || flen > stg.limit {
+		return pck.Apply(ctx, o)
+	}
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// start with the best order of
+	// original order and pack order
+	orig := make([]int, 0, flen)
+	for i := 0; i < flen; i++ {
+		orig = append(orig, i)
+	}
+	pack := make([]int, flen)
+	copy(pack, orig)
+	sort.SliceStable(pack, func(i, j int) bool {
+		return pck.less(r.Fields[pack[i]], r.Fields[pack[j]])
+	})
+	best, bcost := orig, stg.cost(r, orig)
+	if pcost := stg.cost(r, pack); pcost.less(bcost) {
+		best, bcost = pack, pcost
+	}
+	// group fields to alignment classes
+	// fields in the same class are interchangeable
+	// so they are always placed in original order
+	// as it keeps distance from original order minimal
+	var stalign, rem int64 = 1, 0
+	classes := make([]*oclass, 0, flen)
+	for i, f := range r.Fields {
+		var class *oclass
+		for _, c := range classes {
+			if c.size == f.Size && c.align == f.Align && c.ptr == f.Ptr {
+				class = c
+				break
+			}
+		}
+		if class == nil {
+			class = &oclass{size: f.Size, align: f.Align, ptr: f.Ptr}
+			classes = append(classes, class)
+		}
+		class.idxs = append(class.idxs, i)
+		if f.Align > stalign {
+			stalign = f.Align
+		}
+		rem += f.Size
+	}
+	// run branch and bound search
+	// over alignment classes orders
+	placed := make([]bool, flen)
+	order := make([]int, 0, flen)
+	var search func(offset, ptr, dist, rem int64)
+	search = func(offset, ptr, dist, rem int64) {
+		// stop the search on cancelation
+		if ctx.Err() != nil {
+			return
+		}
+		// in case all fields are placed
+		// check if order is better
+		if len(order) == flen {
+			// gc pads zero size last field
+			// of non zero size struct by one byte
+			if r.Fields[order[flen-1]].Size == 0 && offset > 0 {
+				offset++
+			}
+			cost := ocost{size: collections.Align(offset, stalign), ptr: ptr, dist: dist}
+			if cost.less(bcost) {
+				best, bcost = append(best[:0:0], order...), cost
+			}
+			return
+		}
+		// calculate lower bound cost
+		// of all orders in the branch
+		// and prune the branch if it
+		// can't be better than the best
+		// note: all remaining pointerful fields
+		// but the last one precede the last one
+		// so ptr scan size can't be less than
+		// their total size without the biggest
+		// trailing non-pointer bytes
+		// note: if only zero size fields remain
+		// the last one is padded by one byte
+		end := offset + rem
+		if rem == 0 && offset > 0 {
+			end++
+		}
+		lb := ocost{size: collections.Align(end, stalign), ptr: ptr, dist: dist}
+		var psize, ptrail int64 = 0, -1
+		for _, c := range classes {
+			if n := int64(len(c.idxs) - c.next); n > 0 && c.ptr > 0 {
+				psize += c.size * n
+				if trail := c.size - c.ptr; trail > ptrail {
+					ptrail = trail
+				}
+			}
+		}
+		if ptrail >= 0 && offset+psize-ptrail > lb.ptr {
+			lb.ptr = offset + psize - ptrail
+		}
+		if !lb.less(bcost) {
+			return
+		}
+		// go through all classes and place
+		// next class field in the order
+		for _, c := range classes {
+			if c.next == len(c.idxs) {
+				continue
+			}
+			i := c.idxs[c.next]
+			// calculate field offset and ptr end
+			foffset, fptr := offset, ptr
+			if c.align > 0 {
+				foffset = collections.Align(offset, c.align)
+			}
+			if c.ptr > 0 {
+				fptr = foffset + c.ptr
+			}
+			// distance is increased by all
+			// unplaced fields which precede
+			// the field in original order
+			var fdist int64
+			for j := 0; j < i; j++ {
+				if !placed[j] {
+					fdist++
+				}
+			}
+			// place the field and go deeper
+			c.next++
+			placed[i] = true
+			order = append(order, i)
+			search(foffset+c.size, fptr, dist+fdist, rem-c.size)
+			order = order[:len(order)-1]
+			placed[i] = false
+			c.next--
+		}
+	}
+	search(0, 0, 0, rem)
+	// reorder result accordingly to the best order
+	fields := make([]gopium.Field, 0, flen)
+	for _, i := range best {
+		fields = append(fields, r.Fields[i])
+	}
+	r.Fields = fields
+	return r, ctx.Err()
+}
+
+// cost helps to calculate structure
+// cost for provided fields indexes order
+func (stg optimal) cost(st gopium.Struct, order []int) ocost {
+	// calculate distance from original order
+	// as number of inverted fields pairs
+	var dist int64
+	for i := range order {
+		for j := i + 1; j < len(order); j++ {
+			if order[i] > order[j] {
+				dist++
+			}
+		}
+	}
+	r := gopium.Struct{Fields: make([]gopium.Field, 0, len(order))}
+	for _, i := range order {
+		r.Fields = append(r.Fields, st.Fields[i])
+	}
+	size, _, ptr := collections.SizeAlignPtr(r)
+	return ocost{size: size, ptr: ptr, dist: dist}
+}
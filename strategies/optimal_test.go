@@ -0,0 +1,331 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestOptimal(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		stg optimal
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to empty struct": {
+			stg: pckopt,
+			ctx: context.Background(),
+		},
+		"struct with optimal original order should be applied to itself": {
+			stg: pckopt,
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"struct with odd sizes should be applied to minimal size struct": {
+			stg: pckopt,
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  6,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-1",
+						Size:  6,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-2",
+						Size:  2,
+						Align: 2,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-2",
+						Size:  2,
+						Align: 2,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  6,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-2",
+						Size:  2,
+						Align: 2,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-1",
+						Size:  6,
+						Align: 4,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-2",
+						Size:  2,
+						Align: 2,
+					},
+				},
+			},
+		},
+		"struct with ptr should be applied to minimal ptr scan size struct": {
+			stg: pckopt,
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test3",
+						Type:  "*int64",
+						Size:  8,
+						Align: 8,
+						Ptr:   8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test3",
+						Type:  "*int64",
+						Size:  8,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test2",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test1",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"struct with zero size last field should be applied to struct without trailing padding": {
+			stg: pckopt,
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Type:  "struct{}",
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "struct{}",
+						Align: 1,
+					},
+					{
+						Name:  "test1",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"struct with too many fields should be applied to packed struct": {
+			stg: optimal{limit: 2},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test3",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test1",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct should be applied to itself on canceled context": {
+			stg: pckopt,
+			ctx: cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.stg.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -27,37 +27,44 @@ func (stg pack) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, erro
 	// execute memory sorting
 	// https://cs.opensource.google/go/x/tools/+/refs/tags/v0.1.7:go/analysis/passes/fieldalignment/fieldalignment.go;l=145;bpv=0;bpt=1
 	sort.SliceStable(r.Fields, func(i, j int) bool {
-		// place zero sized objects before non-zero sized objects
-		zeroi, zeroj := r.Fields[i].Size == 0, r.Fields[j].Size == 0
-		if zeroi != zeroj {
-			return zeroi
-		}
-		// then compare aligns of two fields
-		// bigger aligmnet means upper position
-		if r.Fields[i].Align != r.Fields[j].Align {
-			return r.Fields[i].Align > r.Fields[j].Align
-		}
-		// place pointerful objects before pointer-free objects
-		noptri, noptrj := r.Fields[i].Ptr == 0, r.Fields[j].Ptr == 0
-		if noptri != noptrj {
-			return noptrj
-		}
+		return stg.less(r.Fields[i], r.Fields[j])
+	})
+	return r, ctx.Err()
+}
+
+// less helps to compare two fields
+// memory sorting positions, returns true
+// if first field should be placed upper
+func (stg pack) less(fi gopium.Field, fj gopium.Field) bool {
+	// place zero sized objects before non-zero sized objects
+	zeroi, zeroj := fi.Size == 0, fj.Size == 0
+	if zeroi != zeroj {
+		return zeroi
+	}
+	// then compare aligns of two fields
+	// bigger aligmnet means upper position
+	if fi.Align != fj.Align {
+		return fi.Align > fj.Align
+	}
+	// place pointerful objects before pointer-free objects
+	noptri, noptrj := fi.Ptr == 0, fj.Ptr == 0
+	if noptri != noptrj {
+		return noptrj
+	}
 
-		if !noptri {
-			// if both have pointers
-			// then place objects with less trailing
-			// non-pointer bytes earlier;
-			// that is, place the field with the most trailing
-			// non-pointer bytes at the end of the pointerful section
-			traili, trailj := r.Fields[i].Size-r.Fields[i].Ptr, r.Fields[j].Size-r.Fields[j].Ptr
-			if traili != trailj {
-				return traili < trailj
-			}
+	if !noptri {
+		// if both have pointers
+		// then place objects with less trailing
+		// non-pointer bytes earlier;
+		// that is, place the field with the most trailing
+		// non-pointer bytes at the end of the pointerful section
+		traili, trailj := fi.Size-fi.Ptr, fj.Size-fj.Ptr
+		if traili != trailj {
+			return traili < trailj
 		}
+	}
 
-		// then compare sizes of two fields
-		// bigger size means upper position
-		return r.Fields[i].Size > r.Fields[j].Size
-	})
-	return r, ctx.Err()
+	// then compare sizes of two fields
+	// bigger size means upper position
+	return fi.Size > fj.Size
 }
@@ -33,9 +33,9 @@ func (stgs pipe) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, err
 		if err != nil {
 			return r, err
 		}
-		// copy result back to
-		// result structure
-		r = tmp
+		// copy result back to result structure
+		// and recalculate its fields offsets
+		r = collections.OffsetStruct(tmp)
 	}
 	return r, ctx.Err()
 }
@@ -0,0 +1,137 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of portable presets
+var (
+	pckport  = portable{worst: false}
+	pckportw = portable{worst: true}
+)
+
+// portable defines strategy implementation
+// that rearranges structure fields
+// to obtain optimal memory utilization
+// across all structure target platforms
+// by evaluating memory sorting orders
+// of each target platform on all of them
+// and choosing the order with either
+// the smallest total or worst case size
+type portable struct {
+	worst bool `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 1 bytes; struct align: 1 bytes; struct aligned size: 1 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Apply portable implementation
+func (stg portable) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// in case there are no target
+	// platforms just fallback to pack
+	platforms := gopium.Platforms(ctx)
+	if len(platforms) == 0 {
+		return pck.Apply(ctx, o)
+	}
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// build structure variant for each platform
+	variants := make([]gopium.Struct, 0, len(platforms))
+	for _, p := range platforms {
+		variants = append(variants, stg.variant(r, p.Struct))
+	}
+	// collect candidate orders, original order
+	// and memory sorting order on each platform
+	flen := len(r.Fields)
+	orders := make([][]int, 0, len(variants)+1)
+	order := make([]int, 0, flen)
+	for i := 0; i < flen; i++ {
+		order = append(order, i)
+	}
+	orders = append(orders, order)
+	for _, v := range variants {
+		order := make([]int, flen)
+		copy(order, orders[0])
+		sort.SliceStable(order, func(i, j int) bool {
+			return pck.less(v.Fields[order[i]], v.Fields[order[j]])
+		})
+		orders = append(orders, order)
+	}
+	// pick candidate order with the smallest
+	// total or worst case size across platforms
+	// then the other size and then total ptr scan size
+	var best int
+	var bsizes []int64
+	var btotal, bworst, bptr int64 = -1, -1, -1
+	for i, order := range orders {
+		var total, worst, ptr int64
+		sizes := make([]int64, 0, len(variants))
+		for _, v := range variants {
+			size, _, p := collections.SizeAlignPtr(stg.reorder(v, order))
+			sizes = append(sizes, size)
+			total += size
+			ptr += p
+			if size > worst {
+				worst = size
+			}
+		}
+		// compare primary and secondary sizes
+		// depends on worst case flag
+		prim, sec, bprim, bsec := total, worst, btotal, bworst
+		if stg.worst {
+			prim, sec, bprim, bsec = worst, total, bworst, btotal
+		}
+		if bprim < 0 || prim < bprim || (prim == bprim && (sec < bsec || (sec == bsec && ptr < bptr))) {
+			best, bsizes, btotal, bworst, bptr = i, sizes, total, worst, ptr
+		}
+	}
+	// reorder result accordingly to the best order
+	r.Fields = stg.reorder(r, orders[best]).Fields
+	// note structure with per platform sizes comment
+	psizes := make([]string, 0, len(platforms))
+	for i, p := range platforms {
+		psizes = append(psizes, fmt.Sprintf("%s %d bytes", p.Name, bsizes[i]))
+	}
+	note := fmt.Sprintf(
+		"// struct portable sizes: %s; struct portable total size: %d bytes; struct portable worst size: %d bytes; - %s",
+		strings.Join(psizes, ", "),
+		btotal,
+		bworst,
+		gopium.STAMP,
+	)
+	r.Comment = append(r.Comment, note)
+	return r, ctx.Err()
+}
+
+// variant helps to build structure variant
+// on the platform by using platform
+// fields sizes, aligns and ptr sizes
+// for all matching by names non blank fields
+func (stg portable) variant(st gopium.Struct, pst gopium.Struct) gopium.Struct {
+	fields := make(map[string]gopium.Field, len(pst.Fields))
+	for _, f := range pst.Fields {
+		fields[f.Name] = f
+	}
+	v := collections.CopyStruct(st)
+	for i, f := range v.Fields {
+		if pf, ok := fields[f.Name]; ok && f.Name != "_" {
+			v.Fields[i].Size = pf.Size
+			v.Fields[i].Align = pf.Align
+			v.Fields[i].Ptr = pf.Ptr
+		}
+	}
+	return v
+}
+
+// reorder helps to reorder structure
+// fields accordingly to provided fields indexes
+func (stg portable) reorder(st gopium.Struct, order []int) gopium.Struct {
+	r := gopium.Struct{Name: st.Name, Fields: make([]gopium.Field, 0, len(order))}
+	for _, i := range order {
+		r.Fields = append(r.Fields, st.Fields[i])
+	}
+	return r
+}
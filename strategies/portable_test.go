@@ -0,0 +1,234 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestPortable(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o386 := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "test1",
+				Type:  "[3]int32",
+				Size:  12,
+				Align: 4,
+			},
+			{
+				Name:  "test2",
+				Type:  "int64",
+				Size:  8,
+				Align: 4,
+			},
+			{
+				Name:  "test3",
+				Type:  "int32",
+				Size:  4,
+				Align: 4,
+			},
+		},
+	}
+	oamd64 := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "test1",
+				Type:  "[3]int32",
+				Size:  12,
+				Align: 4,
+			},
+			{
+				Name:  "test2",
+				Type:  "int64",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:  "test3",
+				Type:  "int32",
+				Size:  4,
+				Align: 4,
+			},
+		},
+	}
+	pctx := gopium.WithPlatforms(context.Background(), []gopium.Platform{
+		{Name: "386", Struct: o386},
+		{Name: "amd64", Struct: oamd64},
+	})
+	table := map[string]struct {
+		stg portable
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to empty struct": {
+			stg: pckport,
+			ctx: context.Background(),
+		},
+		"struct without platforms should be applied to packed struct": {
+			stg: pckport,
+			ctx: context.Background(),
+			o:   o386,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "[3]int32",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct with platforms should be applied to portable struct": {
+			stg: pckport,
+			ctx: pctx,
+			o:   o386,
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct portable sizes: 386 24 bytes, amd64 24 bytes; struct portable total size: 48 bytes; struct portable worst size: 24 bytes; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test1",
+						Type:  "[3]int32",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct with platforms and pads should be applied to worst case portable struct": {
+			stg: pckportw,
+			ctx: pctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "_",
+						Type:  "[4]byte",
+						Size:  4,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct portable sizes: 386 16 bytes, amd64 16 bytes; struct portable total size: 32 bytes; struct portable worst size: 16 bytes; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "_",
+						Type:  "[4]byte",
+						Size:  4,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct with platforms should be applied to portable struct on canceled context": {
+			stg: pckportw,
+			ctx: gopium.WithPlatforms(cctx, gopium.Platforms(pctx)),
+			o:   o386,
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct portable sizes: 386 24 bytes, amd64 24 bytes; struct portable total size: 48 bytes; struct portable worst size: 24 bytes; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test1",
+						Type:  "[3]int32",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.stg.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of sclass presets
+var (
+	scfit = sclass{}
+)
+
+// sclass defines strategy implementation
+// that adds go runtime allocator size class
+// comment annotation for structure with
+// number of wasted bytes inside the size class
+// and number of bytes that must be trimmed
+// to fit the structure into previous size class
+type sclass struct{} // struct size: 0 bytes; struct align: 1 bytes; struct aligned size: 0 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Apply sclass implementation
+func (stg sclass) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// calculate structure aligned size
+	// and its allocator size classes
+	size, _, _ := collections.SizeAlignPtr(r)
+	class, prev := collections.SizeClass(size), collections.PrevSizeClass(size)
+	// note structure with size class comment
+	note := fmt.Sprintf(
+		"// struct size class: %d bytes; struct size class waste: %d bytes; struct size class trim: %d bytes; - %s",
+		class,
+		class-size,
+		size-prev,
+		gopium.STAMP,
+	)
+	r.Comment = append(r.Comment, note)
+	return r, ctx.Err()
+}
@@ -0,0 +1,114 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestSclass(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to annotated empty struct": {
+			ctx: context.Background(),
+			r: gopium.Struct{
+				Comment: []string{
+					"// struct size class: 0 bytes; struct size class waste: 0 bytes; struct size class trim: 0 bytes; - 🌺 gopium @1pkg",
+				},
+			},
+		},
+		"struct inside size class should be applied to annotated struct": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"test"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "[49]byte",
+						Size:  49,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"test",
+					"// struct size class: 64 bytes; struct size class waste: 8 bytes; struct size class trim: 8 bytes; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "[49]byte",
+						Size:  49,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct should be applied to annotated struct on canceled context": {
+			ctx: cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct size class: 8 bytes; struct size class waste: 0 bytes; struct size class trim: 8 bytes; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := scfit.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of split presets
+var (
+	splcold = split{}
+)
+
+// split defines strategy implementation
+// that splits structure cold fields
+// into generated companion structure
+// referenced by cold pointer field
+// placed after structure hot fields,
+// cold fields are either fields from
+// named `cold` tag group or fields with
+// zero profile hotness when profile is provided,
+// blank and embedded fields are never split
+// note: companion structure itself is generated
+// and its fields accesses are rewritten
+// only by ast walkers
+type split struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Curator erich split strategy with curator instance
+func (stg split) Curator(curator gopium.Curator) split {
+	stg.curator = curator
+	return stg
+}
+
+// Apply split implementation
+func (stg split) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// in case structure has no fields
+	// or has been already split skip it
+	if len(r.Fields) == 0 {
+		return r, ctx.Err()
+	}
+	for _, f := range r.Fields {
+		if f.Name == "cold" {
+			return r, ctx.Err()
+		}
+	}
+	// separate cold fields from hot fields
+	// and keep hot fields original order
+	h := gopium.Profiled(ctx)
+	hot := make([]gopium.Field, 0, len(r.Fields))
+	cold := make([]string, 0, len(r.Fields))
+	for _, f := range r.Fields {
+		if stg.cold(h, f) {
+			cold = append(cold, f.Name)
+			continue
+		}
+		hot = append(hot, f)
+	}
+	// in case there are no cold
+	// fields just skip structure
+	if len(cold) == 0 {
+		return r, ctx.Err()
+	}
+	// replace cold fields with
+	// companion pointer field
+	r.Fields = append(hot, collections.ColdField(r.Name, stg.curator.SysWord(), cold...))
+	return r, ctx.Err()
+}
+
+// cold checks if field is cold
+// either by named `cold` tag group
+// or by zero profile hotness
+func (stg split) cold(h gopium.Hotness, f gopium.Field) bool {
+	if f.Name == "_" || f.Embedded {
+		return false
+	}
+	if _, ok := reflect.StructTag(f.Tag).Lookup(gopium.NAME); ok {
+		if group, _, err := tgroup(f); err == nil && group == "cold" {
+			return true
+		}
+	}
+	return len(h) > 0 && h[f.Name] == 0
+}
@@ -0,0 +1,336 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestSplit(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "a",
+				Type:  "bool",
+				Size:  1,
+				Align: 1,
+			},
+			{
+				Name:  "big",
+				Type:  "[56]byte",
+				Size:  56,
+				Align: 1,
+				Tag:   `gopium:"group:cold;memory_pack"`,
+			},
+			{
+				Name:  "b",
+				Type:  "int64",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:     "E",
+				Type:     "E",
+				Size:     8,
+				Align:    8,
+				Exported: true,
+				Embedded: true,
+			},
+			{
+				Name:  "_",
+				Type:  "[8]byte",
+				Size:  8,
+				Align: 1,
+			},
+		},
+	}
+	h := gopium.Hotness{
+		"a":   17,
+		"big": 10,
+	}
+	table := map[string]struct {
+		c   gopium.Curator
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to empty struct": {
+			c:   mocks.Maven{SWord: 8},
+			ctx: gopium.WithHotness(context.Background(), h),
+		},
+		"struct without cold fields should be applied to itself": {
+			c:   mocks.Maven{SWord: 8},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:hot;memory_pack"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:hot;memory_pack"`,
+					},
+				},
+			},
+		},
+		"already split struct should be applied to itself": {
+			c:   mocks.Maven{SWord: 8},
+			ctx: gopium.WithHotness(context.Background(), h),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "cold",
+						Type:  "*testCold",
+						Size:  8,
+						Align: 8,
+						Ptr:   8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "cold",
+						Type:  "*testCold",
+						Size:  8,
+						Align: 8,
+						Ptr:   8,
+					},
+				},
+			},
+		},
+		"struct with cold tag group should be applied to split struct": {
+			c:   mocks.Maven{SWord: 8},
+			ctx: context.Background(),
+			o:   o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:     "E",
+						Type:     "E",
+						Size:     8,
+						Align:    8,
+						Exported: true,
+						Embedded: true,
+					},
+					{
+						Name:  "_",
+						Type:  "[8]byte",
+						Size:  8,
+						Align: 1,
+					},
+					{
+						Name:    "cold",
+						Type:    "*testCold",
+						Size:    8,
+						Align:   8,
+						Ptr:     8,
+						Comment: []string{"// cold fields: big; - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+		},
+		"struct with cold tag group without strategies should be applied to split struct": {
+			c:   mocks.Maven{SWord: 8},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"group:cold"`,
+					},
+					{
+						Name:  "b",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:cold;"`,
+					},
+					{
+						Name:  "c",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:hot"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "c",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"group:hot"`,
+					},
+					{
+						Name:    "cold",
+						Type:    "*testCold",
+						Size:    8,
+						Align:   8,
+						Ptr:     8,
+						Comment: []string{"// cold fields: a, b; - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+		},
+		"struct with fields hotness should be applied to split struct": {
+			c:   mocks.Maven{SWord: 4},
+			ctx: gopium.WithHotness(context.Background(), h),
+			o:   o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:     "E",
+						Type:     "E",
+						Size:     8,
+						Align:    8,
+						Exported: true,
+						Embedded: true,
+					},
+					{
+						Name:  "_",
+						Type:  "[8]byte",
+						Size:  8,
+						Align: 1,
+					},
+					{
+						Name:    "cold",
+						Type:    "*testCold",
+						Size:    4,
+						Align:   4,
+						Ptr:     4,
+						Comment: []string{"// cold fields: big, b; - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+		},
+		"struct with fields hotness should be applied to split struct on canceled context": {
+			c:   mocks.Maven{SWord: 8},
+			ctx: gopium.WithHotness(cctx, h),
+			o:   o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:     "E",
+						Type:     "E",
+						Size:     8,
+						Align:    8,
+						Exported: true,
+						Embedded: true,
+					},
+					{
+						Name:  "_",
+						Type:  "[8]byte",
+						Size:  8,
+						Align: 1,
+					},
+					{
+						Name:    "cold",
+						Type:    "*testCold",
+						Size:    8,
+						Align:   8,
+						Ptr:     8,
+						Comment: []string{"// cold fields: big, b; - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			split := splcold.Curator(tcase.c)
+			// exec
+			r, err := split.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
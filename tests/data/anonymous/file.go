@@ -0,0 +1,43 @@
+//go:build tests_data
+
+package anonymous
+
+type Config struct {
+	name  string
+	inner struct {
+		a bool
+		b int64
+		c bool
+	}
+	flag bool
+}
+
+var cfg struct {
+	x int8
+	y []string
+	z int8
+}
+
+var rows = []struct {
+	ok    bool
+	value float64
+	done  bool
+}{
+	{true, 1.5, false},
+	{false, 2.5, true},
+}
+
+var index map[string]struct {
+	id   int32
+	ptr  *Config
+	seen bool
+}
+
+func scope() int64 {
+	local := struct {
+		a int8
+		b int64
+		c int8
+	}{1, 2, 3}
+	return local.b
+}
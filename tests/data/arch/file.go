@@ -0,0 +1,34 @@
+//go:build tests_data
+
+package arch
+
+import (
+	"sync"
+)
+
+// Common is laid out
+// identically on all archs
+type Common struct {
+	A bool
+	B int32
+	C bool
+}
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	A int64
+	B [3]int32
+}
+
+type (
+	// Grouped depends
+	// on int64 alignment too
+	Grouped struct {
+		A int64
+		B [3]int32
+		C sync.Mutex
+	}
+	// Flag is common
+	Flag bool
+)
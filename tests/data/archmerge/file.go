@@ -0,0 +1,23 @@
+//go:build tests_data
+
+package archmerge
+
+import (
+	"sync"
+)
+
+// Common is laid out
+// identically on all archs
+type Common struct {
+	A bool
+	B int32
+	C bool
+}
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	A int64
+	B [3]int32
+	C sync.Mutex
+}
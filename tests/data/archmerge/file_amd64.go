@@ -0,0 +1,5 @@
+//go:build tests_data
+
+package archmerge
+
+const archName = "amd64"
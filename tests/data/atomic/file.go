@@ -0,0 +1,64 @@
+//go:build tests_data
+
+package atomic
+
+import "sync/atomic"
+
+type Counter struct {
+	ok   bool
+	hits int64
+	miss uint64
+	name string
+}
+
+func (c *Counter) hit() {
+	atomic.AddInt64(&c.hits, 1)
+}
+
+func (c *Counter) missed() uint64 {
+	return atomic.LoadUint64(&(c.miss))
+}
+
+type Stats struct {
+	flag  bool
+	total atomic.Int64
+	slots [2]atomic.Uint64
+	last  int32
+	ready atomic.Bool
+	conf  atomic.Pointer[Plain]
+}
+
+func (s *Stats) tick() {
+	atomic.AddInt32(&s.last, 1)
+}
+
+type Window struct {
+	open    bool
+	buckets [4]int64
+}
+
+func (w *Window) inc(i int) {
+	atomic.AddInt64(&w.buckets[i], 1)
+}
+
+type Outer struct {
+	tag   bool
+	inner Counter
+	ref   *Counter
+	Window
+}
+
+func (o *Outer) touch() {
+	atomic.StoreInt64(&o.inner.hits, 0)
+	atomic.StoreInt64(&o.ref.hits, 0)
+	atomic.SwapInt64(&o.buckets[0], 0)
+}
+
+type Plain struct {
+	a bool
+	b int64
+}
+
+func (p *Plain) load() int64 {
+	return atomic.LoadInt64(new(int64)) + p.b
+}
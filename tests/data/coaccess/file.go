@@ -0,0 +1,43 @@
+//go:build tests_data
+
+package coaccess
+
+type Base struct {
+	z int64
+}
+
+type Hot struct {
+	a   bool
+	big [56]byte
+	b   int64
+	c   bool
+	d   int64
+	Base
+}
+
+func (h *Hot) flags() (bool, bool) {
+	return h.a, h.c
+}
+
+func (h Hot) sum() int64 {
+	return h.b + h.d + h.z
+}
+
+func check(h *Hot) bool {
+	return h.a && h.c && h.big[0] == 0
+}
+
+func single() func(*Hot) int64 {
+	return func(h *Hot) int64 {
+		return h.b
+	}
+}
+
+type Cold struct {
+	a bool
+	b int64
+}
+
+func cold(c Cold) bool {
+	return c.a
+}
@@ -0,0 +1,34 @@
+//go:build tests_data
+
+package generic
+
+type Node[T any] struct {
+	a    bool
+	v    T
+	b    bool
+	next *Node[T]
+}
+
+type Pair[K comparable, V any] struct {
+	f bool
+	k K
+	v V
+}
+
+type Unused[T any] struct {
+	v T
+	a bool
+}
+
+type Plain struct {
+	a bool
+	b int64
+	c bool
+}
+
+var (
+	i Node[int64]
+	b Node[bool]
+	p Pair[int8, int64]
+	q = Pair[string, bool]{}
+)
@@ -2,7 +2,9 @@ package data
 
 import (
 	"fmt"
+	"go/ast"
 	"go/token"
+	"go/types"
 
 	"github.com/1pkg/gopium/gopium"
 )
@@ -40,6 +42,41 @@ func (l locator) Fset(loc string, fset *token.FileSet) (*token.FileSet, bool) {
 	return l.loc.Fset(loc, fset)
 }
 
+// Pin locator implementation
+func (l locator) Pin(p token.Pos, reason string) (string, bool) {
+	return l.loc.Pin(p, reason)
+}
+
+// Instances locator implementation
+func (l locator) Instances(p token.Pos, inst *types.Named) ([]*types.Named, bool) {
+	return l.loc.Instances(p, inst)
+}
+
+// Anonymous locator implementation
+func (l locator) Anonymous(st *types.Struct, ts *ast.TypeSpec) (*ast.TypeSpec, bool) {
+	return l.loc.Anonymous(st, ts)
+}
+
+// CoAccess locator implementation
+func (l locator) CoAccess(p token.Pos, fields ...string) (gopium.CoAccess, bool) {
+	return l.loc.CoAccess(p, fields...)
+}
+
+// Hotness locator implementation
+func (l locator) Hotness(p token.Pos, field string, samples int64) (gopium.Hotness, bool) {
+	return l.loc.Hotness(p, field, samples)
+}
+
+// Source locator implementation
+func (l locator) Source(loc string, src []byte) ([]byte, bool) {
+	return l.loc.Source(loc, src)
+}
+
+// Atomic locator implementation
+func (l locator) Atomic(p token.Pos, bits int64, fields ...string) (gopium.Atomics, bool) {
+	return l.loc.Atomic(p, bits, fields...)
+}
+
 // Root locator implementation
 func (l locator) Root() *token.FileSet {
 	return l.loc.Root()
@@ -57,7 +57,7 @@ func (p Parser) ParseTypes(ctx context.Context, src ...byte) (*types.Package, go
 		defer tmutex.Unlock()
 		tmutex.Lock()
 		// build full dir cache key
-		dir := filepath.Join(xparser.Root, xparser.Path)
+		dir := xparser.Path
 		// check if key exists in cache
 		if tp, ok := tcache[dir]; ok {
 			return tp.pkg, tp.loc, nil
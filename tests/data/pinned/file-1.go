@@ -0,0 +1,32 @@
+//go:build tests_data
+
+package pinned
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+type Inner struct {
+	a bool
+	b int64
+	c bool
+}
+
+type Header struct {
+	a bool
+	b int32
+	i Inner
+	c bool
+}
+
+func read(r io.Reader) (h Header, err error) {
+	err = binary.Read(r, binary.LittleEndian, &h)
+	return
+}
+
+type Free struct {
+	a bool
+	b int64
+	c bool
+}
@@ -0,0 +1,49 @@
+//go:build tests_data
+
+package pinned
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+type Raw struct {
+	a bool
+	b int64
+	c bool
+}
+
+func raw(b []byte) *Raw {
+	return (*Raw)(unsafe.Pointer(&b[0]))
+}
+
+type Offset struct {
+	a bool
+	b int64
+	c bool
+}
+
+var off = unsafe.Offsetof(Offset{}.b)
+
+type Row struct {
+	a bool
+	b int64
+	c bool
+}
+
+func fields(r Row) int {
+	return reflect.ValueOf(r).NumField()
+}
+
+type Mirror struct {
+	a bool
+	b int64
+	c bool
+}
+
+//go:linkname mirror runtime.mirror
+var mirror Mirror
+
+func both(r *Raw) int {
+	return reflect.TypeOf(r).Elem().NumField()
+}
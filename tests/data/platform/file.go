@@ -0,0 +1,9 @@
+//go:build tests_data
+
+package platform
+
+type Common struct {
+	A bool
+	B int64
+	C bool
+}
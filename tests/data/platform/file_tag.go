@@ -0,0 +1,9 @@
+//go:build tests_data && arm64
+
+package platform
+
+type Arm struct {
+	A bool
+	B int64
+	C bool
+}
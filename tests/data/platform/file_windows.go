@@ -0,0 +1,13 @@
+//go:build tests_data
+
+package platform
+
+type Windows struct {
+	A bool
+	B int64
+	C bool
+}
+
+func init() {
+	_ = Common{true, 1, false}
+}
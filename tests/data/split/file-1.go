@@ -0,0 +1,30 @@
+//go:build tests_data
+
+package split
+
+// Split defines split struct
+type Split struct {
+	id    int64
+	name  string   `gopium:"group:cold;memory_pack"`
+	hits  int64    // hits comment
+	notes []string `gopium:"group:cold;memory_pack"`
+	Stats
+} // split comment
+
+func (s *Split) hit() {
+	s.hits++
+	s.notes = append(s.notes, s.name)
+}
+
+type Stats struct {
+	Min, Max int64
+	Count    int64
+}
+
+type Pair[K comparable, V any] struct {
+	key  K
+	val  V
+	meta string
+}
+
+var p = Pair[string, int]{key: "k", val: 1, meta: "m"}
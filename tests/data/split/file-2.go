@@ -0,0 +1,19 @@
+//go:build tests_data
+
+package split
+
+var s = Split{1, "s", 0, nil, Stats{}}
+
+func names(ss []*Split) []string {
+	names := make([]string, 0, len(ss))
+	for _, s := range ss {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+var st = Stats{Min: 1, Max: 2, Count: 3}
+
+func max(s Split) int64 {
+	return s.Max + s.Stats.Max + s.Min
+}
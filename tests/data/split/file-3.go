@@ -0,0 +1,16 @@
+//go:build tests_data
+
+package split
+
+import "sync"
+
+var pool sync.Pool
+
+func copies(s *Split) Split {
+	c := *s
+	return c
+}
+
+func pooled() string {
+	return pool.Get().(*Split).name
+}
@@ -0,0 +1,26 @@
+//go:build tests_data
+
+package splitzero
+
+// Zero defines split struct
+// with used zero values
+type Zero struct {
+	id   int64
+	name string `gopium:"group:cold"`
+}
+
+// Pair defines generic split struct
+// with elided literal type
+type Pair[K comparable, V any] struct {
+	key  K
+	meta string `gopium:"group:cold"`
+}
+
+var ps = []Pair[string, int]{{key: "k"}}
+
+func zero() string {
+	var z Zero
+	n := new(Zero)
+	m := make(map[string]Zero)
+	return z.name + n.name + m["k"].name + make([]Zero, 2)[0].name
+}
@@ -0,0 +1,25 @@
+//go:build tests_data
+
+package unkeyed
+
+type A struct {
+	a bool
+	b int64
+	c bool
+}
+
+type B struct {
+	A
+	_ [8]byte
+	d string
+}
+
+var a = A{true, 10, false}
+
+type Exported struct {
+	X bool
+	Y int64
+	Z bool
+}
+
+var e = Exported{true, 20, true}
@@ -0,0 +1,18 @@
+//go:build tests_data
+
+package unkeyed
+
+import "strings"
+
+var as = []A{{true, 1, false}, {a: true}, {}}
+
+var pb = &B{A{false, 2, true}, [8]byte{}, strings.ToUpper("b")}
+
+func local() interface{} {
+	type C struct {
+		d bool
+		e []string
+		f bool
+	}
+	return map[string]C{"c": {true, nil, false}}
+}
@@ -0,0 +1,7 @@
+//go:build tests_data
+
+package unkeyed
+
+var i = 0
+
+var ints = []int{1, 2, 3}
@@ -0,0 +1,10 @@
+//go:build tests_data
+
+package zerotail
+
+// Tail ends with zero size field
+// which is padded by gc
+type Tail struct {
+	A int64
+	B struct{}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"go/ast"
+	"io"
 
 	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
@@ -62,3 +63,8 @@ type Apply struct {
 func (a Apply) Apply(context.Context, *ast.Package, gopium.Locator, gopium.Categorized) (*ast.Package, error) {
 	return nil, a.Err
 }
+
+// Report mock implementation
+func (a Apply) Report(io.Writer) gopium.Apply {
+	return a.Apply
+}
@@ -14,11 +14,15 @@ type Type struct {
 // Maven defines mock maven implementation
 type Maven struct {
 	SCache []int64         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	SSizes []int64         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	SWays  []int64         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	Types  map[string]Type `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	SWord  int64           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	SAlign int64           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	SDestr int64           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	SConst int64           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	_      [16]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
 
 // SysWord mock implementation
 func (m Maven) SysWord() int64 {
@@ -42,6 +46,40 @@ func (m Maven) SysCache(level uint) int64 {
 	return 0
 }
 
+// SysCacheSize mock implementation
+func (m Maven) SysCacheSize(level uint) int64 {
+	// decrement level to match index
+	l := int(level) - 1
+	// check if we have it in vals
+	if l >= 0 && l < len(m.SSizes) {
+		return m.SSizes[l]
+	}
+	// otherwise return default val
+	return 0
+}
+
+// SysCacheWays mock implementation
+func (m Maven) SysCacheWays(level uint) int64 {
+	// decrement level to match index
+	l := int(level) - 1
+	// check if we have it in vals
+	if l >= 0 && l < len(m.SWays) {
+		return m.SWays[l]
+	}
+	// otherwise return default val
+	return 0
+}
+
+// SysDestructive mock implementation
+func (m Maven) SysDestructive() int64 {
+	return m.SDestr
+}
+
+// SysConstructive mock implementation
+func (m Maven) SysConstructive() int64 {
+	return m.SConst
+}
+
 // Name mock implementation
 func (m Maven) Name(t types.Type) string {
 	// check if we have it in vals
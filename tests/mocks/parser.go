@@ -18,8 +18,15 @@ type Pos struct {
 
 // Locator defines mock locator implementation
 type Locator struct {
-	Poses map[token.Pos]Pos `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 8 bytes; struct align: 8 bytes; struct aligned size: 8 bytes; - 🌺 gopium @1pkg
+	Poses map[token.Pos]Pos               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Pins  map[token.Pos]string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Insts map[token.Pos][]*types.Named    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Anons map[*types.Struct]*ast.TypeSpec `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Accs  map[token.Pos]gopium.CoAccess   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Heats map[token.Pos]gopium.Hotness    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Atoms map[token.Pos]gopium.Atomics    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Srcs  map[string][]byte               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; - 🌺 gopium @1pkg
 
 // ID mock implementation
 func (l Locator) ID(pos token.Pos) string {
@@ -51,6 +58,76 @@ func (l Locator) Fset(string, *token.FileSet) (*token.FileSet, bool) {
 	return token.NewFileSet(), true
 }
 
+// Pin mock implementation
+func (l Locator) Pin(pos token.Pos, _ string) (string, bool) {
+	// check if we have it in vals
+	if reason, ok := l.Pins[pos]; ok {
+		return reason, true
+	}
+	// otherwise return default val
+	return "", false
+}
+
+// Instances mock implementation
+func (l Locator) Instances(pos token.Pos, _ *types.Named) ([]*types.Named, bool) {
+	// check if we have it in vals
+	if insts, ok := l.Insts[pos]; ok {
+		return insts, true
+	}
+	// otherwise return default val
+	return nil, false
+}
+
+// Anonymous mock implementation
+func (l Locator) Anonymous(st *types.Struct, _ *ast.TypeSpec) (*ast.TypeSpec, bool) {
+	// check if we have it in vals
+	if ts, ok := l.Anons[st]; ok {
+		return ts, true
+	}
+	// otherwise return default val
+	return nil, false
+}
+
+// CoAccess mock implementation
+func (l Locator) CoAccess(pos token.Pos, _ ...string) (gopium.CoAccess, bool) {
+	// check if we have it in vals
+	if ca, ok := l.Accs[pos]; ok {
+		return ca, true
+	}
+	// otherwise return default val
+	return nil, false
+}
+
+// Hotness mock implementation
+func (l Locator) Hotness(pos token.Pos, _ string, _ int64) (gopium.Hotness, bool) {
+	// check if we have it in vals
+	if h, ok := l.Heats[pos]; ok {
+		return h, true
+	}
+	// otherwise return default val
+	return nil, false
+}
+
+// Source mock implementation
+func (l Locator) Source(loc string, _ []byte) ([]byte, bool) {
+	// check if we have it in vals
+	if src, ok := l.Srcs[loc]; ok {
+		return src, true
+	}
+	// otherwise return default val
+	return nil, false
+}
+
+// Atomic mock implementation
+func (l Locator) Atomic(pos token.Pos, _ int64, _ ...string) (gopium.Atomics, bool) {
+	// check if we have it in vals
+	if a, ok := l.Atoms[pos]; ok {
+		return a, true
+	}
+	// otherwise return default val
+	return nil, false
+}
+
 // Root mock implementation
 func (l Locator) Root() *token.FileSet {
 	return token.NewFileSet()
@@ -83,3 +160,20 @@ func (p Parser) ParseAst(ctx context.Context, src ...byte) (*ast.Package, gopium
 	}
 	return &ast.Package{}, Locator{}, p.Asterr
 }
+
+// Expander defines mock expander implementation
+type Expander struct {
+	Parsers []gopium.Parser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Err     error           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Parser  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [24]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; - 🌺 gopium @1pkg
+
+// Expand mock implementation
+func (e Expander) Expand(ctx context.Context) ([]gopium.Parser, error) {
+	// check error at start
+	if e.Err != nil {
+		return nil, e.Err
+	}
+	return e.Parsers, ctx.Err()
+}
@@ -0,0 +1,109 @@
+package typepkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// anonymous goes through all package files
+// and collects all concrete anonymous struct types
+// with their synthetic type specs inside locator
+func anonymous(pkg *types.Package, info *types.Info, files []*ast.File, loc *Locator) {
+	// skip packages without types info
+	if pkg == nil || info == nil {
+		return
+	}
+	for _, file := range files {
+		// path keeps all ast ancestors
+		// of currently inspected node
+		var path []ast.Node
+		ast.Inspect(file, func(node ast.Node) bool {
+			// pop node from path
+			// after its children are inspected
+			if node == nil {
+				path = path[:len(path)-1]
+				return true
+			}
+			if st, ok := node.(*ast.StructType); ok {
+				// skip non anonymous and empty structs
+				// and structs parametrized by type params
+				tst, ok := info.TypeOf(st).(*types.Struct)
+				if ok && tst.NumFields() > 0 && concrete(tst) {
+					if ts, ok := AnonymousSpec(path, st); ok {
+						loc.Anonymous(tst, ts)
+					}
+				}
+			}
+			path = append(path, node)
+			return true
+		})
+	}
+}
+
+// AnonymousSpec builds synthetic type spec
+// for anonymous struct type from its ast ancestors path,
+// synthetic type spec shares the same position
+// as struct keyword and is named after dot separated
+// names of all enclosing declarations and fields
+func AnonymousSpec(path []ast.Node, st *ast.StructType) (*ast.TypeSpec, bool) {
+	// skip struct types that
+	// belong to type specs directly
+	if len(path) > 0 {
+		if ts, ok := path[len(path)-1].(*ast.TypeSpec); ok && ts.Type == st {
+			return nil, false
+		}
+	}
+	// collect enclosing names
+	// from inner to outer nodes
+	names := make([]string, 0, len(path))
+loop:
+	for i := len(path) - 1; i >= 0; i-- {
+		switch n := path[i].(type) {
+		case *ast.Field:
+			if len(n.Names) > 0 {
+				names = append(names, n.Names[0].Name)
+			}
+		case *ast.ValueSpec:
+			names = append(names, n.Names[0].Name)
+		case *ast.AssignStmt:
+			if id, ok := n.Lhs[0].(*ast.Ident); ok {
+				names = append(names, id.Name)
+			}
+		case *ast.KeyValueExpr:
+			if id, ok := n.Key.(*ast.Ident); ok {
+				names = append(names, id.Name)
+			}
+		case *ast.TypeSpec:
+			names = append(names, n.Name.Name)
+			break loop
+		case *ast.FuncDecl:
+			names = append(names, n.Name.Name)
+			break loop
+		}
+	}
+	// reverse collected names
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	name := strings.Join(names, ".")
+	if name == "" {
+		name = "struct"
+	}
+	return &ast.TypeSpec{
+		Name: &ast.Ident{Name: name, NamePos: st.Struct},
+		Type: st,
+	}, true
+}
+
+// AnonymousID builds id for anonymous
+// struct type at position using locator,
+// as several struct types could share
+// the same line it appends column to id
+func AnonymousID(loc gopium.Locator, p token.Pos) string {
+	return fmt.Sprintf("%s:%d", loc.ID(p), loc.Root().Position(p).Column)
+}
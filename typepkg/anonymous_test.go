@@ -0,0 +1,146 @@
+package typepkg
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/tests"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestAnonymous(t *testing.T) {
+	// prepare
+	p := &ParserXToolPackagesAst{
+		Pattern: "github.com/1pkg/gopium/tests/data/anonymous",
+		Path:    filepath.Join(tests.Gopium, "tests", "data", "anonymous"),
+		//nolint
+		ModeTypes:  packages.LoadAllSyntax,
+		ModeAst:    parser.ParseComments | parser.AllErrors,
+		BuildFlags: []string{"-tags=tests_data"},
+	}
+	pkg, loc, err := p.ParseTypes(context.Background())
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	scope := pkg.Scope()
+	config := scope.Lookup("Config").Type().Underlying().(*types.Struct)
+	table := map[string]struct {
+		st   types.Type
+		name string
+		line int
+		ok   bool
+	}{
+		"anonymous struct field should be collected with struct name prefix": {
+			st:   config.Field(1).Type(),
+			name: "Config.inner",
+			line: 7,
+			ok:   true,
+		},
+		"anonymous struct var should be collected with var name": {
+			st:   scope.Lookup("cfg").Type(),
+			name: "cfg",
+			line: 15,
+			ok:   true,
+		},
+		"anonymous struct slice element should be collected with var name": {
+			st:   scope.Lookup("rows").Type().(*types.Slice).Elem(),
+			name: "rows",
+			line: 21,
+			ok:   true,
+		},
+		"anonymous struct map element should be collected with var name": {
+			st:   scope.Lookup("index").Type().(*types.Map).Elem(),
+			name: "index",
+			line: 30,
+			ok:   true,
+		},
+		"type spec struct shouldn't be collected": {
+			st: config,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ts, ok := loc.Anonymous(tcase.st.(*types.Struct), nil)
+			// check
+			var name string
+			var line int
+			if ts != nil {
+				name = ts.Name.Name
+				line = loc.Root().Position(ts.Pos()).Line
+			}
+			if !reflect.DeepEqual(name, tcase.name) {
+				t.Errorf("actual %v doesn't equal to expected %v", name, tcase.name)
+			}
+			if !reflect.DeepEqual(line, tcase.line) {
+				t.Errorf("actual %v doesn't equal to expected %v", line, tcase.line)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
+
+func TestAnonymousSpec(t *testing.T) {
+	// prepare
+	st := &ast.StructType{Struct: 10, Fields: &ast.FieldList{}}
+	table := map[string]struct {
+		path []ast.Node
+		name string
+		ok   bool
+	}{
+		"struct directly under type spec shouldn't be anonymous": {
+			path: []ast.Node{&ast.TypeSpec{Name: ast.NewIdent("test"), Type: st}},
+		},
+		"struct without any enclosing names should be named after struct": {
+			name: "struct",
+			ok:   true,
+		},
+		"struct inside function local assign should be named after function and var": {
+			path: []ast.Node{
+				&ast.FuncDecl{Name: ast.NewIdent("fn")},
+				&ast.AssignStmt{Lhs: []ast.Expr{ast.NewIdent("local")}},
+				&ast.CompositeLit{},
+			},
+			name: "fn.local",
+			ok:   true,
+		},
+		"struct inside nested fields should be named after all fields": {
+			path: []ast.Node{
+				&ast.TypeSpec{Name: ast.NewIdent("test"), Type: &ast.StructType{}},
+				&ast.Field{Names: []*ast.Ident{ast.NewIdent("a"), ast.NewIdent("b")}},
+				&ast.StarExpr{},
+				&ast.Field{Names: []*ast.Ident{ast.NewIdent("c")}},
+			},
+			name: "test.a.c",
+			ok:   true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ts, ok := AnonymousSpec(tcase.path, st)
+			// check
+			var name string
+			if ts != nil {
+				name = ts.Name.Name
+				if !reflect.DeepEqual(ts.Pos(), st.Pos()) {
+					t.Errorf("actual %v doesn't equal to expected %v", ts.Pos(), st.Pos())
+				}
+			}
+			if !reflect.DeepEqual(name, tcase.name) {
+				t.Errorf("actual %v doesn't equal to expected %v", name, tcase.name)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package typepkg
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// atomics goes through all package files
+// and collects package structs fields
+// accessed by sync/atomic functions
+// or having sync/atomic types inside locator
+// as struct atomic fields with operations width,
+// fields on the path to accessed nested field
+// are atomic as well unless path goes through pointer
+func atomics(pkg *types.Package, info *types.Info, files []*ast.File, loc *Locator) {
+	// skip packages without types info
+	if pkg == nil || info == nil {
+		return
+	}
+	// mark defines marking helper
+	// that marks provided type struct field
+	// as atomic field inside locator
+	mark := func(t types.Type, bits int64, field string) {
+		if p, st := pinned(pkg, t, loc); st != nil {
+			loc.Atomic(p, bits, field)
+		}
+	}
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.TypeSpec:
+				// named structs with atomic typed fields
+				if obj, ok := info.Defs[n.Name]; ok && obj != nil {
+					typed(pkg, obj.Type(), loc)
+				}
+			case *ast.StructType:
+				// anonymous structs with atomic typed fields
+				if st, ok := info.TypeOf(n).(*types.Struct); ok {
+					typed(pkg, st, loc)
+				}
+			case *ast.CallExpr:
+				// sync/atomic functions calls
+				fn, ok := typeutil.Callee(info, n).(*types.Func)
+				if !ok || len(n.Args) == 0 {
+					return true
+				}
+				bits, ok := atomicf(fn)
+				if !ok {
+					return true
+				}
+				addr, ok := ast.Unparen(n.Args[0]).(*ast.UnaryExpr)
+				if !ok || addr.Op != token.AND {
+					return true
+				}
+				// go through accessed field selectors chain
+				// from the innermost field to the outermost
+				x := addr.X
+				for {
+					x = ast.Unparen(x)
+					// arrays elements are aligned
+					// only if array field is aligned
+					if idx, ok := x.(*ast.IndexExpr); ok {
+						if t := info.TypeOf(idx.X); t != nil {
+							if _, ok := t.Underlying().(*types.Array); ok {
+								x = idx.X
+								continue
+							}
+						}
+					}
+					sel, ok := x.(*ast.SelectorExpr)
+					if !ok {
+						break
+					}
+					s, ok := info.Selections[sel]
+					if !ok || s.Kind() != types.FieldVal {
+						break
+					}
+					// promoted fields are accessed
+					// through embedded fields path
+					t, path := s.Recv(), s.Index()
+					owners := make([]types.Type, 0, len(path))
+					names := make([]string, 0, len(path))
+					for _, i := range path {
+						st, ok := deref(t).Underlying().(*types.Struct)
+						if !ok {
+							break
+						}
+						owners = append(owners, t)
+						names = append(names, st.Field(i).Name())
+						t = st.Field(i).Type()
+					}
+					// mark fields from the innermost one
+					// until embedded pointer field is met
+					direct := true
+					for i := len(names) - 1; i >= 0; i-- {
+						mark(owners[i], bits, names[i])
+						if i > 0 && ptr(owners[i]) {
+							direct = false
+							break
+						}
+					}
+					// stop on pointer receivers
+					// as they are allocated separately
+					if !direct || ptr(info.TypeOf(sel.X)) {
+						break
+					}
+					x = sel.X
+				}
+			}
+			return true
+		})
+	}
+}
+
+// typed marks all package struct fields
+// having sync/atomic types
+// as atomic fields inside locator
+func typed(pkg *types.Package, t types.Type, loc *Locator) {
+	p, st := pinned(pkg, t, loc)
+	// skip pointers to structs
+	// as they are not fields owners
+	if st == nil || ptr(t) {
+		return
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if bits, ok := atomict(f.Type()); ok {
+			loc.Atomic(p, bits, f.Name())
+		}
+	}
+}
+
+// atomicf checks if function is
+// sync/atomic package function and
+// returns its operations bits width
+func atomicf(fn *types.Func) (int64, bool) {
+	if fn.Pkg() == nil || fn.Pkg().Path() != "sync/atomic" {
+		return 0, false
+	}
+	// skip sync/atomic types methods
+	// as such fields are typed
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return 0, false
+	}
+	return atomicw(fn.Name())
+}
+
+// atomict checks if type is either
+// sync/atomic type or array or struct
+// containing it and returns max
+// its operations bits width
+func atomict(t types.Type) (int64, bool) {
+	switch tp := types.Unalias(t).(type) {
+	case *types.Named:
+		if obj := tp.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "sync/atomic" {
+			return atomicw(obj.Name())
+		}
+		return atomict(tp.Underlying())
+	case *types.Array:
+		return atomict(tp.Elem())
+	case *types.Struct:
+		// structs can't contain
+		// themselves by value
+		var bits int64
+		var found bool
+		for i := 0; i < tp.NumFields(); i++ {
+			if w, ok := atomict(tp.Field(i).Type()); ok {
+				if !found || w > bits {
+					bits = w
+				}
+				found = true
+			}
+		}
+		return bits, found
+	}
+	return 0, false
+}
+
+// atomicw returns bits width of
+// sync/atomic function or type by name,
+// zero width stands for platform word width
+func atomicw(name string) (int64, bool) {
+	switch {
+	case strings.HasSuffix(name, "Int64"), strings.HasSuffix(name, "Uint64"):
+		return 64, true
+	case strings.HasSuffix(name, "Int32"), strings.HasSuffix(name, "Uint32"), name == "Bool":
+		return 32, true
+	case strings.HasSuffix(name, "Uintptr"), strings.HasSuffix(name, "Pointer"), name == "Value":
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// deref returns pointer elem type
+// or type itself for non pointers
+func deref(t types.Type) types.Type {
+	if t == nil {
+		return types.Typ[types.Invalid]
+	}
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
+
+// ptr checks if type is pointer
+func ptr(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	_, ok := t.Underlying().(*types.Pointer)
+	return ok
+}
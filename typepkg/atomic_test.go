@@ -0,0 +1,72 @@
+package typepkg
+
+import (
+	"context"
+	"go/parser"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestAtomics(t *testing.T) {
+	// prepare
+	p := &ParserXToolPackagesAst{
+		Pattern: "github.com/1pkg/gopium/tests/data/atomic",
+		Path:    filepath.Join(tests.Gopium, "tests", "data", "atomic"),
+		//nolint
+		ModeTypes:  packages.LoadAllSyntax,
+		ModeAst:    parser.ParseComments | parser.AllErrors,
+		BuildFlags: []string{"-tags=tests_data"},
+	}
+	pkg, loc, err := p.ParseTypes(context.Background())
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		name string
+		a    gopium.Atomics
+		ok   bool
+	}{
+		"struct with atomically accessed fields should collect atomic fields": {
+			name: "Counter",
+			a:    gopium.Atomics{"hits": 64, "miss": 64},
+			ok:   true,
+		},
+		"struct with atomic typed and accessed fields should collect atomic fields": {
+			name: "Stats",
+			a:    gopium.Atomics{"total": 64, "slots": 64, "last": 32, "ready": 32, "conf": 0},
+			ok:   true,
+		},
+		"struct with atomically accessed array elements should collect atomic fields": {
+			name: "Window",
+			a:    gopium.Atomics{"buckets": 64},
+			ok:   true,
+		},
+		"struct with atomically accessed nested fields should collect atomic fields": {
+			name: "Outer",
+			a:    gopium.Atomics{"inner": 64, "Window": 64},
+			ok:   true,
+		},
+		"struct without atomic fields should collect nothing": {
+			name: "Plain",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			a, ok := loc.Atomic(pkg.Scope().Lookup(tcase.name).Pos(), 0)
+			// check
+			if !reflect.DeepEqual(a, tcase.a) {
+				t.Errorf("actual %v doesn't equal to expected %v", a, tcase.a)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package typepkg
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// coaccess goes through all package functions bodies
+// and builds fields co-access graphs of package structs
+// inside locator from fields selector expressions,
+// fields pair weight is number of functions
+// that access both fields of the struct
+func coaccess(pkg *types.Package, info *types.Info, files []*ast.File, loc *Locator) {
+	// skip packages without types info
+	if pkg == nil || info == nil {
+		return
+	}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			// skip non function decls
+			// and functions without bodies
+			fdecl, ok := decl.(*ast.FuncDecl)
+			if !ok || fdecl.Body == nil {
+				continue
+			}
+			ps, accessed := accesses(pkg, info, fdecl, loc)
+			for _, p := range ps {
+				loc.CoAccess(p, accessed[p]...)
+			}
+		}
+	}
+}
+
+// accesses collects all package structs fields
+// accessed inside function body including
+// nested function literals from fields selector
+// expressions and returns structs positions
+// in order of their first access with their fields
+func accesses(pkg *types.Package, info *types.Info, fdecl *ast.FuncDecl, loc *Locator) ([]token.Pos, map[token.Pos][]string) {
+	var ps []token.Pos
+	accessed := make(map[token.Pos][]string)
+	ast.Inspect(fdecl.Body, func(node ast.Node) bool {
+		sel, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		// skip non fields selections
+		s, ok := info.Selections[sel]
+		if !ok || s.Kind() != types.FieldVal {
+			return true
+		}
+		// skip structs of other packages
+		p, st := pinned(pkg, s.Recv(), loc)
+		if st == nil {
+			return true
+		}
+		// promoted fields are accessed
+		// through their embedded field
+		name := st.Field(s.Index()[0]).Name()
+		if _, ok := accessed[p]; !ok {
+			ps = append(ps, p)
+		}
+		accessed[p] = append(accessed[p], name)
+		return true
+	})
+	return ps, accessed
+}
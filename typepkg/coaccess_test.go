@@ -0,0 +1,67 @@
+package typepkg
+
+import (
+	"context"
+	"go/parser"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestCoAccess(t *testing.T) {
+	// prepare
+	p := &ParserXToolPackagesAst{
+		Pattern: "github.com/1pkg/gopium/tests/data/coaccess",
+		Path:    filepath.Join(tests.Gopium, "tests", "data", "coaccess"),
+		//nolint
+		ModeTypes:  packages.LoadAllSyntax,
+		ModeAst:    parser.ParseComments | parser.AllErrors,
+		BuildFlags: []string{"-tags=tests_data"},
+	}
+	pkg, loc, err := p.ParseTypes(context.Background())
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		name string
+		ca   gopium.CoAccess
+		ok   bool
+	}{
+		"struct with co-accessed fields should build co-access graph": {
+			name: "Hot",
+			ca: gopium.CoAccess{
+				"a":    {"c": 2, "big": 1},
+				"c":    {"a": 2, "big": 1},
+				"big":  {"a": 1, "c": 1},
+				"b":    {"d": 1, "Base": 1},
+				"d":    {"b": 1, "Base": 1},
+				"Base": {"b": 1, "d": 1},
+			},
+			ok: true,
+		},
+		"struct with single accessed fields should build nothing": {
+			name: "Cold",
+		},
+		"struct with only promoted accesses should build nothing": {
+			name: "Base",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ca, ok := loc.CoAccess(pkg.Scope().Lookup(tcase.name).Pos())
+			// check
+			if !reflect.DeepEqual(ca, tcase.ca) {
+				t.Errorf("actual %v doesn't equal to expected %v", ca, tcase.ca)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package typepkg
+
+import (
+	"go/types"
+)
+
+// instantiate goes through all package
+// types instances and collects all concrete
+// package generic structs instances inside locator
+func instantiate(pkg *types.Package, info *types.Info, loc *Locator) {
+	// skip packages without types info
+	if pkg == nil || info == nil {
+		return
+	}
+	for _, inst := range info.Instances {
+		// skip non struct instances
+		// and instances of other packages
+		named, ok := inst.Type.(*types.Named)
+		if !ok || named.Obj().Pkg() != pkg {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			continue
+		}
+		// skip instances that are still
+		// parametrized by type params
+		// like `next *Node[T]` inside `Node[T]`
+		if !concrete(named) {
+			continue
+		}
+		loc.Instances(named.Origin().Obj().Pos(), named)
+	}
+}
+
+// concrete checks if provided type
+// doesn't refer to any type params
+func concrete(t types.Type) bool {
+	switch tp := t.(type) {
+	case *types.TypeParam:
+		return false
+	case *types.Pointer:
+		return concrete(tp.Elem())
+	case *types.Slice:
+		return concrete(tp.Elem())
+	case *types.Array:
+		return concrete(tp.Elem())
+	case *types.Chan:
+		return concrete(tp.Elem())
+	case *types.Map:
+		return concrete(tp.Key()) && concrete(tp.Elem())
+	case *types.Tuple:
+		for i := 0; i < tp.Len(); i++ {
+			if !concrete(tp.At(i).Type()) {
+				return false
+			}
+		}
+	case *types.Signature:
+		return concrete(tp.Params()) && concrete(tp.Results())
+	case *types.Struct:
+		for i := 0; i < tp.NumFields(); i++ {
+			if !concrete(tp.Field(i).Type()) {
+				return false
+			}
+		}
+	case *types.Named:
+		args := tp.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			if !concrete(args.At(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
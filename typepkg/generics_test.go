@@ -0,0 +1,74 @@
+package typepkg
+
+import (
+	"context"
+	"go/parser"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/tests"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestInstantiate(t *testing.T) {
+	// prepare
+	p := &ParserXToolPackagesAst{
+		Pattern: "github.com/1pkg/gopium/tests/data/generic",
+		Path:    filepath.Join(tests.Gopium, "tests", "data", "generic"),
+		//nolint
+		ModeTypes:  packages.LoadAllSyntax,
+		ModeAst:    parser.ParseComments | parser.AllErrors,
+		BuildFlags: []string{"-tags=tests_data"},
+	}
+	pkg, loc, err := p.ParseTypes(context.Background())
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		name  string
+		insts []string
+		ok    bool
+	}{
+		"generic struct should collect only concrete instances": {
+			name: "Node",
+			insts: []string{
+				"github.com/1pkg/gopium/tests/data/generic.Node[bool]",
+				"github.com/1pkg/gopium/tests/data/generic.Node[int64]",
+			},
+			ok: true,
+		},
+		"generic struct with multiple type params should collect all instances": {
+			name: "Pair",
+			insts: []string{
+				"github.com/1pkg/gopium/tests/data/generic.Pair[int8, int64]",
+				"github.com/1pkg/gopium/tests/data/generic.Pair[string, bool]",
+			},
+			ok: true,
+		},
+		"unused generic struct should collect nothing": {
+			name: "Unused",
+		},
+		"non generic struct should collect nothing": {
+			name: "Plain",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			insts, ok := loc.Instances(pkg.Scope().Lookup(tcase.name).Pos(), nil)
+			// check
+			var names []string
+			for _, inst := range insts {
+				names = append(names, inst.String())
+			}
+			if !reflect.DeepEqual(names, tcase.insts) {
+				t.Errorf("actual %v doesn't equal to expected %v", names, tcase.insts)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"go/ast"
 	"go/token"
+	"go/types"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/1pkg/gopium/gopium"
@@ -14,11 +18,18 @@ import (
 // encapsulate pkgs token.FileSets and provides
 // some operations on top of it
 type Locator struct {
-	root  *token.FileSet            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	extra map[string]*token.FileSet `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	mutex sync.Mutex                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_     [8]byte                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+	root  *token.FileSet                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	extra map[string]*token.FileSet       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	srcs  map[string][]byte               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	pins  map[token.Pos][]string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	insts map[token.Pos][]*types.Named    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	anons map[*types.Struct]*ast.TypeSpec `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	accs  map[token.Pos]gopium.CoAccess   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	heats map[token.Pos]gopium.Hotness    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	atoms map[token.Pos]gopium.Atomics    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	mutex sync.Mutex                      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [48]byte                        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
 
 // NewLocator creates new locator instance
 // from provided file set
@@ -31,6 +42,13 @@ func NewLocator(fset *token.FileSet) *Locator {
 	return &Locator{
 		root:  fset,
 		extra: make(map[string]*token.FileSet),
+		srcs:  make(map[string][]byte),
+		pins:  make(map[token.Pos][]string),
+		insts: make(map[token.Pos][]*types.Named),
+		anons: make(map[*types.Struct]*ast.TypeSpec),
+		accs:  make(map[token.Pos]gopium.CoAccess),
+		heats: make(map[token.Pos]gopium.Hotness),
+		atoms: make(map[token.Pos]gopium.Atomics),
 	}
 }
 
@@ -85,6 +103,189 @@ func (l *Locator) Fset(loc string, fset *token.FileSet) (*token.FileSet, bool) {
 	return fset, true
 }
 
+// Source multifunc method that
+// either set original source for location
+// or returns original source if any,
+// sources are set only for locations
+// which contents differ from os files
+// (like editor overlays unsaved buffers)
+func (l *Locator) Source(loc string, src []byte) ([]byte, bool) {
+	// lock concurrent map access
+	defer l.mutex.Unlock()
+	l.mutex.Lock()
+	// if src isn't nil
+	// write it to sources
+	if src != nil {
+		l.srcs[loc] = src
+		return src, true
+	}
+	// otherwise read it from sources
+	src, ok := l.srcs[loc]
+	return src, ok
+}
+
+// Pin multifunc method that
+// either pins type at position with reason
+// or returns all joined type pinning reasons
+func (l *Locator) Pin(p token.Pos, reason string) (string, bool) {
+	// lock concurrent map access
+	defer l.mutex.Unlock()
+	l.mutex.Lock()
+	// if reason isn't empty
+	// add it to type reasons
+	if reason != "" {
+		l.pins[p] = append(l.pins[p], reason)
+	}
+	// then read all type reasons
+	reasons, ok := l.pins[p]
+	return strings.Join(reasons, ", "), ok
+}
+
+// Instances multifunc method that
+// either adds generic type instance at position
+// or returns all generic type instances
+// sorted by their types names
+func (l *Locator) Instances(p token.Pos, inst *types.Named) ([]*types.Named, bool) {
+	// lock concurrent map access
+	defer l.mutex.Unlock()
+	l.mutex.Lock()
+	// if instance isn't nil
+	// and it's not identical
+	// to known instances add it
+	if inst != nil {
+		for _, known := range l.insts[p] {
+			if types.Identical(known, inst) {
+				inst = nil
+				break
+			}
+		}
+	}
+	if inst != nil {
+		insts := append(l.insts[p], inst)
+		sort.SliceStable(insts, func(i, j int) bool {
+			return insts[i].String() < insts[j].String()
+		})
+		l.insts[p] = insts
+	}
+	// then read all type instances
+	insts, ok := l.insts[p]
+	return insts, ok
+}
+
+// Anonymous multifunc method that
+// either sets synthetic type spec
+// for anonymous struct type
+// or returns synthetic type spec if any
+func (l *Locator) Anonymous(st *types.Struct, ts *ast.TypeSpec) (*ast.TypeSpec, bool) {
+	// lock concurrent map access
+	defer l.mutex.Unlock()
+	l.mutex.Lock()
+	// if type spec isn't nil
+	// and struct type hasn't
+	// been set already set it
+	if _, ok := l.anons[st]; ts != nil && !ok {
+		l.anons[st] = ts
+	}
+	// then read struct type spec
+	ts, ok := l.anons[st]
+	return ts, ok
+}
+
+// CoAccess multifunc method that
+// either adds fields accessed together
+// by single function to type co-access graph
+// or returns type fields co-access graph
+func (l *Locator) CoAccess(p token.Pos, fields ...string) (gopium.CoAccess, bool) {
+	// lock concurrent map access
+	defer l.mutex.Unlock()
+	l.mutex.Lock()
+	// collect distinct fields first
+	distinct := make([]string, 0, len(fields))
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if !known[f] {
+			known[f] = true
+			distinct = append(distinct, f)
+		}
+	}
+	// if there are at least two fields
+	// increment weights of all distinct
+	// fields pairs in both directions
+	if len(distinct) > 1 {
+		ca, ok := l.accs[p]
+		if !ok {
+			ca = make(gopium.CoAccess)
+			l.accs[p] = ca
+		}
+		for i, f1 := range distinct {
+			for _, f2 := range distinct[i+1:] {
+				if ca[f1] == nil {
+					ca[f1] = make(map[string]int64)
+				}
+				if ca[f2] == nil {
+					ca[f2] = make(map[string]int64)
+				}
+				ca[f1][f2]++
+				ca[f2][f1]++
+			}
+		}
+	}
+	// then read type co-access graph
+	ca, ok := l.accs[p]
+	return ca, ok
+}
+
+// Hotness multifunc method that
+// either adds profile samples of function
+// accessing type field to type fields hotness
+// or returns type fields hotness
+func (l *Locator) Hotness(p token.Pos, field string, samples int64) (gopium.Hotness, bool) {
+	// lock concurrent map access
+	defer l.mutex.Unlock()
+	l.mutex.Lock()
+	// if field isn't empty and samples
+	// are positive add them to field hotness
+	if field != "" && samples > 0 {
+		h, ok := l.heats[p]
+		if !ok {
+			h = make(gopium.Hotness)
+			l.heats[p] = h
+		}
+		h[field] += samples
+	}
+	// then read type fields hotness
+	h, ok := l.heats[p]
+	return h, ok
+}
+
+// Atomic multifunc method that
+// either adds fields accessed by atomic
+// operations of provided bits width
+// to type atomic fields keeping max width
+// or returns type atomic fields
+func (l *Locator) Atomic(p token.Pos, bits int64, fields ...string) (gopium.Atomics, bool) {
+	// lock concurrent map access
+	defer l.mutex.Unlock()
+	l.mutex.Lock()
+	// if there are any fields
+	// add them to atomic fields
+	if len(fields) > 0 {
+		a, ok := l.atoms[p]
+		if !ok {
+			a = make(gopium.Atomics)
+			l.atoms[p] = a
+		}
+		for _, f := range fields {
+			if w, ok := a[f]; !ok || bits > w {
+				a[f] = bits
+			}
+		}
+	}
+	// then read type atomic fields
+	a, ok := l.atoms[p]
+	return a, ok
+}
+
 // Root just returns root token.FileSet back
 func (l *Locator) Root() *token.FileSet {
 	return l.root
@@ -1,7 +1,9 @@
 package typepkg
 
 import (
+	"go/ast"
 	"go/token"
+	"go/types"
 	"reflect"
 	"testing"
 
@@ -22,6 +24,13 @@ func TestNewLocatorRoot(t *testing.T) {
 			loc: &Locator{
 				root:  token.NewFileSet(),
 				extra: make(map[string]*token.FileSet),
+				srcs:  make(map[string][]byte),
+				pins:  make(map[token.Pos][]string),
+				insts: make(map[token.Pos][]*types.Named),
+				anons: make(map[*types.Struct]*ast.TypeSpec),
+				accs:  make(map[token.Pos]gopium.CoAccess),
+				heats: make(map[token.Pos]gopium.Hotness),
+				atoms: make(map[token.Pos]gopium.Atomics),
 			},
 		},
 		"non nil fset should return custom locator": {
@@ -30,6 +39,13 @@ func TestNewLocatorRoot(t *testing.T) {
 			loc: &Locator{
 				root:  fset,
 				extra: make(map[string]*token.FileSet),
+				srcs:  make(map[string][]byte),
+				pins:  make(map[token.Pos][]string),
+				insts: make(map[token.Pos][]*types.Named),
+				anons: make(map[*types.Struct]*ast.TypeSpec),
+				accs:  make(map[token.Pos]gopium.CoAccess),
+				heats: make(map[token.Pos]gopium.Hotness),
+				atoms: make(map[token.Pos]gopium.Atomics),
 			},
 		},
 	}
@@ -191,3 +207,338 @@ func TestLocatorFset(t *testing.T) {
 		})
 	}
 }
+
+func TestLocatorSource(t *testing.T) {
+	// prepare
+	locator := NewLocator(nil)
+	locator.Source("test", []byte("package test"))
+	table := map[string]struct {
+		l   string
+		src []byte
+		ok  bool
+	}{
+		"invalid loc should return default results": {
+			l: "loc",
+		},
+		"valid loc should return expected results": {
+			l:   "test",
+			src: []byte("package test"),
+			ok:  true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			src, ok := locator.Source(tcase.l, nil)
+			// check
+			if !reflect.DeepEqual(src, tcase.src) {
+				t.Errorf("actual %v doesn't equal to expected %v", src, tcase.src)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
+
+func TestLocatorPin(t *testing.T) {
+	// prepare
+	locator := NewLocator(nil)
+	table := map[string]struct {
+		pos    token.Pos
+		reason string
+		r      string
+		ok     bool
+	}{
+		"unpinned pos should return default results": {
+			pos: token.Pos(1),
+		},
+		"pinned pos should return pinning reason": {
+			pos:    token.Pos(2),
+			reason: "test-1",
+			r:      "test-1",
+			ok:     true,
+		},
+		"pinned pos should return all pinning reasons": {
+			pos:    token.Pos(3),
+			reason: "test-2",
+			r:      "test-1, test-2",
+			ok:     true,
+		},
+	}
+	locator.Pin(token.Pos(3), "test-1")
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, ok := locator.Pin(tcase.pos, tcase.reason)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
+
+func TestLocatorInstances(t *testing.T) {
+	// prepare
+	locator := NewLocator(nil)
+	tn := types.NewTypeName(token.NoPos, nil, "test", nil)
+	tp := types.NewTypeParam(types.NewTypeName(token.NoPos, nil, "T", nil), types.NewInterfaceType(nil, nil))
+	generic := types.NewNamed(tn, types.NewStruct([]*types.Var{types.NewField(token.NoPos, nil, "v", tp, false)}, nil), nil)
+	generic.SetTypeParams([]*types.TypeParam{tp})
+	instint, err := types.Instantiate(nil, generic, []types.Type{types.Typ[types.Int]}, true)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	instbool, err := types.Instantiate(nil, generic, []types.Type{types.Typ[types.Bool]}, true)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	locator.Instances(token.Pos(3), instint.(*types.Named))
+	locator.Instances(token.Pos(4), instint.(*types.Named))
+	locator.Instances(token.Pos(4), instbool.(*types.Named))
+	table := map[string]struct {
+		pos   token.Pos
+		inst  *types.Named
+		insts []string
+		ok    bool
+	}{
+		"unknown pos should return default results": {
+			pos: token.Pos(1),
+		},
+		"new instance should return single instance": {
+			pos:   token.Pos(2),
+			inst:  instint.(*types.Named),
+			insts: []string{"test[int]"},
+			ok:    true,
+		},
+		"new instance should return all instances sorted": {
+			pos:   token.Pos(3),
+			inst:  instbool.(*types.Named),
+			insts: []string{"test[bool]", "test[int]"},
+			ok:    true,
+		},
+		"identical instance should return all instances without duplicates": {
+			pos:   token.Pos(4),
+			inst:  instint.(*types.Named),
+			insts: []string{"test[bool]", "test[int]"},
+			ok:    true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			insts, ok := locator.Instances(tcase.pos, tcase.inst)
+			// check
+			var names []string
+			for _, inst := range insts {
+				names = append(names, inst.String())
+			}
+			if !reflect.DeepEqual(names, tcase.insts) {
+				t.Errorf("actual %v doesn't equal to expected %v", names, tcase.insts)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
+
+func TestLocatorAnonymous(t *testing.T) {
+	// prepare
+	locator := NewLocator(nil)
+	st := types.NewStruct(nil, nil)
+	known := &ast.TypeSpec{Name: ast.NewIdent("known")}
+	locator.Anonymous(st, known)
+	table := map[string]struct {
+		st *types.Struct
+		ts *ast.TypeSpec
+		r  *ast.TypeSpec
+		ok bool
+	}{
+		"unknown struct should return default results": {
+			st: types.NewStruct(nil, nil),
+		},
+		"known struct should return its type spec": {
+			st: st,
+			r:  known,
+			ok: true,
+		},
+		"known struct should keep its first type spec": {
+			st: st,
+			ts: &ast.TypeSpec{Name: ast.NewIdent("unknown")},
+			r:  known,
+			ok: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ts, ok := locator.Anonymous(tcase.st, tcase.ts)
+			// check
+			if !reflect.DeepEqual(ts, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", ts, tcase.r)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
+
+func TestLocatorCoAccess(t *testing.T) {
+	// prepare
+	locator := NewLocator(nil)
+	locator.CoAccess(token.Pos(3), "a", "b")
+	table := map[string]struct {
+		pos    token.Pos
+		fields []string
+		r      gopium.CoAccess
+		ok     bool
+	}{
+		"unknown pos should return default results": {
+			pos: token.Pos(1),
+		},
+		"single field should return default results": {
+			pos:    token.Pos(2),
+			fields: []string{"a"},
+		},
+		"new fields should return co-access graph without duplicates": {
+			pos:    token.Pos(4),
+			fields: []string{"a", "b", "a"},
+			r: gopium.CoAccess{
+				"a": {"b": 1},
+				"b": {"a": 1},
+			},
+			ok: true,
+		},
+		"known fields should return accumulated co-access graph": {
+			pos:    token.Pos(3),
+			fields: []string{"b", "a", "c"},
+			r: gopium.CoAccess{
+				"a": {"b": 2, "c": 1},
+				"b": {"a": 2, "c": 1},
+				"c": {"a": 1, "b": 1},
+			},
+			ok: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, ok := locator.CoAccess(tcase.pos, tcase.fields...)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
+
+func TestLocatorHotness(t *testing.T) {
+	// prepare
+	locator := NewLocator(nil)
+	locator.Hotness(token.Pos(3), "a", 10)
+	table := map[string]struct {
+		pos     token.Pos
+		field   string
+		samples int64
+		r       gopium.Hotness
+		ok      bool
+	}{
+		"unknown pos should return default results": {
+			pos: token.Pos(1),
+		},
+		"non positive samples should return default results": {
+			pos:   token.Pos(2),
+			field: "a",
+		},
+		"new field should return fields hotness": {
+			pos:     token.Pos(4),
+			field:   "a",
+			samples: 5,
+			r:       gopium.Hotness{"a": 5},
+			ok:      true,
+		},
+		"known field should return accumulated fields hotness": {
+			pos:     token.Pos(3),
+			field:   "a",
+			samples: 5,
+			r:       gopium.Hotness{"a": 15},
+			ok:      true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, ok := locator.Hotness(tcase.pos, tcase.field, tcase.samples)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
+
+func TestLocatorAtomic(t *testing.T) {
+	// prepare
+	locator := NewLocator(nil)
+	locator.Atomic(token.Pos(3), 32, "a")
+	locator.Atomic(token.Pos(5), 64, "a")
+	table := map[string]struct {
+		pos    token.Pos
+		bits   int64
+		fields []string
+		r      gopium.Atomics
+		ok     bool
+	}{
+		"unknown pos should return default results": {
+			pos: token.Pos(1),
+		},
+		"no fields should return default results": {
+			pos: token.Pos(2),
+		},
+		"new fields should return atomic fields": {
+			pos:    token.Pos(4),
+			bits:   64,
+			fields: []string{"a", "b", "a"},
+			r:      gopium.Atomics{"a": 64, "b": 64},
+			ok:     true,
+		},
+		"known fields should return accumulated atomic fields with max width": {
+			pos:    token.Pos(3),
+			bits:   64,
+			fields: []string{"b", "a"},
+			r:      gopium.Atomics{"a": 64, "b": 64},
+			ok:     true,
+		},
+		"known fields with narrower width should return atomic fields with max width": {
+			pos:    token.Pos(5),
+			fields: []string{"a", "c"},
+			r:      gopium.Atomics{"a": 64, "c": 0},
+			ok:     true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, ok := locator.Atomic(tcase.pos, tcase.bits, tcase.fields...)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
@@ -3,15 +3,58 @@ package typepkg
 import (
 	"fmt"
 	"go/types"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of known compilers and architectures
+// that could be supported by types.SizesFor
+var (
+	compilers = []string{"gc", "gccgo"}
+	archs     = []string{
+		"386", "alpha", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be",
+		"ia64", "loong64", "m68k", "mips", "mipsle", "mips64", "mips64le", "mips64p32",
+		"mips64p32le", "nios2", "ppc", "ppc64", "ppc64le", "riscv", "riscv64", "s390",
+		"s390x", "sh", "shbe", "sparc", "sparc64", "wasm",
+	}
 )
 
+// list of typical destructive and constructive
+// interference sizes for architectures
+// that differ from typical cpu cache line size,
+// destructive size accounts adjacent cache lines
+// spatial prefetchers on modern cpus
+var interferences = map[string][2]int64{
+	"386":         {128, 64},
+	"amd64":       {128, 64},
+	"amd64p32":    {128, 64},
+	"arm64":       {128, 64},
+	"arm64be":     {128, 64},
+	"ppc64":       {128, 128},
+	"ppc64le":     {128, 128},
+	"s390x":       {256, 256},
+	"arm":         {32, 32},
+	"armbe":       {32, 32},
+	"mips":        {32, 32},
+	"mipsle":      {32, 32},
+	"mips64":      {32, 32},
+	"mips64le":    {32, 32},
+	"mips64p32":   {32, 32},
+	"mips64p32le": {32, 32},
+	"sparc":       {32, 32},
+	"m68k":        {16, 16},
+}
+
 // MavenGoTypes defines maven default "go/types" implementation
 // that uses types.Sizes Sizeof in order to get type info
 type MavenGoTypes struct {
-	sizes  stdsizes       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	caches map[uint]int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_      [8]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 24 bytes; - 🌺 gopium @1pkg
+	sizes        stdsizes          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	caches       map[uint]int64    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	hierarchy    map[uint]SysCache `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	destructive  int64             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	constructive int64             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_            [16]byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
 
 // NewMavenGoTypes creates instance of MavenGoTypes
 // and requires compiler and arch for types.Sizes initialization
@@ -23,15 +66,148 @@ func NewMavenGoTypes(compiler, arch string, caches ...int64) (MavenGoTypes, erro
 		cm[uint(i+1)] = cache
 	}
 	// try to get size for compiler and arch
+	// and use typical arch interference sizes
 	if sizes := types.SizesFor(compiler, arch); sizes != nil {
+		destructive, constructive := int64(64), int64(64)
+		if sizes, ok := interferences[arch]; ok {
+			destructive, constructive = sizes[0], sizes[1]
+		}
 		return MavenGoTypes{
-			sizes:  stdsizes{sizes},
-			caches: cm,
+			sizes:        stdsizes{sizes},
+			caches:       cm,
+			destructive:  destructive,
+			constructive: constructive,
 		}, nil
 	}
 	return MavenGoTypes{}, fmt.Errorf("unsuported compiler %q arch %q combination", compiler, arch)
 }
 
+// Interference returns copy of maven with provided
+// destructive and constructive interference sizes,
+// non positive sizes keep typical arch sizes
+func (m MavenGoTypes) Interference(destructive, constructive int64) MavenGoTypes {
+	if destructive > 0 {
+		m.destructive = destructive
+	}
+	if constructive > 0 {
+		m.constructive = constructive
+	}
+	return m
+}
+
+// Hierarchy returns copy of maven with provided
+// cpu caches hierarchy ordered by cache level
+// starting from l1, caches hierarchy overrides
+// cache lines sizes and adds caches sizes
+// and associativities metadata,
+// empty hierarchy keeps maven intact
+func (m MavenGoTypes) Hierarchy(caches ...SysCache) MavenGoTypes {
+	if len(caches) == 0 {
+		return m
+	}
+	cm := make(map[uint]int64, len(m.caches)+len(caches))
+	for level, size := range m.caches {
+		cm[level] = size
+	}
+	hm := make(map[uint]SysCache, len(caches))
+	for i, cache := range caches {
+		cm[uint(i+1)] = cache.Line
+		hm[uint(i+1)] = cache
+	}
+	m.caches, m.hierarchy = cm, hm
+	return m
+}
+
+// MavenPortable defines maven portable implementation
+// that uses MavenGoTypes for its own architecture
+// and additionally exposes type info on target architectures
+type MavenPortable struct {
+	MavenGoTypes `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	archs        []string       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	mavens       []MavenGoTypes `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_            [16]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 96 bytes; - 🌺 gopium @1pkg
+
+// NewMavenPortable creates instance of MavenPortable
+// and requires compiler, arch and target archs
+// for types.Sizes initialization, duplicated
+// target archs are skipped
+func NewMavenPortable(compiler, arch string, parchs []string, caches ...int64) (MavenPortable, error) {
+	// set up own arch maven first
+	m, err := NewMavenGoTypes(compiler, arch, caches...)
+	if err != nil {
+		return MavenPortable{}, err
+	}
+	mp := MavenPortable{
+		MavenGoTypes: m,
+		archs:        []string{arch},
+		mavens:       []MavenGoTypes{m},
+	}
+	// then set up all target archs mavens
+	seen := map[string]bool{arch: true}
+	for _, parch := range parchs {
+		// skip duplicated archs
+		if seen[parch] {
+			continue
+		}
+		seen[parch] = true
+		pm, err := NewMavenGoTypes(compiler, parch, caches...)
+		if err != nil {
+			return MavenPortable{}, err
+		}
+		mp.archs = append(mp.archs, parch)
+		mp.mavens = append(mp.mavens, pm)
+	}
+	return mp, nil
+}
+
+// Interference returns copy of portable maven
+// with provided destructive and constructive
+// interference sizes for its own architecture
+// note: target archs mavens are used only as exposers
+func (m MavenPortable) Interference(destructive, constructive int64) MavenPortable {
+	m.MavenGoTypes = m.MavenGoTypes.Interference(destructive, constructive)
+	return m
+}
+
+// Hierarchy returns copy of portable maven
+// with provided cpu caches hierarchy
+// for its own architecture
+// note: target archs mavens are used only as exposers
+func (m MavenPortable) Hierarchy(caches ...SysCache) MavenPortable {
+	m.MavenGoTypes = m.MavenGoTypes.Hierarchy(caches...)
+	return m
+}
+
+// Platforms MavenPortable implementation
+func (m MavenPortable) Platforms() []string {
+	return m.archs
+}
+
+// Platform MavenPortable implementation
+func (m MavenPortable) Platform(arch string) (gopium.Exposer, bool) {
+	for i := range m.archs {
+		if m.archs[i] == arch {
+			return m.mavens[i], true
+		}
+	}
+	return nil, false
+}
+
+// Platforms returns list of all compiler/arch
+// platforms supported by types.SizesFor
+func Platforms() []string {
+	platforms := make([]string, 0, len(compilers)*len(archs))
+	for _, compiler := range compilers {
+		for _, arch := range archs {
+			if types.SizesFor(compiler, arch) != nil {
+				platforms = append(platforms, fmt.Sprintf("%s/%s", compiler, arch))
+			}
+		}
+	}
+	return platforms
+}
+
 // SysWord MavenGoTypes implementation
 func (m MavenGoTypes) SysWord() int64 {
 	return m.sizes.WordSize()
@@ -53,6 +229,28 @@ func (m MavenGoTypes) SysCache(level uint) int64 {
 	return 64
 }
 
+// SysCacheSize MavenGoTypes implementation
+func (m MavenGoTypes) SysCacheSize(level uint) int64 {
+	// zero size stands for unknown cache size
+	return m.hierarchy[level].Size
+}
+
+// SysCacheWays MavenGoTypes implementation
+func (m MavenGoTypes) SysCacheWays(level uint) int64 {
+	// zero ways stands for unknown cache associativity
+	return m.hierarchy[level].Ways
+}
+
+// SysDestructive MavenGoTypes implementation
+func (m MavenGoTypes) SysDestructive() int64 {
+	return m.destructive
+}
+
+// SysConstructive MavenGoTypes implementation
+func (m MavenGoTypes) SysConstructive() int64 {
+	return m.constructive
+}
+
 // Name MavenGoTypes implementation
 func (m MavenGoTypes) Name(t types.Type) string {
 	return t.String()
@@ -45,6 +45,18 @@ func TestNewMavenGoTypes(t *testing.T) {
 					4: 16,
 					5: 32,
 				},
+				destructive:  128,
+				constructive: 64,
+			},
+		},
+		"valid compiler and unknown interference arch pair should return expected maven": {
+			compiler: "gc",
+			arch:     "riscv64",
+			maven: MavenGoTypes{
+				sizes:        stdsizes{types.SizesFor("gc", "riscv64")},
+				caches:       map[uint]int64{},
+				destructive:  64,
+				constructive: 64,
 			},
 		},
 	}
@@ -63,34 +75,245 @@ func TestNewMavenGoTypes(t *testing.T) {
 	}
 }
 
+func TestNewMavenPortable(t *testing.T) {
+	// prepare
+	mamd64, err := NewMavenGoTypes("gc", "amd64", 2, 4, 8)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	m386, err := NewMavenGoTypes("gc", "386", 2, 4, 8)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	table := map[string]struct {
+		compiler  string
+		arch      string
+		parchs    []string
+		maven     MavenPortable
+		platforms []string
+		exps      map[string]gopium.Exposer
+		err       error
+	}{
+		"invalid arch should return error": {
+			compiler: "gc",
+			arch:     "test",
+			parchs:   []string{"386"},
+			err:      errors.New(`unsuported compiler "gc" arch "test" combination`),
+		},
+		"invalid portable arch should return error": {
+			compiler: "gc",
+			arch:     "amd64",
+			parchs:   []string{"386", "test"},
+			err:      errors.New(`unsuported compiler "gc" arch "test" combination`),
+		},
+		"valid archs should return expected maven without duplicates": {
+			compiler: "gc",
+			arch:     "amd64",
+			parchs:   []string{"386", "amd64", "386"},
+			maven: MavenPortable{
+				MavenGoTypes: mamd64,
+				archs:        []string{"amd64", "386"},
+				mavens:       []MavenGoTypes{mamd64, m386},
+			},
+			platforms: []string{"amd64", "386"},
+			exps: map[string]gopium.Exposer{
+				"amd64": mamd64,
+				"386":   m386,
+				"arm":   nil,
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			maven, err := NewMavenPortable(tcase.compiler, tcase.arch, tcase.parchs, 2, 4, 8)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if !reflect.DeepEqual(maven, tcase.maven) {
+				t.Errorf("actual %v doesn't equal to expected %v", maven, tcase.maven)
+			}
+			if platforms := maven.Platforms(); !reflect.DeepEqual(platforms, tcase.platforms) {
+				t.Errorf("actual %v doesn't equal to expected %v", platforms, tcase.platforms)
+			}
+			for arch, exp := range tcase.exps {
+				pexp, ok := maven.Platform(arch)
+				if !reflect.DeepEqual(pexp, exp) {
+					t.Errorf("actual %v doesn't equal to expected %v", pexp, exp)
+				}
+				if !reflect.DeepEqual(ok, exp != nil) {
+					t.Errorf("actual %v doesn't equal to expected %v", ok, exp != nil)
+				}
+			}
+		})
+	}
+}
+
+func TestMavenPortableInterference(t *testing.T) {
+	// prepare
+	maven, err := NewMavenPortable("gc", "amd64", []string{"386"})
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	table := map[string]struct {
+		destructive   int64
+		constructive  int64
+		rdestructive  int64
+		rconstructive int64
+	}{
+		"non positive interference sizes should keep typical sizes": {
+			rdestructive:  128,
+			rconstructive: 64,
+		},
+		"positive interference sizes should override typical sizes": {
+			destructive:   256,
+			constructive:  32,
+			rdestructive:  256,
+			rconstructive: 32,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			m := maven.Interference(tcase.destructive, tcase.constructive)
+			destructive, constructive := m.SysDestructive(), m.SysConstructive()
+			// check
+			if !reflect.DeepEqual(destructive, tcase.rdestructive) {
+				t.Errorf("actual %v doesn't equal to expected %v", destructive, tcase.rdestructive)
+			}
+			if !reflect.DeepEqual(constructive, tcase.rconstructive) {
+				t.Errorf("actual %v doesn't equal to expected %v", constructive, tcase.rconstructive)
+			}
+			if platforms := m.Platforms(); !reflect.DeepEqual(platforms, maven.Platforms()) {
+				t.Errorf("actual %v doesn't equal to expected %v", platforms, maven.Platforms())
+			}
+		})
+	}
+}
+
+func TestPlatforms(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		platform  string
+		supported bool
+	}{
+		"gc/amd64 platform should be supported": {
+			platform:  "gc/amd64",
+			supported: true,
+		},
+		"gccgo/alpha platform should be supported": {
+			platform:  "gccgo/alpha",
+			supported: true,
+		},
+		"gc/alpha platform should not be supported": {
+			platform: "gc/alpha",
+		},
+		"test/amd64 platform should not be supported": {
+			platform: "test/amd64",
+		},
+	}
+	// exec
+	platforms := Platforms()
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// check
+			var supported bool
+			for _, platform := range platforms {
+				if platform == tcase.platform {
+					supported = true
+				}
+			}
+			if !reflect.DeepEqual(supported, tcase.supported) {
+				t.Errorf("actual %v doesn't equal to expected %v", supported, tcase.supported)
+			}
+		})
+	}
+}
+
 func TestMavenGoTypesCurator(t *testing.T) {
 	// prepare
 	maven, err := NewMavenGoTypes("gc", "amd64", 2, 4, 8, 16, 32)
 	if !reflect.DeepEqual(err, nil) {
 		t.Fatalf("actual %v doesn't equal to %v", err, nil)
 	}
+	portable, err := NewMavenPortable("gc", "amd64", []string{"386"})
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
 	table := map[string]struct {
-		maven  gopium.Maven
-		word   int64
-		align  int64
-		caches []int64
+		maven        gopium.Maven
+		word         int64
+		align        int64
+		caches       []int64
+		sizes        []int64
+		ways         []int64
+		destructive  int64
+		constructive int64
 	}{
 		"gc/amd64 maven should return expected results": {
-			maven:  maven,
-			word:   8,
-			align:  8,
-			caches: []int64{64, 2, 4, 8, 16, 32, 64, 64, 64},
+			maven:        maven,
+			word:         8,
+			align:        8,
+			caches:       []int64{64, 2, 4, 8, 16, 32, 64, 64, 64},
+			sizes:        []int64{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			ways:         []int64{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			destructive:  128,
+			constructive: 64,
+		},
+		"gc/amd64 maven with interference should return expected results": {
+			maven:        maven.Interference(256, 0),
+			word:         8,
+			align:        8,
+			caches:       []int64{64, 2, 4, 8, 16, 32, 64, 64, 64},
+			sizes:        []int64{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			ways:         []int64{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			destructive:  256,
+			constructive: 64,
+		},
+		"gc/amd64 maven with hierarchy should return expected results": {
+			maven: maven.Hierarchy(
+				SysCache{Line: 64, Size: 32 << 10, Ways: 8},
+				SysCache{Line: 128, Size: 1 << 20, Ways: 16},
+			),
+			word:         8,
+			align:        8,
+			caches:       []int64{64, 64, 128, 8, 16, 32, 64, 64, 64},
+			sizes:        []int64{0, 32 << 10, 1 << 20, 0, 0, 0, 0, 0, 0},
+			ways:         []int64{0, 8, 16, 0, 0, 0, 0, 0, 0},
+			destructive:  128,
+			constructive: 64,
+		},
+		"gc/amd64 portable maven with hierarchy should return expected results": {
+			maven:        portable.Hierarchy(SysCache{Line: 32, Size: 16 << 10, Ways: 4}),
+			word:         8,
+			align:        8,
+			caches:       []int64{64, 32, 64, 64},
+			sizes:        []int64{0, 16 << 10, 0, 0},
+			ways:         []int64{0, 4, 0, 0},
+			destructive:  128,
+			constructive: 64,
 		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
 			// exec
-			word := maven.SysWord()
-			align := maven.SysAlign()
+			word := tcase.maven.SysWord()
+			align := tcase.maven.SysAlign()
 			caches := make([]int64, len(tcase.caches))
 			for i := range caches {
-				caches[i] = maven.SysCache(uint(i))
+				caches[i] = tcase.maven.SysCache(uint(i))
+			}
+			sizes := make([]int64, len(tcase.sizes))
+			for i := range sizes {
+				sizes[i] = tcase.maven.SysCacheSize(uint(i))
 			}
+			ways := make([]int64, len(tcase.ways))
+			for i := range ways {
+				ways[i] = tcase.maven.SysCacheWays(uint(i))
+			}
+			destructive := tcase.maven.SysDestructive()
+			constructive := tcase.maven.SysConstructive()
 			// check
 			if !reflect.DeepEqual(word, tcase.word) {
 				t.Errorf("actual %v doesn't equal to %v", word, tcase.word)
@@ -101,6 +324,18 @@ func TestMavenGoTypesCurator(t *testing.T) {
 			if !reflect.DeepEqual(caches, tcase.caches) {
 				t.Errorf("actual %v doesn't equal to %v", caches, tcase.caches)
 			}
+			if !reflect.DeepEqual(sizes, tcase.sizes) {
+				t.Errorf("actual %v doesn't equal to %v", sizes, tcase.sizes)
+			}
+			if !reflect.DeepEqual(ways, tcase.ways) {
+				t.Errorf("actual %v doesn't equal to %v", ways, tcase.ways)
+			}
+			if !reflect.DeepEqual(destructive, tcase.destructive) {
+				t.Errorf("actual %v doesn't equal to %v", destructive, tcase.destructive)
+			}
+			if !reflect.DeepEqual(constructive, tcase.constructive) {
+				t.Errorf("actual %v doesn't equal to %v", constructive, tcase.constructive)
+			}
 		})
 	}
 }
@@ -0,0 +1,57 @@
+package typepkg
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"path/filepath"
+)
+
+// ReadOverlay reads editor overlay from json encoded
+// map of files paths to files contents,
+// relative files paths are resolved
+// against current directory
+func ReadOverlay(r io.Reader) (map[string][]byte, error) {
+	// decode files contents map
+	var contents map[string]string
+	if err := json.NewDecoder(r).Decode(&contents); err != nil {
+		return nil, err
+	}
+	// packages overlay expects
+	// absolute files paths
+	overlay := make(map[string][]byte, len(contents))
+	for path, content := range contents {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		overlay[abs] = []byte(content)
+	}
+	return overlay, nil
+}
+
+// parseFiles parses provided package files
+// into single ast package with provided name,
+// it prefers overlay contents over files contents on disk
+func parseFiles(fset *token.FileSet, name string, files []string, overlay map[string][]byte, mode parser.Mode) (*ast.Package, error) {
+	pkg := &ast.Package{
+		Name:  name,
+		Files: make(map[string]*ast.File, len(files)),
+	}
+	for _, fname := range files {
+		// note: nil src makes parser read file from disk
+		var src interface{}
+		if content, ok := overlay[fname]; ok {
+			src = content
+		}
+		file, err := parser.ParseFile(fset, fname, src, mode)
+		// on any error just propagate it
+		if err != nil {
+			return nil, err
+		}
+		pkg.Files[fname] = file
+	}
+	return pkg, nil
+}
@@ -0,0 +1,187 @@
+package typepkg
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/tests"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestReadOverlay(t *testing.T) {
+	// prepare
+	wd, err := filepath.Abs(".")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	abs := filepath.Join(wd, "abs.go")
+	table := map[string]struct {
+		input   string
+		overlay map[string][]byte
+		err     error
+	}{
+		"invalid json should return error": {
+			input: `invalid`,
+			err:   fmt.Errorf("invalid character 'i' looking for beginning of value"),
+		},
+		"empty json should return empty overlay": {
+			input:   `{}`,
+			overlay: map[string][]byte{},
+		},
+		"valid json should return overlay with absolute paths": {
+			input: fmt.Sprintf(`{"rel.go": "package rel", %q: "package abs"}`, abs),
+			overlay: map[string][]byte{
+				filepath.Join(wd, "rel.go"): []byte("package rel"),
+				abs:                         []byte("package abs"),
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			overlay, err := ReadOverlay(strings.NewReader(tcase.input))
+			// check
+			if !reflect.DeepEqual(overlay, tcase.overlay) {
+				t.Errorf("actual %v doesn't equal to expected %v", overlay, tcase.overlay)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	// prepare
+	dir := filepath.Join(tests.Gopium, "tests", "data", "platform")
+	table := map[string]struct {
+		files   []string
+		overlay map[string][]byte
+		decls   []string
+		err     error
+	}{
+		"missing file should return error": {
+			files: []string{filepath.Join(dir, "missing.go")},
+			err: tests.OnOS(
+				"windows",
+				fmt.Errorf("open %s: The system cannot find the file specified.", filepath.Join(dir, "missing.go")),
+				fmt.Errorf("open %s: no such file or directory", filepath.Join(dir, "missing.go")),
+			).(error),
+		},
+		"files without overlay should return only provided disk files": {
+			files: []string{filepath.Join(dir, "file.go"), filepath.Join(dir, "file_linux.go")},
+			decls: []string{"file.go:Common", "file_linux.go:Linux"},
+		},
+		"files with overlay should return overlay files contents": {
+			files: []string{filepath.Join(dir, "file.go"), filepath.Join(dir, "extra.go")},
+			overlay: map[string][]byte{
+				filepath.Join(dir, "file.go"):  []byte("package platform\ntype Overlay struct{}"),
+				filepath.Join(dir, "extra.go"): []byte("package platform\ntype Extra struct{}"),
+				filepath.Join(dir, "other.go"): []byte("package platform\ntype Other struct{}"),
+			},
+			decls: []string{"extra.go:Extra", "file.go:Overlay"},
+		},
+		"files with invalid overlay should return parser error": {
+			files: []string{filepath.Join(dir, "file.go")},
+			overlay: map[string][]byte{
+				filepath.Join(dir, "file.go"): []byte("invalid"),
+			},
+			err: fmt.Errorf("%s:1:1: expected 'package', found invalid", filepath.Join(dir, "file.go")),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			pkg, err := parseFiles(token.NewFileSet(), "platform", tcase.files, tcase.overlay, parser.ParseComments)
+			// check
+			var decls []string
+			if pkg != nil {
+				for name, file := range pkg.Files {
+					ts := file.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec)
+					decls = append(decls, fmt.Sprintf("%s:%s", filepath.Base(name), ts.Name.Name))
+				}
+				sort.Strings(decls)
+			}
+			if !reflect.DeepEqual(decls, tcase.decls) {
+				t.Errorf("actual %v doesn't equal to expected %v", decls, tcase.decls)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestParserXToolPackagesAstOverlay(t *testing.T) {
+	// prepare
+	dir := filepath.Join(tests.Gopium, "tests", "data", "single")
+	p := ParserXToolPackagesAst{
+		Pattern: "github.com/1pkg/gopium/tests/data/single",
+		Path:    dir,
+		//nolint
+		ModeTypes:  packages.LoadAllSyntax,
+		ModeAst:    parser.ParseComments | parser.AllErrors,
+		BuildFlags: []string{"-tags=tests_data"},
+		Overlay: map[string][]byte{
+			filepath.Join(dir, "file.go"): []byte(`
+//go:build tests_data
+
+package single
+
+type Single struct {
+	A string
+	B bool
+}
+`),
+			filepath.Join(dir, "extra.go"): []byte(`
+//go:build tests_data
+
+package single
+
+type Extra struct {
+	S Single
+}
+`),
+		},
+	}
+	// exec
+	tpkg, _, terr := p.ParseTypes(context.Background())
+	apkg, _, aerr := p.ParseAst(context.Background())
+	// check
+	if !reflect.DeepEqual(terr, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", terr, nil)
+	}
+	if !reflect.DeepEqual(aerr, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", aerr, nil)
+	}
+	// types should be checked against overlay contents
+	single := tpkg.Scope().Lookup("Single").Type().Underlying().String()
+	if !reflect.DeepEqual(single, "struct{A string; B bool}") {
+		t.Errorf("actual %v doesn't equal to expected %v", single, "struct{A string; B bool}")
+	}
+	if tpkg.Scope().Lookup("Extra") == nil {
+		t.Errorf("actual %v doesn't equal to expected not %v", nil, nil)
+	}
+	// ast should be parsed from overlay contents
+	var names []string
+	for name := range apkg.Files {
+		names = append(names, filepath.Base(name))
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"extra.go", "file.go"}) {
+		t.Errorf("actual %v doesn't equal to expected %v", names, []string{"extra.go", "file.go"})
+	}
+	fields := apkg.Files[filepath.Join(dir, "file.go")].Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields
+	if !reflect.DeepEqual(fields.NumFields(), 2) {
+		t.Errorf("actual %v doesn't equal to expected %v", fields.NumFields(), 2)
+	}
+}
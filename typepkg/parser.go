@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
 	"go/types"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/1pkg/gopium/gopium"
@@ -25,15 +28,18 @@ import (
 // Note: ParserXToolPackagesAst is big struct
 // so it should be passed via pointer
 type ParserXToolPackagesAst struct {
-	Pattern    string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Path       string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Root       string            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	BuildEnv   []string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	BuildFlags []string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	ModeTypes  packages.LoadMode `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	ModeAst    parser.Mode       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_          [16]byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 80 bytes; - 🌺 gopium @1pkg
+	Pattern    string                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Path       string                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	BuildEnv   []string                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	BuildFlags []string                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Overlay    map[string][]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Profile    map[string]int64        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	loaded     *packages.Package       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	foreign    map[token.Position]bool `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ModeTypes  packages.LoadMode       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ModeAst    parser.Mode             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_          [64]byte                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; struct ptr scan size: 112 bytes; - 🌺 gopium @1pkg
 
 // ParseTypes ParserXToolPackagesAst implementation
 func (p *ParserXToolPackagesAst) ParseTypes(ctx context.Context, _ ...byte) (*types.Package, gopium.Locator, error) {
@@ -45,44 +51,27 @@ func (p *ParserXToolPackagesAst) ParseTypes(ctx context.Context, _ ...byte) (*ty
 		return nil, nil, ctx.Err()
 	default:
 	}
-	// create packages.Config obj
-	fset := token.NewFileSet()
-	dir := filepath.Join(p.Root, p.Path)
-	cfg := &packages.Config{
-		Fset:       fset,
-		Context:    ctx,
-		Dir:        dir,
-		Mode:       p.ModeTypes,
-		Env:        p.BuildEnv,
-		BuildFlags: p.BuildFlags,
-		Tests:      true,
+	// if package has been already loaded
+	// by multi packages parser expansion
+	// just reuse its types info
+	if p.loaded != nil {
+		_, loc := locate(p.loaded, p.loaded.Fset, p.Profile)
+		unkeyed(p.loaded.Types, p.loaded.Fset, p.foreign, loc)
+		return owned(p.loaded), loc, nil
 	}
-	// use packages.Load
-	pkgs, err := packages.Load(cfg, "")
+	// load package with tests
+	pkgs, fset, err := p.load(ctx, p.ModeTypes, true)
 	// on any error just propagate it
 	if err != nil {
 		return nil, nil, err
 	}
-	// prepare relative path for package
-	// by splitting path by src directory
-	path := p.Path
-	src := fmt.Sprintf("src%s", string(os.PathSeparator))
-	if parts := strings.SplitN(p.Path, src, 2); len(parts) == 2 {
-		path = parts[1]
-		// fix package name for windows
-		path = strings.ReplaceAll(path, string(os.PathSeparator), "/")
-	}
 	// check parse results
-	// note: len of pkgs should be equal to
-	// - either 1 (pkg contains no tests)
-	// - or 3 (pkg contains tests)
-	// see go packages config test description
-	if plen := len(pkgs); plen >= 1 && validPackage(pkgs[0].String(), p.Pattern, path) {
-		switch plen {
-		case 1:
-			return pkgs[0].Types, NewLocator(fset), nil
-		default:
-			return pkgs[1].Types, NewLocator(fset), nil
+	// against real package path
+	dir := p.Path
+	for _, pkg := range variants(pkgs) {
+		if validPackage(pkg, p.Pattern, dir) {
+			tpkg, loc := locate(pkg, fset, p.Profile)
+			return tpkg, loc, nil
 		}
 	}
 	return nil, nil, fmt.Errorf("types package %q wasn't found at %q", p.Pattern, dir)
@@ -121,52 +110,382 @@ func (p *ParserXToolPackagesAst) ParseAst(ctx context.Context, src ...byte) (*as
 			},
 		}, NewLocator(fset), err
 	}
-	// otherwise use parser parse dir
-	dir := filepath.Join(p.Root, p.Path)
-	pkgs, err := parser.ParseDir(
+	// otherwise resolve package
+	// files and name first
+	dir := p.Path
+	pkg := p.loaded
+	if pkg == nil {
+		// load package files only
+		// with tests the same way
+		// as types are loaded
+		pkgs, _, err := p.load(ctx, 0, true)
+		// on any error just propagate it
+		if err != nil {
+			return nil, nil, err
+		}
+		// check load results
+		// against real package path
+		for _, vpkg := range variants(pkgs) {
+			if validPackage(vpkg, p.Pattern, dir) {
+				pkg = vpkg
+				break
+			}
+		}
+		if pkg == nil {
+			return nil, nil, fmt.Errorf("ast package %q wasn't found at %q", p.Pattern, dir)
+		}
+	}
+	// then parse exactly the same package files
+	// that satisfy build constraints for types
+	// with overlay files contents
+	pckg, err := parseFiles(
 		fset,
-		dir,
-		nil,
+		pkg.Name,
+		pkg.GoFiles,
+		p.Overlay,
 		p.ModeAst,
 	)
 	// on any error just propagate it
 	if err != nil {
 		return nil, nil, err
 	}
+	// keep overlay contents as package
+	// files original sources in locator
+	loc := NewLocator(fset)
+	for _, file := range pkg.GoFiles {
+		if content, ok := p.Overlay[file]; ok {
+			loc.Source(file, content)
+		}
+	}
+	return pckg, loc, nil
+}
+
+// load loads packages matching parser pattern
+// with provided load mode extended by names, files
+// and module metadata required for packages resolution
+func (p *ParserXToolPackagesAst) load(ctx context.Context, mode packages.LoadMode, tests bool) ([]*packages.Package, *token.FileSet, error) {
+	// empty pattern stands
+	// for current directory package
+	pattern := p.Pattern
+	if pattern == "" {
+		pattern = "."
+	}
+	// bare package names are also
+	// resolved as package inside path
+	patterns := []string{pattern}
+	if barePackage(pattern) {
+		patterns = append(patterns, ".")
+	}
+	// create packages.Config obj
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Fset:       fset,
+		Context:    ctx,
+		Dir:        p.Path,
+		Mode:       mode | packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Env:        p.BuildEnv,
+		BuildFlags: p.BuildFlags,
+		Overlay:    p.Overlay,
+		Tests:      tests,
+	}
+	// use packages.Load
+	pkgs, err := packages.Load(cfg, patterns...)
+	return pkgs, fset, err
+}
+
+// ParserXToolPackagesAstMulti defines
+// gopium multi packages parser implementation
+// on top of ParserXToolPackagesAst
+// that expands go list patterns like `./...`
+// to single package parsers using one packages load,
+// optionally packages are loaded for several
+// goos/goarch platforms and each package file
+// belongs to the first platform that compiles it
+type ParserXToolPackagesAstMulti struct {
+	ParserXToolPackagesAst `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Platforms              []string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_                      [40]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 256 bytes; struct align: 8 bytes; struct aligned size: 256 bytes; struct ptr scan size: 200 bytes; - 🌺 gopium @1pkg
+
+// Expand ParserXToolPackagesAstMulti implementation
+func (p *ParserXToolPackagesAstMulti) Expand(ctx context.Context) ([]gopium.Parser, error) {
+	// manage context actions
+	// in case of cancelation
+	// stop parse and return error back
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	// no platforms stands for
+	// default build environment
+	platforms := p.Platforms
+	if len(platforms) == 0 {
+		platforms = []string{""}
+	}
+	// go through all platforms one by one
+	// and build single package parser
+	// for each picked package variant
+	parsers := make([]gopium.Parser, 0, len(platforms))
+	owners := make(map[string]*ParserXToolPackagesAst)
+	var loaded []*packages.Package
+	for _, platform := range platforms {
+		// extend build env with platform
+		env, err := platformEnv(p.BuildEnv, platform)
+		if err != nil {
+			return nil, err
+		}
+		pp := p.ParserXToolPackagesAst
+		pp.BuildEnv = env
+		// load all matched packages with tests at once
+		pkgs, _, err := pp.load(ctx, p.ModeTypes, true)
+		// on any error just propagate it
+		if err != nil {
+			return nil, err
+		}
+		for _, pkg := range variants(pkgs) {
+			loaded = append(loaded, pkg)
+			// each package file belongs to
+			// the first platform that compiles it
+			// so results are merged per file
+			files := make([]string, 0, len(pkg.GoFiles))
+			for _, file := range pkg.GoFiles {
+				if _, ok := owners[file]; !ok {
+					files = append(files, file)
+				}
+			}
+			// skip packages without own files
+			if len(files) == 0 {
+				continue
+			}
+			opkg := *pkg
+			opkg.GoFiles = files
+			op := &ParserXToolPackagesAst{
+				Pattern:    pkg.PkgPath,
+				Path:       filepath.Dir(files[0]),
+				BuildEnv:   env,
+				BuildFlags: p.BuildFlags,
+				Overlay:    p.Overlay,
+				Profile:    p.Profile,
+				ModeTypes:  p.ModeTypes,
+				ModeAst:    p.ModeAst,
+				loaded:     &opkg,
+			}
+			for _, file := range files {
+				owners[file] = op
+			}
+			parsers = append(parsers, op)
+		}
+	}
 	// check parse results
-	// note: ast requires only last element of the path
-	path := filepath.Base(p.Path)
-	for pckgn, pckg := range pkgs {
-		if validPackage(pckgn, p.Pattern, path) {
-			return pckg, NewLocator(fset), nil
+	if len(parsers) == 0 {
+		dir := p.Path
+		return nil, fmt.Errorf("types packages %q weren't found at %q", p.Pattern, dir)
+	}
+	// go through all loaded packages
+	// and mark structs with unkeyed literals
+	// inside files owned by other platforms
+	for _, pkg := range loaded {
+		foreign(pkg, owners)
+	}
+	return parsers, nil
+}
+
+// platformEnv extends build env with goos and goarch
+// of provided platform in goos/goarch format,
+// empty platform stands for unchanged build env
+func platformEnv(env []string, platform string) ([]string, error) {
+	if platform == "" {
+		return env, nil
+	}
+	parts := strings.Split(platform, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("platform %q is invalid, goos/goarch is expected", platform)
+	}
+	// nil build env stands
+	// for current process env
+	if env == nil {
+		env = os.Environ()
+	}
+	penv := make([]string, 0, len(env)+2)
+	penv = append(penv, env...)
+	return append(penv, "GOOS="+parts[0], "GOARCH="+parts[1]), nil
+}
+
+// MultiPattern checks if provided package pattern
+// is go list pattern that could match multiple packages
+func MultiPattern(pattern string) bool {
+	return strings.Contains(pattern, "...")
+}
+
+// variants picks single variant for each loaded package
+// and returns them sorted by packages paths
+// note: with tests each package could be loaded
+// - as plain package
+// - as package variant with internal tests
+// - as external tests package
+// - as generated tests main package
+// see go packages config test description
+func variants(pkgs []*packages.Package) []*packages.Package {
+	vpkgs := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		// skip generated tests main packages,
+		// external tests packages and packages
+		// without any go files
+		if strings.HasSuffix(pkg.PkgPath, ".test") ||
+			strings.HasSuffix(pkg.PkgPath, "_test") ||
+			len(pkg.GoFiles) == 0 {
+			continue
+		}
+		// prefer variant with internal tests
+		// as the most complete one
+		if v, ok := vpkgs[pkg.PkgPath]; !ok || len(pkg.GoFiles) > len(v.GoFiles) {
+			vpkgs[pkg.PkgPath] = pkg
 		}
 	}
-	return nil, nil, fmt.Errorf("ast package %q wasn't found at %q", p.Pattern, dir)
+	// sort packages by paths
+	// to keep results order stable
+	result := make([]*packages.Package, 0, len(vpkgs))
+	for _, pkg := range vpkgs {
+		result = append(result, pkg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PkgPath < result[j].PkgPath
+	})
+	return result
 }
 
-// validPackage checks package name against provided package pattern and path.
-// It preformns shallow sanity package name check to be able to validate
-// packages outside of gopath and versioned packages.
-func validPackage(pckg string, pattern string, path string) bool {
-	// to be extra sure take last component of the path.
-	path = filepath.Base(path)
-	// first check package name directly
-	if direct := strings.HasSuffix(pckg, pattern) ||
-		strings.HasSuffix(pckg, path); direct {
+// locate collects all anonymous structs,
+// detects and pins all layout sensitive structs,
+// collects all generic structs instances
+// builds all structs fields co-access graphs,
+// attributes profile samples to structs fields
+// and collects all structs 64-bit atomic fields
+// using loaded package types info
+func locate(pkg *packages.Package, fset *token.FileSet, profile map[string]int64) (*types.Package, *Locator) {
+	loc := NewLocator(fset)
+	anonymous(pkg.Types, pkg.TypesInfo, pkg.Syntax, loc)
+	Pin(pkg.Types, pkg.TypesInfo, pkg.Syntax, loc)
+	instantiate(pkg.Types, pkg.TypesInfo, loc)
+	coaccess(pkg.Types, pkg.TypesInfo, pkg.Syntax, loc)
+	heat(pkg.Types, pkg.TypesInfo, pkg.Syntax, profile, loc)
+	atomics(pkg.Types, pkg.TypesInfo, pkg.Syntax, loc)
+	return pkg.Types, loc
+}
+
+// owned narrows loaded types package down to objects
+// declared inside package go files only, as package go files
+// could be narrowed down to files of a single platform,
+// it clones package scopes only if any object is left out
+func owned(pkg *packages.Package) *types.Package {
+	// skip packages without types info
+	if pkg.Types == nil || pkg.Fset == nil {
+		return pkg.Types
+	}
+	files := make(map[string]bool, len(pkg.GoFiles))
+	for _, file := range pkg.GoFiles {
+		files[file] = true
+	}
+	in := func(p token.Pos) bool {
+		return files[pkg.Fset.Position(p).Filename]
+	}
+	// check if any object is left out
+	s := pkg.Types.Scope()
+	names := s.Names()
+	out := false
+	for _, name := range names {
+		if !in(s.Lookup(name).Pos()) {
+			out = true
+			break
+		}
+	}
+	if !out {
+		return pkg.Types
+	}
+	// clone package scope objects
+	// and files scopes of package go files
+	tpkg := types.NewPackage(pkg.Types.Path(), pkg.Types.Name())
+	for _, name := range names {
+		if obj := s.Lookup(name); in(obj.Pos()) {
+			tpkg.Scope().Insert(obj)
+		}
+	}
+	for i := 0; i < s.NumChildren(); i++ {
+		if child := s.Child(i); in(child.Pos()) {
+			clone(tpkg.Scope(), child)
+		}
+	}
+	return tpkg
+}
+
+// clone recursively clones scope
+// with all its objects and children
+// into provided parent scope
+func clone(parent *types.Scope, s *types.Scope) {
+	c := types.NewScope(parent, s.Pos(), s.End(), "")
+	for _, name := range s.Names() {
+		c.Insert(s.Lookup(name))
+	}
+	for i := 0; i < s.NumChildren(); i++ {
+		clone(c, s.Child(i))
+	}
+}
+
+// validPackage checks loaded package against provided package pattern,
+// pattern is expected to be either package import path
+// or package directory either absolute or relative to provided dir
+// or bare package name of package inside provided dir,
+// for module packages directory is converted to import path
+// using package module metadata, so it works for go.work workspaces as well
+func validPackage(pkg *packages.Package, pattern string, dir string) bool {
+	// first check package import path directly
+	if pkg.PkgPath == pattern {
 		return true
 	}
-	// then filter out package version
-	parts := strings.Split(pckg, "/")
-	fparts := make([]string, 0, len(parts))
-	for _, part := range parts {
-		var version int64
-		if _, err := fmt.Sscanf(part, "v%d", &version); err == nil {
-			continue
+	// then check bare package name
+	// against package inside dir
+	if barePackage(pattern) {
+		abs, err := filepath.Abs(dir)
+		return err == nil &&
+			pkg.Name == pattern &&
+			len(pkg.GoFiles) > 0 &&
+			filepath.Dir(pkg.GoFiles[0]) == abs
+	}
+	// then check that pattern is directory
+	if pattern == "" {
+		pattern = "."
+	}
+	if !build.IsLocalImport(pattern) && !filepath.IsAbs(pattern) {
+		return false
+	}
+	// build absolute package directory
+	target := pattern
+	if !filepath.IsAbs(target) {
+		abs, err := filepath.Abs(filepath.Join(dir, pattern))
+		if err != nil {
+			return false
+		}
+		target = abs
+	}
+	// for module packages compare import path
+	// built from module path and relative directory
+	if pkg.Module != nil && pkg.Module.Dir != "" {
+		rel, err := filepath.Rel(pkg.Module.Dir, target)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return false
 		}
-		fparts = append(fparts, part)
+		return pkg.PkgPath == path.Join(pkg.Module.Path, filepath.ToSlash(rel))
 	}
-	pckg = strings.Join(fparts, "/")
-	// and try to recheck again
-	return strings.HasSuffix(pckg, pattern) ||
-		strings.HasSuffix(pckg, path)
+	// otherwise compare package directory directly
+	return len(pkg.GoFiles) > 0 && filepath.Dir(pkg.GoFiles[0]) == target
+}
+
+// barePackage checks if package pattern
+// is bare package name like `transaction`
+// rather than import path or directory
+func barePackage(pattern string) bool {
+	return pattern != "" &&
+		!build.IsLocalImport(pattern) &&
+		!filepath.IsAbs(pattern) &&
+		!strings.ContainsAny(pattern, "/\\.")
 }
@@ -12,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 
@@ -67,6 +68,59 @@ func TestParserXToolPackagesAstTypes(t *testing.T) {
 			pkg: types.NewPackage("test", "test"),
 			loc: NewLocator(nil),
 		},
+		"missing pattern should return parser error": {
+			p: ParserXToolPackagesAst{
+				Pattern: "github.com/1pkg/gopium/none",
+				Path:    sdir,
+				//nolint
+				ModeTypes: packages.LoadAllSyntax,
+			},
+			ctx: context.Background(),
+			err: fmt.Errorf("types package %q wasn't found at %q", "github.com/1pkg/gopium/none", sdir),
+		},
+		"directory pattern with abs path should return expected parser package": {
+			p: ParserXToolPackagesAst{
+				Pattern: ".",
+				Path:    pdir,
+				//nolint
+				ModeTypes: packages.LoadAllSyntax,
+			},
+			ctx: context.Background(),
+			pkg: types.NewPackage("test", "test"),
+			loc: NewLocator(nil),
+		},
+		"relative directory pattern should return expected parser package": {
+			p: ParserXToolPackagesAst{
+				Pattern: "../gopium",
+				Path:    sdir,
+				//nolint
+				ModeTypes: packages.LoadAllSyntax,
+			},
+			ctx: context.Background(),
+			pkg: types.NewPackage("test", "test"),
+			loc: NewLocator(nil),
+		},
+		"bare package name with abs path should return expected parser package": {
+			p: ParserXToolPackagesAst{
+				Pattern: "gopium",
+				Path:    pdir,
+				//nolint
+				ModeTypes: packages.LoadAllSyntax,
+			},
+			ctx: context.Background(),
+			pkg: types.NewPackage("test", "test"),
+			loc: NewLocator(nil),
+		},
+		"bare package name with another path should return parser error": {
+			p: ParserXToolPackagesAst{
+				Pattern: "gopium",
+				Path:    sdir,
+				//nolint
+				ModeTypes: packages.LoadAllSyntax,
+			},
+			ctx: context.Background(),
+			err: fmt.Errorf("types package %q wasn't found at %q", "gopium", sdir),
+		},
 		"empty types mode should return expected empty parser package": {
 			p: ParserXToolPackagesAst{
 				Pattern: "github.com/1pkg/gopium/gopium",
@@ -207,42 +261,71 @@ func TestParserXToolPackagesAstAst(t *testing.T) {
 			ctx: context.Background(),
 			err: tests.OnOS(
 				"windows",
-				fmt.Errorf("%s", "open test: The system cannot find the file specified."),
-				fmt.Errorf("%s", "open test: no such file or directory"),
+				fmt.Errorf("%s", "err: chdir test: The system cannot find the file specified.: stderr: "),
+				fmt.Errorf("%s", "err: chdir test: no such file or directory: stderr: "),
 			).(error),
 		},
 		"invalid pattern with relative path should return parser error": {
+			p: ParserXToolPackagesAst{
+				Pattern: "github.com/1pkg/gopium/none",
+				Path:    ".",
+				ModeAst: parser.ParseComments | parser.AllErrors,
+			},
+			ctx: context.Background(),
+			err: errors.New(`ast package "github.com/1pkg/gopium/none" wasn't found at "."`),
+		},
+		"valid pattern with relative path should return expected parser ast": {
 			p: ParserXToolPackagesAst{
 				Pattern: "github.com/1pkg/gopium/gopium",
 				Path:    ".",
 				ModeAst: parser.ParseComments | parser.AllErrors,
 			},
 			ctx: context.Background(),
-			err: errors.New(`ast package "github.com/1pkg/gopium/gopium" wasn't found at "."`),
+			pkg: &ast.Package{},
+			loc: NewLocator(nil),
 		},
-		"valid pattern with root path should return expected parser ast": {
+		"bare package name with full path should return expected parser ast": {
 			p: ParserXToolPackagesAst{
 				Pattern: "gopium",
-				Root:    pdir,
+				Path:    pdir,
 				ModeAst: parser.ParseComments | parser.AllErrors,
 			},
 			ctx: context.Background(),
 			pkg: &ast.Package{},
 			loc: NewLocator(nil),
 		},
-		"invalid pattern with full path should return expected parser ast": {
+		"valid directory pattern with full path should return expected parser ast": {
+			p: ParserXToolPackagesAst{
+				Pattern: ".",
+				Path:    pdir,
+				ModeAst: parser.ParseComments | parser.AllErrors,
+			},
+			ctx: context.Background(),
+			pkg: &ast.Package{},
+			loc: NewLocator(nil),
+		},
+		"invalid pattern with full path should return parser error": {
 			p: ParserXToolPackagesAst{
 				Pattern: "1pkg/gopium/1gopium",
 				Path:    pdir,
 				ModeAst: parser.ParseComments | parser.AllErrors,
 			},
 			ctx: context.Background(),
+			err: fmt.Errorf("ast package %q wasn't found at %q", "1pkg/gopium/1gopium", pdir),
+		},
+		"valid relative directory pattern should return expected parser ast": {
+			p: ParserXToolPackagesAst{
+				Pattern: "../gopium",
+				Path:    ".",
+				ModeAst: parser.ParseComments | parser.AllErrors,
+			},
+			ctx: context.Background(),
 			pkg: &ast.Package{},
 			loc: NewLocator(nil),
 		},
 		"valid pattern and path and empty ast mode should return expected parser ast": {
 			p: ParserXToolPackagesAst{
-				Pattern: "gopium",
+				Pattern: "github.com/1pkg/gopium/gopium",
 				Path:    filepath.Join("..", "gopium"),
 			},
 			ctx: context.Background(),
@@ -251,7 +334,7 @@ func TestParserXToolPackagesAstAst(t *testing.T) {
 		},
 		"valid pattern and path and mode should return expected parser ast": {
 			p: ParserXToolPackagesAst{
-				Pattern: "gopium",
+				Pattern: "github.com/1pkg/gopium/gopium",
 				Path:    filepath.Join("..", "gopium"),
 				ModeAst: parser.ParseComments | parser.AllErrors,
 			},
@@ -261,7 +344,7 @@ func TestParserXToolPackagesAstAst(t *testing.T) {
 		},
 		"valid pattern and path and mode should return expected parser ast with src": {
 			p: ParserXToolPackagesAst{
-				Pattern: "gopium",
+				Pattern: "github.com/1pkg/gopium/gopium",
 				Path:    filepath.Join("..", "gopium"),
 				ModeAst: parser.ParseComments | parser.AllErrors,
 			},
@@ -287,7 +370,7 @@ type Single struct {
 		},
 		"valid pattern and path and mode should return parser error with invalid src": {
 			p: ParserXToolPackagesAst{
-				Pattern: "gopium",
+				Pattern: "github.com/1pkg/gopium/gopium",
 				Path:    filepath.Join("..", "gopium"),
 				ModeAst: parser.ParseComments | parser.AllErrors,
 			},
@@ -335,7 +418,7 @@ type Single struct {
 		},
 		"valid pattern and path and mode should return parser error on canceled context": {
 			p: ParserXToolPackagesAst{
-				Pattern: "gopium",
+				Pattern: "github.com/1pkg/gopium/gopium",
 				Path:    filepath.Join("..", "gopium"),
 				ModeAst: parser.ParseComments | parser.AllErrors,
 			},
@@ -373,3 +456,337 @@ type Single struct {
 		})
 	}
 }
+
+func TestParserXToolPackagesAstMultiExpand(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		p   ParserXToolPackagesAstMulti
+		ctx context.Context
+		pts []string
+		err error
+	}{
+		"invalid folder should return parser error": {
+			p: ParserXToolPackagesAstMulti{
+				ParserXToolPackagesAst: ParserXToolPackagesAst{
+					Pattern: "./...",
+					Path:    "test",
+					//nolint
+					ModeTypes: packages.LoadAllSyntax,
+				},
+			},
+			ctx: context.Background(),
+			err: tests.OnOS(
+				"windows",
+				fmt.Errorf("%s", "err: chdir test: The system cannot find the file specified.: stderr: "),
+				fmt.Errorf("%s", "err: chdir test: no such file or directory: stderr: "),
+			).(error),
+		},
+		"pattern without matches should return parser error": {
+			p: ParserXToolPackagesAstMulti{
+				ParserXToolPackagesAst: ParserXToolPackagesAst{
+					Pattern: "github.com/1pkg/gopium/tests/data/none/...",
+					Path:    "..",
+					//nolint
+					ModeTypes: packages.LoadAllSyntax,
+				},
+			},
+			ctx: context.Background(),
+			err: errors.New(`types packages "github.com/1pkg/gopium/tests/data/none/..." weren't found at ".."`),
+		},
+		"valid pattern should return expected sorted parsers": {
+			p: ParserXToolPackagesAstMulti{
+				ParserXToolPackagesAst: ParserXToolPackagesAst{
+					Pattern: "github.com/1pkg/gopium/tests/data/...",
+					Path:    "..",
+					//nolint
+					ModeTypes:  packages.LoadAllSyntax,
+					ModeAst:    parser.ParseComments | parser.AllErrors,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+			},
+			ctx: context.Background(),
+			pts: []string{
+				"github.com/1pkg/gopium/tests/data",
+				"github.com/1pkg/gopium/tests/data/anonymous",
+				"github.com/1pkg/gopium/tests/data/arch",
+				"github.com/1pkg/gopium/tests/data/archmerge",
+				"github.com/1pkg/gopium/tests/data/atomic",
+				"github.com/1pkg/gopium/tests/data/coaccess",
+				"github.com/1pkg/gopium/tests/data/embedded",
+				"github.com/1pkg/gopium/tests/data/empty",
+				"github.com/1pkg/gopium/tests/data/flat",
+				"github.com/1pkg/gopium/tests/data/generic",
+				"github.com/1pkg/gopium/tests/data/multi",
+				"github.com/1pkg/gopium/tests/data/nested",
+				"github.com/1pkg/gopium/tests/data/note",
+				"github.com/1pkg/gopium/tests/data/pinned",
+				"github.com/1pkg/gopium/tests/data/platform",
+				"github.com/1pkg/gopium/tests/data/single",
+				"github.com/1pkg/gopium/tests/data/split",
+				"github.com/1pkg/gopium/tests/data/splitzero",
+				"github.com/1pkg/gopium/tests/data/unkeyed",
+				"github.com/1pkg/gopium/tests/data/zerotail",
+			},
+		},
+		"valid pattern should return parser error on canceled context": {
+			p: ParserXToolPackagesAstMulti{
+				ParserXToolPackagesAst: ParserXToolPackagesAst{
+					Pattern: "github.com/1pkg/gopium/tests/data/...",
+					Path:    "..",
+					//nolint
+					ModeTypes: packages.LoadAllSyntax,
+				},
+			},
+			ctx: cctx,
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			parsers, err := tcase.p.Expand(tcase.ctx)
+			// check
+			var pts []string
+			for _, p := range parsers {
+				xp, ok := p.(*ParserXToolPackagesAst)
+				if !ok {
+					t.Fatalf("actual %T doesn't equal to expected %T", p, xp)
+				}
+				pts = append(pts, xp.Pattern)
+				// expanded parser should parse
+				// exactly its preloaded package
+				pkg, _, err := xp.ParseTypes(context.Background())
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+				if !reflect.DeepEqual(pkg.Path(), xp.Pattern) {
+					t.Errorf("actual %v doesn't equal to expected %v", pkg.Path(), xp.Pattern)
+				}
+				apkg, _, err := xp.ParseAst(context.Background())
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+				if !reflect.DeepEqual(apkg.Name, pkg.Name()) {
+					t.Errorf("actual %v doesn't equal to expected %v", apkg.Name, pkg.Name())
+				}
+			}
+			if !reflect.DeepEqual(pts, tcase.pts) {
+				t.Errorf("actual %v doesn't equal to expected %v", pts, tcase.pts)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestParserXToolPackagesAstMultiPlatforms(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		platforms []string
+		names     [][]string
+		files     [][]string
+		pins      [][]string
+		err       error
+	}{
+		"invalid platform should return parser error": {
+			platforms: []string{"linux"},
+			err:       errors.New(`platform "linux" is invalid, goos/goarch is expected`),
+		},
+		"single platform should return single parser with all platform files": {
+			platforms: []string{"windows/amd64"},
+			names:     [][]string{{"Common", "Windows"}},
+			files:     [][]string{{"file.go", "file_windows.go"}},
+			pins:      [][]string{nil},
+		},
+		"multiple platforms should return parsers with files of the first platforms": {
+			platforms: []string{"linux/amd64", "windows/amd64", "linux/arm64", "windows/386"},
+			names:     [][]string{{"Common", "Linux"}, {"Windows"}, {"Arm"}},
+			files:     [][]string{{"file.go", "file_linux.go"}, {"file_windows.go"}, {"file_tag.go"}},
+			pins:      [][]string{{"Common: other platform unkeyed literal"}, nil, nil},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			p := ParserXToolPackagesAstMulti{
+				ParserXToolPackagesAst: ParserXToolPackagesAst{
+					Pattern: "github.com/1pkg/gopium/tests/data/platform",
+					Path:    "..",
+					//nolint
+					ModeTypes:  packages.LoadAllSyntax,
+					ModeAst:    parser.ParseComments | parser.AllErrors,
+					BuildFlags: []string{"-tags=tests_data"},
+				},
+				Platforms: tcase.platforms,
+			}
+			// exec
+			parsers, err := p.Expand(context.Background())
+			// check
+			var names, files, pins [][]string
+			for _, p := range parsers {
+				pkg, loc, err := p.ParseTypes(context.Background())
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+				names = append(names, pkg.Scope().Names())
+				var ppins []string
+				for _, name := range pkg.Scope().Names() {
+					if reason, ok := loc.Pin(pkg.Scope().Lookup(name).Pos(), ""); ok {
+						ppins = append(ppins, fmt.Sprintf("%s: %s", name, reason))
+					}
+				}
+				pins = append(pins, ppins)
+				apkg, _, err := p.ParseAst(context.Background())
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+				var afiles []string
+				for name := range apkg.Files {
+					afiles = append(afiles, filepath.Base(name))
+				}
+				sort.Strings(afiles)
+				files = append(files, afiles)
+			}
+			if !reflect.DeepEqual(names, tcase.names) {
+				t.Errorf("actual %v doesn't equal to expected %v", names, tcase.names)
+			}
+			if !reflect.DeepEqual(files, tcase.files) {
+				t.Errorf("actual %v doesn't equal to expected %v", files, tcase.files)
+			}
+			if !reflect.DeepEqual(pins, tcase.pins) {
+				t.Errorf("actual %v doesn't equal to expected %v", pins, tcase.pins)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestValidPackage(t *testing.T) {
+	// prepare
+	mdir := tests.OnOS("windows", "c:\\mod", "/mod").(string)
+	mod := &packages.Module{Path: "example.com/mod", Dir: mdir}
+	table := map[string]struct {
+		pkg     *packages.Package
+		pattern string
+		dir     string
+		valid   bool
+	}{
+		"package with same import path should be valid": {
+			pkg:     &packages.Package{PkgPath: "example.com/mod/pkg", Module: mod},
+			pattern: "example.com/mod/pkg",
+			valid:   true,
+		},
+		"package with different import path should not be valid": {
+			pkg:     &packages.Package{PkgPath: "example.com/mod/pkg", Module: mod},
+			pattern: "mod/pkg",
+		},
+		"versioned package with suffix import path should not be valid": {
+			pkg:     &packages.Package{PkgPath: "example.com/mod/v2/pkg", Module: mod},
+			pattern: "example.com/mod/pkg",
+		},
+		"module package inside relative directory should be valid": {
+			pkg:     &packages.Package{PkgPath: "example.com/mod/pkg", Module: mod},
+			pattern: "./pkg",
+			dir:     mdir,
+			valid:   true,
+		},
+		"module package inside current directory should be valid": {
+			pkg:   &packages.Package{PkgPath: "example.com/mod/pkg", Module: mod},
+			dir:   filepath.Join(mdir, "pkg"),
+			valid: true,
+		},
+		"module package inside another directory should not be valid": {
+			pkg:     &packages.Package{PkgPath: "example.com/mod/pkg", Module: mod},
+			pattern: "./other",
+			dir:     mdir,
+		},
+		"module package outside of module directory should not be valid": {
+			pkg:     &packages.Package{PkgPath: "example.com/mod/pkg", Module: mod},
+			pattern: filepath.Join(mdir, "..", "pkg"),
+		},
+		"package with bare name inside directory should be valid": {
+			pkg: &packages.Package{
+				Name:    "pkg",
+				PkgPath: "example.com/mod/pkg",
+				GoFiles: []string{filepath.Join(mdir, "pkg", "file.go")},
+				Module:  mod,
+			},
+			pattern: "pkg",
+			dir:     filepath.Join(mdir, "pkg"),
+			valid:   true,
+		},
+		"package with bare name inside another directory should not be valid": {
+			pkg: &packages.Package{
+				Name:    "pkg",
+				PkgPath: "example.com/mod/pkg",
+				GoFiles: []string{filepath.Join(mdir, "pkg", "file.go")},
+				Module:  mod,
+			},
+			pattern: "pkg",
+			dir:     mdir,
+		},
+		"package with another bare name inside directory should not be valid": {
+			pkg: &packages.Package{
+				Name:    "pkg",
+				PkgPath: "example.com/mod/pkg",
+				GoFiles: []string{filepath.Join(mdir, "pkg", "file.go")},
+				Module:  mod,
+			},
+			pattern: "other",
+			dir:     filepath.Join(mdir, "pkg"),
+		},
+		"package without module inside directory should be valid": {
+			pkg: &packages.Package{
+				PkgPath: "pkg",
+				GoFiles: []string{filepath.Join(mdir, "pkg", "file.go")},
+			},
+			pattern: filepath.Join(mdir, "pkg"),
+			valid:   true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			valid := validPackage(tcase.pkg, tcase.pattern, tcase.dir)
+			// check
+			if !reflect.DeepEqual(valid, tcase.valid) {
+				t.Errorf("actual %v doesn't equal to expected %v", valid, tcase.valid)
+			}
+		})
+	}
+}
+
+func TestMultiPattern(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		pattern string
+		multi   bool
+	}{
+		"single package pattern should not be multi": {
+			pattern: "github.com/1pkg/gopium/gopium",
+		},
+		"relative wildcard pattern should be multi": {
+			pattern: "./...",
+			multi:   true,
+		},
+		"module wildcard pattern should be multi": {
+			pattern: "example.com/mod/...",
+			multi:   true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			multi := MultiPattern(tcase.pattern)
+			// check
+			if !reflect.DeepEqual(multi, tcase.multi) {
+				t.Errorf("actual %v doesn't equal to expected %v", multi, tcase.multi)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package typepkg
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// list of layout sensitive usages reasons
+const (
+	pinBinary   = "encoding/binary"
+	pinUnsafe   = "unsafe.Pointer"
+	pinOffsetof = "unsafe.Offsetof"
+	pinCgo      = "cgo"
+	pinLinkname = "go:linkname"
+	pinReflect  = "reflect"
+	pinUnkeyed  = "other platform unkeyed literal"
+)
+
+// Pin goes through all package files
+// and detects structs which layout is relied on
+// by encoding/binary, unsafe, cgo, go:linkname
+// or reflect usages, then pins all such structs
+// and their nested package structs inside locator
+func Pin(pkg *types.Package, info *types.Info, files []*ast.File, loc *Locator) {
+	// skip packages without types info
+	if pkg == nil || info == nil {
+		return
+	}
+	// mark defines pinning helper
+	// that pins provided type structs
+	// with reason only once
+	marked := make(map[token.Pos]map[string]bool)
+	var mark func(types.Type, string)
+	mark = func(t types.Type, reason string) {
+		p, st := pinned(pkg, t, loc)
+		if st == nil || marked[p][reason] {
+			return
+		}
+		if marked[p] == nil {
+			marked[p] = make(map[string]bool)
+		}
+		marked[p][reason] = true
+		loc.Pin(p, reason)
+		// nested structs layouts
+		// are relied on as well
+		for i := 0; i < st.NumFields(); i++ {
+			mark(st.Field(i).Type(), reason)
+		}
+	}
+	for _, file := range files {
+		// collect all linkname directives
+		// from file comments first
+		for _, cg := range file.Comments {
+			for _, c := range cg.List {
+				fields := strings.Fields(c.Text)
+				if len(fields) < 2 || fields[0] != "//go:linkname" {
+					continue
+				}
+				// pin all structs used
+				// by linknamed object type
+				if obj := pkg.Scope().Lookup(fields[1]); obj != nil {
+					visible(obj.Type(), func(t types.Type) { mark(t, pinLinkname) })
+				}
+			}
+		}
+		// then go through all calls
+		// and conversions in file
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			// unsafe pointer conversions
+			// either from or to struct pointer
+			if tv, ok := info.Types[call.Fun]; ok && tv.IsType() && len(call.Args) == 1 {
+				if unsafeptr(tv.Type) {
+					mark(info.TypeOf(call.Args[0]), pinUnsafe)
+				} else if unsafeptr(info.TypeOf(call.Args[0])) {
+					mark(tv.Type, pinUnsafe)
+				}
+				return true
+			}
+			// cgo calls either in original
+			// or in cgo processed form
+			if cgocall(info, call.Fun) {
+				for _, arg := range call.Args {
+					mark(info.TypeOf(arg), pinCgo)
+				}
+				return true
+			}
+			switch fn := typeutil.Callee(info, call).(type) {
+			case *types.Builtin:
+				// unsafe offsetof selectors
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && fn.Name() == "Offsetof" && len(call.Args) == 1 {
+					if id, ok := sel.X.(*ast.Ident); ok {
+						if pn, ok := info.Uses[id].(*types.PkgName); ok && pn.Imported().Path() == "unsafe" {
+							if arg, ok := ast.Unparen(call.Args[0]).(*ast.SelectorExpr); ok {
+								mark(info.TypeOf(arg.X), pinOffsetof)
+							}
+						}
+					}
+				}
+			case *types.Func:
+				if fn.Pkg() == nil {
+					break
+				}
+				switch path, name := fn.Pkg().Path(), fn.Name(); {
+				case path == "encoding/binary" && (name == "Read" || name == "Write" || name == "Size"):
+					for _, arg := range call.Args {
+						mark(info.TypeOf(arg), pinBinary)
+					}
+				case path == "reflect" && (name == "TypeOf" || name == "ValueOf"):
+					for _, arg := range call.Args {
+						mark(info.TypeOf(arg), pinReflect)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// pinned resolves package struct position
+// and struct behind provided type
+// by dereferencing pointers, slices and arrays
+// anonymous structs are resolved by their
+// synthetic type specs positions
+func pinned(pkg *types.Package, t types.Type, loc *Locator) (token.Pos, *types.Struct) {
+	for {
+		switch tp := t.(type) {
+		case *types.Pointer:
+			t = tp.Elem()
+		case *types.Slice:
+			t = tp.Elem()
+		case *types.Array:
+			t = tp.Elem()
+		case *types.Named:
+			tn := tp.Obj()
+			st, ok := tp.Underlying().(*types.Struct)
+			if !ok || tn.Pkg() != pkg {
+				return token.NoPos, nil
+			}
+			// use generic origin type name
+			// as only origin is visited
+			return tp.Origin().Obj().Pos(), st
+		case *types.Struct:
+			if ts, ok := loc.Anonymous(tp, nil); ok {
+				return ts.Pos(), tp
+			}
+			return token.NoPos, nil
+		default:
+			return token.NoPos, nil
+		}
+	}
+}
+
+// visible goes through all types
+// visible in object type signature
+// and calls provided callback on them
+func visible(t types.Type, f func(types.Type)) {
+	sig, ok := t.(*types.Signature)
+	if !ok {
+		f(t)
+		return
+	}
+	for _, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+		for i := 0; i < tuple.Len(); i++ {
+			f(tuple.At(i).Type())
+		}
+	}
+	if recv := sig.Recv(); recv != nil {
+		f(recv.Type())
+	}
+}
+
+// unsafeptr checks if provided
+// type is unsafe.Pointer type
+func unsafeptr(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == types.UnsafePointer
+}
+
+// cgocall checks if function expression
+// refers to cgo function either as `C.name`
+// selector or as cgo processed `_Cfunc_name`
+func cgocall(info *types.Info, fun ast.Expr) bool {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.SelectorExpr:
+		if id, ok := f.X.(*ast.Ident); ok {
+			if pn, ok := info.Uses[id].(*types.PkgName); ok {
+				return pn.Imported().Path() == "C"
+			}
+			return id.Name == "C" && info.Uses[id] == nil
+		}
+	case *ast.Ident:
+		return strings.HasPrefix(f.Name, "_Cfunc_")
+	}
+	return false
+}
+
+// foreign goes through all package files
+// and collects positions of package structs
+// used with unkeyed literals inside files
+// owned by other platform parser than struct itself,
+// as such literals can't be rewritten by struct owner
+func foreign(pkg *packages.Package, owners map[string]*ParserXToolPackagesAst) {
+	// skip packages without types info
+	if pkg.Types == nil || pkg.TypesInfo == nil || pkg.Fset == nil {
+		return
+	}
+	for _, file := range pkg.Syntax {
+		owner := owners[pkg.Fset.Position(file.Pos()).Filename]
+		ast.Inspect(file, func(node ast.Node) bool {
+			// skip empty and keyed literals
+			lit, ok := node.(*ast.CompositeLit)
+			if !ok || len(lit.Elts) == 0 {
+				return true
+			}
+			if _, ok := lit.Elts[0].(*ast.KeyValueExpr); ok {
+				return true
+			}
+			// skip literals of irrelevant types
+			// use generic origin for named types
+			// as only origin is visited
+			named, ok := pkg.TypesInfo.TypeOf(lit).(*types.Named)
+			if !ok || named.Obj().Pkg() != pkg.Types {
+				return true
+			}
+			if _, ok := named.Underlying().(*types.Struct); !ok {
+				return true
+			}
+			// mark struct inside its owner
+			// if literal is owned by other one
+			pos := pkg.Fset.Position(named.Origin().Obj().Pos())
+			if sowner, ok := owners[pos.Filename]; ok && sowner != owner {
+				if sowner.foreign == nil {
+					sowner.foreign = make(map[token.Position]bool)
+				}
+				sowner.foreign[pos] = true
+			}
+			return true
+		})
+	}
+}
+
+// unkeyed pins all package structs
+// used with unkeyed literals inside files
+// owned by other platform parser
+func unkeyed(pkg *types.Package, fset *token.FileSet, positions map[token.Position]bool, loc *Locator) {
+	// skip packages without types info
+	if pkg == nil || fset == nil || len(positions) == 0 {
+		return
+	}
+	for _, name := range pkg.Scope().Names() {
+		if tn, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok && positions[fset.Position(tn.Pos())] {
+			loc.Pin(tn.Pos(), pinUnkeyed)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package typepkg
+
+import (
+	"context"
+	"go/parser"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/tests"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestPin(t *testing.T) {
+	// prepare
+	p := &ParserXToolPackagesAst{
+		Pattern: "github.com/1pkg/gopium/tests/data/pinned",
+		Path:    filepath.Join(tests.Gopium, "tests", "data", "pinned"),
+		//nolint
+		ModeTypes:  packages.LoadAllSyntax,
+		ModeAst:    parser.ParseComments | parser.AllErrors,
+		BuildFlags: []string{"-tags=tests_data"},
+	}
+	pkg, loc, err := p.ParseTypes(context.Background())
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		name   string
+		reason string
+		ok     bool
+	}{
+		"struct read by encoding/binary should be pinned": {
+			name:   "Header",
+			reason: "encoding/binary",
+			ok:     true,
+		},
+		"struct nested in pinned struct should be pinned": {
+			name:   "Inner",
+			reason: "encoding/binary",
+			ok:     true,
+		},
+		"struct without layout usages shouldn't be pinned": {
+			name: "Free",
+		},
+		"struct casted via unsafe pointer and reflected should be pinned with all reasons": {
+			name:   "Raw",
+			reason: "unsafe.Pointer, reflect",
+			ok:     true,
+		},
+		"struct used by unsafe offsetof should be pinned": {
+			name:   "Offset",
+			reason: "unsafe.Offsetof",
+			ok:     true,
+		},
+		"struct iterated by reflect should be pinned": {
+			name:   "Row",
+			reason: "reflect",
+			ok:     true,
+		},
+		"struct mirrored by linkname should be pinned": {
+			name:   "Mirror",
+			reason: "go:linkname",
+			ok:     true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			reason, ok := loc.Pin(pkg.Scope().Lookup(tcase.name).Pos(), "")
+			// check
+			if !reflect.DeepEqual(reason, tcase.reason) {
+				t.Errorf("actual %v doesn't equal to expected %v", reason, tcase.reason)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
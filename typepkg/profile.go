@@ -0,0 +1,304 @@
+package typepkg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"strings"
+)
+
+// list of pprof profile proto fields numbers
+const (
+	pprofSample   = 2
+	pprofLocation = 4
+	pprofFunction = 5
+	pprofString   = 6
+)
+
+// ReadProfile reads either gzipped or raw
+// pprof profile (like go `default.pgo` cpu profile)
+// and returns flat samples counts of profile functions
+// by their normalized names, each sample is attributed
+// to the innermost function of its leaf location
+// and counted by the first sample value
+func ReadProfile(r io.Reader) (map[string]int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	// decompress gzipped profile
+	if len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b {
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = io.ReadAll(gr); err != nil {
+			return nil, err
+		}
+	}
+	// decode profile samples leaf locations
+	// and values, locations innermost functions,
+	// functions names and strings table
+	type sample struct {
+		loc   uint64
+		value int64
+	}
+	var samples []sample
+	var strs []string
+	locs := make(map[uint64]uint64)
+	funcs := make(map[uint64]int64)
+	err = protowalk(data, func(num int, _ uint64, buf []byte) error {
+		switch num {
+		case pprofSample:
+			var s sample
+			var lok, vok bool
+			err := protowalk(buf, func(num int, val uint64, buf []byte) error {
+				switch {
+				case num == 1 && !lok:
+					s.loc, lok = protofirst(val, buf)
+				case num == 2 && !vok:
+					var v uint64
+					v, vok = protofirst(val, buf)
+					s.value = int64(v)
+				}
+				return nil
+			})
+			if lok && vok {
+				samples = append(samples, s)
+			}
+			return err
+		case pprofLocation:
+			var id, fid uint64
+			var fok bool
+			err := protowalk(buf, func(num int, val uint64, buf []byte) error {
+				switch {
+				case num == 1:
+					id = val
+				case num == 4 && !fok:
+					fok = true
+					return protowalk(buf, func(num int, val uint64, _ []byte) error {
+						if num == 1 {
+							fid = val
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+			locs[id] = fid
+			return err
+		case pprofFunction:
+			var id uint64
+			var name int64
+			err := protowalk(buf, func(num int, val uint64, _ []byte) error {
+				switch num {
+				case 1:
+					id = val
+				case 2:
+					name = int64(val)
+				}
+				return nil
+			})
+			funcs[id] = name
+			return err
+		case pprofString:
+			strs = append(strs, string(buf))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// sum samples values by
+	// normalized functions names
+	profile := make(map[string]int64)
+	for _, s := range samples {
+		fid, ok := locs[s.loc]
+		if !ok {
+			continue
+		}
+		name, ok := funcs[fid]
+		if !ok || name <= 0 || name >= int64(len(strs)) {
+			continue
+		}
+		profile[pprofName(strs[name])] += s.value
+	}
+	return profile, nil
+}
+
+// protowalk goes through all protobuf
+// message fields and calls provided callback
+// with field number and either scalar value
+// or length delimited bytes
+func protowalk(data []byte, f func(int, uint64, []byte) error) error {
+	for len(data) > 0 {
+		// read field tag
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("invalid protobuf tag")
+		}
+		data = data[n:]
+		num, wire := int(tag>>3), tag&7
+		var val uint64
+		var buf []byte
+		switch wire {
+		// varint
+		case 0:
+			if val, n = binary.Uvarint(data); n <= 0 {
+				return errors.New("invalid protobuf varint")
+			}
+			data = data[n:]
+		// fixed64
+		case 1:
+			if len(data) < 8 {
+				return errors.New("invalid protobuf fixed64")
+			}
+			val, data = binary.LittleEndian.Uint64(data), data[8:]
+		// length delimited
+		case 2:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < l {
+				return errors.New("invalid protobuf length")
+			}
+			buf, data = data[n:n+int(l)], data[n+int(l):]
+		// fixed32
+		case 5:
+			if len(data) < 4 {
+				return errors.New("invalid protobuf fixed32")
+			}
+			val, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		default:
+			return fmt.Errorf("unsupported protobuf wire type %d", wire)
+		}
+		if err := f(num, val, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protofirst returns first value of repeated
+// protobuf field that is either packed or not
+func protofirst(val uint64, buf []byte) (uint64, bool) {
+	if buf == nil {
+		return val, true
+	}
+	v, n := binary.Uvarint(buf)
+	return v, n > 0
+}
+
+// pprofName normalizes pprof function name
+// by dropping generic type arguments,
+// method values wrappers suffixes
+// and function literals suffixes, so
+// closures samples belong to enclosing function
+func pprofName(name string) string {
+	// drop generic type arguments
+	var b strings.Builder
+	depth := 0
+	for _, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			_, _ = b.WriteRune(r)
+		}
+	}
+	name = strings.TrimSuffix(b.String(), "-fm")
+	// drop function literals suffixes
+	// like `.func1`, `.func1.2` or `.gowrap1`
+	for {
+		i := strings.LastIndex(name, ".")
+		if i < 0 || i < strings.LastIndex(name, "/") {
+			return name
+		}
+		suffix := name[i+1:]
+		for _, prefix := range []string{"func", "gowrap", "deferwrap"} {
+			suffix = strings.TrimPrefix(suffix, prefix)
+		}
+		if suffix == "" || strings.Trim(suffix, "0123456789") != "" {
+			return name
+		}
+		name = name[:i]
+	}
+}
+
+// funcName builds pprof function name
+// for package function declaration
+func funcName(pkg *types.Package, fdecl *ast.FuncDecl) string {
+	name := fdecl.Name.Name
+	// add receiver type name for methods
+	if fdecl.Recv != nil && len(fdecl.Recv.List) > 0 {
+		t, ptr := fdecl.Recv.List[0].Type, false
+		if st, ok := t.(*ast.StarExpr); ok {
+			t, ptr = st.X, true
+		}
+		// drop receiver type params
+		switch tp := t.(type) {
+		case *ast.IndexExpr:
+			t = tp.X
+		case *ast.IndexListExpr:
+			t = tp.X
+		}
+		if id, ok := t.(*ast.Ident); ok {
+			if ptr {
+				name = fmt.Sprintf("(*%s).%s", id.Name, name)
+			} else {
+				name = fmt.Sprintf("%s.%s", id.Name, name)
+			}
+		}
+	}
+	// main package functions
+	// are named after package name
+	path := pkg.Path()
+	if pkg.Name() == "main" {
+		path = pkg.Name()
+	}
+	return fmt.Sprintf("%s.%s", path, name)
+}
+
+// heat goes through all package functions
+// found in profile and attributes their samples
+// to all package structs fields they access
+// inside locator as struct fields hotness
+func heat(pkg *types.Package, info *types.Info, files []*ast.File, profile map[string]int64, loc *Locator) {
+	// skip packages without types info
+	// or without profile
+	if pkg == nil || info == nil || len(profile) == 0 {
+		return
+	}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			// skip non function decls,
+			// functions without bodies
+			// and functions without samples
+			fdecl, ok := decl.(*ast.FuncDecl)
+			if !ok || fdecl.Body == nil {
+				continue
+			}
+			samples := profile[funcName(pkg, fdecl)]
+			if samples <= 0 {
+				continue
+			}
+			// each accessed field gets
+			// function samples only once
+			ps, accessed := accesses(pkg, info, fdecl, loc)
+			for _, p := range ps {
+				marked := make(map[string]bool, len(accessed[p]))
+				for _, field := range accessed[p] {
+					if !marked[field] {
+						marked[field] = true
+						loc.Hotness(p, field, samples)
+					}
+				}
+			}
+		}
+	}
+}
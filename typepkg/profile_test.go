@@ -0,0 +1,191 @@
+package typepkg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"go/parser"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestReadProfile(t *testing.T) {
+	// prepare
+	gz, err := os.ReadFile(filepath.Join(tests.Gopium, "tests", "data", "coaccess", "default.pgo"))
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	gr, err := gzip.NewReader(bytes.NewReader(gz))
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	raw, err := io.ReadAll(gr)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	profile := map[string]int64{
+		"github.com/1pkg/gopium/tests/data/coaccess.check":        10,
+		"github.com/1pkg/gopium/tests/data/coaccess.(*Hot).flags": 7,
+		"github.com/1pkg/gopium/tests/data/coaccess.Hot.sum":      3,
+		"github.com/1pkg/gopium/tests/data/coaccess.single":       7,
+		"runtime.mallocgc": 4,
+	}
+	table := map[string]struct {
+		input   []byte
+		profile map[string]int64
+		err     error
+	}{
+		"empty profile should return empty profile": {
+			profile: map[string]int64{},
+		},
+		"gzipped profile should return expected profile": {
+			input:   gz,
+			profile: profile,
+		},
+		"raw profile should return expected profile": {
+			input:   raw,
+			profile: profile,
+		},
+		"invalid gzipped profile should return error": {
+			input: []byte{0x1f, 0x8b},
+			err:   fmt.Errorf("unexpected EOF"),
+		},
+		"invalid protobuf tag should return error": {
+			input: []byte{0x80},
+			err:   fmt.Errorf("invalid protobuf tag"),
+		},
+		"invalid protobuf length should return error": {
+			input: []byte{0x12, 0x10},
+			err:   fmt.Errorf("invalid protobuf length"),
+		},
+		"unsupported protobuf wire type should return error": {
+			input: []byte{0x13},
+			err:   fmt.Errorf("unsupported protobuf wire type 3"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			profile, err := ReadProfile(bytes.NewReader(tcase.input))
+			// check
+			if !reflect.DeepEqual(profile, tcase.profile) {
+				t.Errorf("actual %v doesn't equal to expected %v", profile, tcase.profile)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestPprofName(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		name string
+		r    string
+	}{
+		"function name should return function name": {
+			name: "github.com/1pkg/test.fn",
+			r:    "github.com/1pkg/test.fn",
+		},
+		"generic function name should return function name without type args": {
+			name: "github.com/1pkg/test.(*Node[go.shape.int64]).fn",
+			r:    "github.com/1pkg/test.(*Node).fn",
+		},
+		"method value name should return method name": {
+			name: "github.com/1pkg/test.Node.fn-fm",
+			r:    "github.com/1pkg/test.Node.fn",
+		},
+		"closure name should return enclosing function name": {
+			name: "github.com/1pkg/test.fn.func1.2",
+			r:    "github.com/1pkg/test.fn",
+		},
+		"go statement wrapper name should return enclosing function name": {
+			name: "github.com/1pkg/test.fn.gowrap1",
+			r:    "github.com/1pkg/test.fn",
+		},
+		"dotted package path should return function name": {
+			name: "gopkg.in/v2.fn",
+			r:    "gopkg.in/v2.fn",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := pprofName(tcase.name)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
+
+func TestHeat(t *testing.T) {
+	// prepare
+	f, err := os.Open(filepath.Join(tests.Gopium, "tests", "data", "coaccess", "default.pgo"))
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	defer f.Close()
+	profile, err := ReadProfile(f)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	p := &ParserXToolPackagesAst{
+		Pattern: "github.com/1pkg/gopium/tests/data/coaccess",
+		Path:    filepath.Join(tests.Gopium, "tests", "data", "coaccess"),
+		//nolint
+		ModeTypes:  packages.LoadAllSyntax,
+		ModeAst:    parser.ParseComments | parser.AllErrors,
+		BuildFlags: []string{"-tags=tests_data"},
+		Profile:    profile,
+	}
+	pkg, loc, err := p.ParseTypes(context.Background())
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		name string
+		h    gopium.Hotness
+		ok   bool
+	}{
+		"struct accessed by profiled functions should have fields hotness": {
+			name: "Hot",
+			h: gopium.Hotness{
+				"a":    17,
+				"c":    17,
+				"big":  10,
+				"b":    10,
+				"d":    3,
+				"Base": 3,
+			},
+			ok: true,
+		},
+		"struct accessed by unprofiled functions should have no fields hotness": {
+			name: "Cold",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			h, ok := loc.Hotness(pkg.Scope().Lookup(tcase.name).Pos(), "", 0)
+			// check
+			if !reflect.DeepEqual(h, tcase.h) {
+				t.Errorf("actual %v doesn't equal to expected %v", h, tcase.h)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to expected %v", ok, tcase.ok)
+			}
+		})
+	}
+}
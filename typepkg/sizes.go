@@ -23,11 +23,19 @@ type stdsizes struct {
 } // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
 
 func (s stdsizes) WordSize() int64 {
+	// Custom target sizes know their WordSize directly.
+	if ts, ok := s.Sizes.(tsizes); ok {
+		return ts.word
+	}
 	// This should work for both gc and gccgo types as default case returning proper WordSize.
 	return s.Sizeof(stubtype{})
 }
 
 func (s stdsizes) MaxAlign() int64 {
+	// Custom target sizes know their MaxAlign directly.
+	if ts, ok := s.Sizes.(tsizes); ok {
+		return ts.align
+	}
 	// This should work for both gc and gccgo types as default case returning proper MaxAlign.
 	return s.Alignof(types.Typ[types.Complex128])
 }
@@ -79,3 +87,132 @@ func (s stdsizes) Ptr(t types.Type) int64 {
 
 	return 0
 }
+
+// tsizes implements sizes interface for custom target
+// using gc layout rules with provided word size,
+// max alignment and basic types overrides
+type tsizes struct {
+	basics map[types.BasicKind]TargetBasic `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	word   int64                           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	align  int64                           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [8]byte                         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 8 bytes; - 🌺 gopium @1pkg
+
+// Alignof tsizes implementation
+func (s tsizes) Alignof(t types.Type) int64 {
+	switch t := t.Underlying().(type) {
+	case *types.Basic:
+		// use basic type override if any
+		if b, ok := s.basics[t.Kind()]; ok {
+			return b.Align
+		}
+		// complex types are aligned as their parts
+		// and strings are aligned as pointers
+		a := s.basic(t.Kind())
+		switch t.Kind() {
+		case types.Complex64, types.Complex128:
+			a /= 2
+		case types.String:
+			a = s.word
+		}
+		return s.clamp(a)
+	case *types.Array:
+		return s.Alignof(t.Elem())
+	case *types.Struct:
+		a := int64(1)
+		for i := 0; i < t.NumFields(); i++ {
+			if fa := s.Alignof(t.Field(i).Type()); fa > a {
+				a = fa
+			}
+		}
+		return a
+	}
+	return s.clamp(s.word)
+}
+
+// Offsetsof tsizes implementation
+func (s tsizes) Offsetsof(fields []*types.Var) []int64 {
+	var o int64
+	offsets := make([]int64, len(fields))
+	for i, f := range fields {
+		o = collections.Align(o, s.Alignof(f.Type()))
+		offsets[i] = o
+		o += s.Sizeof(f.Type())
+	}
+	return offsets
+}
+
+// Sizeof tsizes implementation
+func (s tsizes) Sizeof(t types.Type) int64 {
+	switch t := t.Underlying().(type) {
+	case *types.Basic:
+		// use basic type override if any
+		if b, ok := s.basics[t.Kind()]; ok {
+			return b.Size
+		}
+		return s.basic(t.Kind())
+	case *types.Array:
+		// array elements are placed
+		// with their alignment stride
+		if t.Len() <= 0 {
+			return 0
+		}
+		es := s.Sizeof(t.Elem())
+		return collections.Align(es, s.Alignof(t.Elem())) * t.Len()
+	case *types.Slice:
+		return 3 * s.word
+	case *types.Interface:
+		return 2 * s.word
+	case *types.Struct:
+		n := t.NumFields()
+		if n == 0 {
+			return 0
+		}
+		fields := make([]*types.Var, 0, n)
+		for i := 0; i < n; i++ {
+			fields = append(fields, t.Field(i))
+		}
+		offsets := s.Offsetsof(fields)
+		offs, size := offsets[n-1], s.Sizeof(fields[n-1].Type())
+		// last field of non zero size struct
+		// can't have zero size as it would
+		// point past the struct end, so it is
+		// padded to single byte like in gc
+		if offs > 0 && size == 0 {
+			size = 1
+		}
+		return collections.Align(offs+size, s.Alignof(t))
+	}
+	return s.word
+}
+
+// basic returns default basic type size
+// by its kind for target word size
+func (s tsizes) basic(kind types.BasicKind) int64 {
+	switch kind {
+	case types.Bool, types.Int8, types.Uint8:
+		return 1
+	case types.Int16, types.Uint16:
+		return 2
+	case types.Int32, types.Uint32, types.Float32:
+		return 4
+	case types.Int64, types.Uint64, types.Float64, types.Complex64:
+		return 8
+	case types.Complex128:
+		return 16
+	case types.String:
+		return 2 * s.word
+	}
+	return s.word
+}
+
+// clamp limits alignment by max alignment
+func (s tsizes) clamp(a int64) int64 {
+	if a < 1 {
+		return 1
+	}
+	if a > s.align {
+		return s.align
+	}
+	return a
+}
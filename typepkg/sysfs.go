@@ -0,0 +1,133 @@
+package typepkg
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SysRoot defines default linux sysfs root
+const SysRoot = "/sys"
+
+// SysCache defines cpu cache level metadata
+// line size, total size and associativity
+type SysCache struct {
+	Line int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Size int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Ways int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [8]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// ReadSysCaches reads host cpu caches hierarchy
+// from linux sysfs root (like /sys) cpu0 caches
+// and returns data and unified caches metadata
+// ordered by cache level starting from l1,
+// instruction caches are skipped and missing
+// caches sizes or associativities are left zero
+func ReadSysCaches(root string) ([]SysCache, error) {
+	dir := filepath.Join(root, "devices", "system", "cpu", "cpu0", "cache")
+	indexes, err := filepath.Glob(filepath.Join(dir, "index*"))
+	if err != nil {
+		return nil, err
+	}
+	// go through all cache indexes
+	// and collect caches by levels
+	levels := make(map[int64]SysCache, len(indexes))
+	for _, index := range indexes {
+		ctype, err := sysread(index, "type")
+		if err != nil {
+			return nil, err
+		}
+		if ctype == "Instruction" {
+			continue
+		}
+		level, err := sysint(index, "level")
+		if err != nil {
+			return nil, err
+		}
+		line, err := sysint(index, "coherency_line_size")
+		if err != nil {
+			return nil, err
+		}
+		// cache size and associativity
+		// are not exposed on every platform
+		// so treat missing ones as unknown
+		size, err := syssize(index, "size")
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		ways, err := sysint(index, "ways_of_associativity")
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		levels[level] = SysCache{Line: line, Size: size, Ways: ways}
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no cpu caches found inside %q", dir)
+	}
+	// check that caches levels
+	// are sequential from l1
+	keys := make([]int64, 0, len(levels))
+	for level := range levels {
+		keys = append(keys, level)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	caches := make([]SysCache, 0, len(keys))
+	for i, level := range keys {
+		if level != int64(i+1) {
+			return nil, fmt.Errorf("cpu cache level %d is missing inside %q", i+1, dir)
+		}
+		caches = append(caches, levels[level])
+	}
+	return caches, nil
+}
+
+// sysread reads trimmed sysfs attribute
+func sysread(index, attr string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(index, attr))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// sysint reads sysfs integer attribute
+func sysint(index, attr string) (int64, error) {
+	val, err := sysread(index, attr)
+	if err != nil {
+		return 0, err
+	}
+	num, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse %q %v", filepath.Join(index, attr), err)
+	}
+	return num, nil
+}
+
+// syssize reads sysfs size attribute
+// with optional K, M or G suffix
+func syssize(index, attr string) (int64, error) {
+	val, err := sysread(index, attr)
+	if err != nil {
+		return 0, err
+	}
+	mult := int64(1)
+	switch {
+	case strings.HasSuffix(val, "K"):
+		mult, val = 1<<10, strings.TrimSuffix(val, "K")
+	case strings.HasSuffix(val, "M"):
+		mult, val = 1<<20, strings.TrimSuffix(val, "M")
+	case strings.HasSuffix(val, "G"):
+		mult, val = 1<<30, strings.TrimSuffix(val, "G")
+	}
+	num, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse %q %v", filepath.Join(index, attr), err)
+	}
+	return num * mult, nil
+}
@@ -0,0 +1,134 @@
+package typepkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/tests"
+)
+
+func TestReadSysCaches(t *testing.T) {
+	// prepare
+	cache := func(root string, index string, attrs map[string]string) {
+		dir := filepath.Join(root, "devices", "system", "cpu", "cpu0", "cache", index)
+		if err := os.MkdirAll(dir, 0700); !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+		}
+		for attr, val := range attrs {
+			if err := os.WriteFile(filepath.Join(dir, attr), []byte(val+"\n"), 0600); !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+		}
+	}
+	empty := t.TempDir()
+	gap := t.TempDir()
+	cache(gap, "index0", map[string]string{
+		"level":                 "1",
+		"type":                  "Data",
+		"coherency_line_size":   "64",
+		"size":                  "32K",
+		"ways_of_associativity": "8",
+	})
+	cache(gap, "index1", map[string]string{
+		"level":                 "3",
+		"type":                  "Unified",
+		"coherency_line_size":   "64",
+		"size":                  "8M",
+		"ways_of_associativity": "16",
+	})
+	invalid := t.TempDir()
+	cache(invalid, "index0", map[string]string{
+		"level":                 "1",
+		"type":                  "Data",
+		"coherency_line_size":   "64",
+		"size":                  "test",
+		"ways_of_associativity": "8",
+	})
+	partial := t.TempDir()
+	cache(partial, "index0", map[string]string{
+		"level": "1",
+		"type":  "Data",
+	})
+	minimal := t.TempDir()
+	cache(minimal, "index0", map[string]string{
+		"level":               "1",
+		"type":                "Data",
+		"coherency_line_size": "64",
+	})
+	cache(minimal, "index1", map[string]string{
+		"level":                 "2",
+		"type":                  "Unified",
+		"coherency_line_size":   "128",
+		"ways_of_associativity": "16",
+	})
+	table := map[string]struct {
+		root   string
+		caches []SysCache
+		err    error
+	}{
+		"valid sysfs should return expected caches": {
+			root: filepath.Join(tests.Gopium, "tests", "data", "sysfs"),
+			caches: []SysCache{
+				{Line: 64, Size: 48 << 10, Ways: 12},
+				{Line: 64, Size: 2048 << 10, Ways: 16},
+				{Line: 64, Size: 36 << 20, Ways: 12},
+			},
+		},
+		"empty sysfs should return error": {
+			root: empty,
+			err: fmt.Errorf(
+				"no cpu caches found inside %q",
+				filepath.Join(empty, "devices", "system", "cpu", "cpu0", "cache"),
+			),
+		},
+		"sysfs with missing cache level should return error": {
+			root: gap,
+			err: fmt.Errorf(
+				"cpu cache level 2 is missing inside %q",
+				filepath.Join(gap, "devices", "system", "cpu", "cpu0", "cache"),
+			),
+		},
+		"sysfs with invalid cache size should return error": {
+			root: invalid,
+			err: fmt.Errorf(
+				"can't parse %q strconv.ParseInt: parsing \"test\": invalid syntax",
+				filepath.Join(invalid, "devices", "system", "cpu", "cpu0", "cache", "index0", "size"),
+			),
+		},
+		"sysfs without caches sizes and associativities should return expected caches": {
+			root: minimal,
+			caches: []SysCache{
+				{Line: 64},
+				{Line: 128, Ways: 16},
+			},
+		},
+		"sysfs with missing cache attribute should return error": {
+			root: partial,
+			err: fmt.Errorf(
+				"open %s: no such file or directory",
+				filepath.Join(partial, "devices", "system", "cpu", "cpu0", "cache", "index0", "coherency_line_size"),
+			),
+		},
+		"invalid sysfs root pattern should return error": {
+			root: "[",
+			err:  errors.New("syntax error in pattern"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			caches, err := ReadSysCaches(tcase.root)
+			// check
+			if !reflect.DeepEqual(caches, tcase.caches) {
+				t.Errorf("actual %v doesn't equal to expected %v", caches, tcase.caches)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
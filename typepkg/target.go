@@ -0,0 +1,96 @@
+package typepkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+)
+
+// Target defines custom target platform description
+// for architectures unknown to types.SizesFor
+// (like tinygo targets or experimental archs)
+// with word size, max alignment, basic types
+// sizes and alignments overrides and cache lines sizes
+type Target struct {
+	Caches []int64                `json:"caches" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Basics map[string]TargetBasic `json:"basics" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Word   int64                  `json:"word_size" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Align  int64                  `json:"max_align" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [16]byte               `json:"-" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// TargetBasic defines custom target platform
+// basic type size and alignment override
+type TargetBasic struct {
+	Size  int64 `json:"size" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Align int64 `json:"align" gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// ReadTarget reads json encoded custom target
+// platform description like
+// {"word_size": 4, "max_align": 4, "basics": {"int64": {"size": 8, "align": 4}}, "caches": [32]}
+// unknown description fields are rejected
+func ReadTarget(r io.Reader) (Target, error) {
+	var t Target
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return Target{}, err
+	}
+	return t, nil
+}
+
+// NewMavenTarget creates instance of MavenGoTypes
+// from custom target platform description,
+// target cache lines sizes are used if defined
+// otherwise provided caches are used instead,
+// interference sizes are typical cpu cache line size
+func NewMavenTarget(t Target, caches ...int64) (MavenGoTypes, error) {
+	// check that target word and alignment are valid
+	if t.Word <= 0 || t.Align <= 0 {
+		return MavenGoTypes{}, fmt.Errorf("unsuported target word size %d max align %d combination", t.Word, t.Align)
+	}
+	// go through all basic types overrides
+	// and resolve them to basic types kinds
+	basics := make(map[types.BasicKind]TargetBasic, len(t.Basics))
+	for name, basic := range t.Basics {
+		kind, ok := basickind(name)
+		if !ok {
+			return MavenGoTypes{}, fmt.Errorf("unsuported target basic type %q", name)
+		}
+		if basic.Size < 0 || basic.Align <= 0 {
+			return MavenGoTypes{}, fmt.Errorf("unsuported target basic type %q size %d align %d combination", name, basic.Size, basic.Align)
+		}
+		basics[kind] = basic
+	}
+	// go through all target or passed caches
+	// and fill them to cache map
+	if len(t.Caches) > 0 {
+		caches = t.Caches
+	}
+	cm := make(map[uint]int64, len(caches))
+	for i, cache := range caches {
+		cm[uint(i+1)] = cache
+	}
+	return MavenGoTypes{
+		sizes:        stdsizes{tsizes{basics: basics, word: t.Word, align: t.Align}},
+		caches:       cm,
+		destructive:  64,
+		constructive: 64,
+	}, nil
+}
+
+// basickind resolves basic type kind
+// by its universe name or alias name
+func basickind(name string) (types.BasicKind, bool) {
+	if name == "unsafe.Pointer" {
+		return types.UnsafePointer, true
+	}
+	if tn, ok := types.Universe.Lookup(name).(*types.TypeName); ok {
+		if basic, ok := tn.Type().(*types.Basic); ok {
+			return basic.Kind(), true
+		}
+	}
+	return types.Invalid, false
+}
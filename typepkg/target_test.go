@@ -0,0 +1,269 @@
+package typepkg
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadTarget(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		data   string
+		target Target
+		err    error
+	}{
+		"valid target should return expected target": {
+			data: `{"word_size": 2, "max_align": 1, "basics": {"int64": {"size": 8, "align": 2}}, "caches": [32]}`,
+			target: Target{
+				Caches: []int64{32},
+				Basics: map[string]TargetBasic{
+					"int64": {Size: 8, Align: 2},
+				},
+				Word:  2,
+				Align: 1,
+			},
+		},
+		"target with unknown field should return error": {
+			data: `{"word_size": 2, "max_align": 1, "cache": [32]}`,
+			err:  errors.New(`json: unknown field "cache"`),
+		},
+		"target with unknown basic field should return error": {
+			data: `{"word_size": 2, "max_align": 1, "basics": {"int64": {"size": 8, "alignment": 2}}}`,
+			err:  errors.New(`json: unknown field "alignment"`),
+		},
+		"invalid target should return error": {
+			data: `{"word_size": "2"}`,
+			err:  errors.New("json: cannot unmarshal string into Go struct field Target.word_size of type int64"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			target, err := ReadTarget(strings.NewReader(tcase.data))
+			// check
+			if !reflect.DeepEqual(target, tcase.target) {
+				t.Errorf("actual %v doesn't equal to expected %v", target, tcase.target)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestNewMavenTarget(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		target Target
+		caches []int64
+		maven  MavenGoTypes
+		err    error
+	}{
+		"invalid word size should return error": {
+			target: Target{Align: 4},
+			err:    errors.New("unsuported target word size 0 max align 4 combination"),
+		},
+		"invalid basic type should return error": {
+			target: Target{
+				Basics: map[string]TargetBasic{
+					"error": {Size: 8, Align: 8},
+				},
+				Word:  4,
+				Align: 4,
+			},
+			err: errors.New(`unsuported target basic type "error"`),
+		},
+		"invalid basic type alignment should return error": {
+			target: Target{
+				Basics: map[string]TargetBasic{
+					"int64": {Size: 8},
+				},
+				Word:  4,
+				Align: 4,
+			},
+			err: errors.New(`unsuported target basic type "int64" size 8 align 0 combination`),
+		},
+		"valid target should return expected maven": {
+			target: Target{
+				Caches: []int64{32, 64},
+				Basics: map[string]TargetBasic{
+					"byte":           {Size: 1, Align: 1},
+					"unsafe.Pointer": {Size: 2, Align: 2},
+				},
+				Word:  2,
+				Align: 1,
+			},
+			caches: []int64{128},
+			maven: MavenGoTypes{
+				sizes: stdsizes{tsizes{
+					basics: map[types.BasicKind]TargetBasic{
+						types.Uint8:         {Size: 1, Align: 1},
+						types.UnsafePointer: {Size: 2, Align: 2},
+					},
+					word:  2,
+					align: 1,
+				}},
+				caches:       map[uint]int64{1: 32, 2: 64},
+				destructive:  64,
+				constructive: 64,
+			},
+		},
+		"valid target without caches should return expected maven": {
+			target: Target{
+				Word:  4,
+				Align: 4,
+			},
+			caches: []int64{128},
+			maven: MavenGoTypes{
+				sizes: stdsizes{tsizes{
+					basics: map[types.BasicKind]TargetBasic{},
+					word:   4,
+					align:  4,
+				}},
+				caches:       map[uint]int64{1: 128},
+				destructive:  64,
+				constructive: 64,
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			maven, err := NewMavenTarget(tcase.target, tcase.caches...)
+			// check
+			if !reflect.DeepEqual(maven, tcase.maven) {
+				t.Errorf("actual %v doesn't equal to expected %v", maven, tcase.maven)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestMavenTargetExposer(t *testing.T) {
+	// prepare
+	amd64, err := NewMavenGoTypes("gc", "amd64")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	tamd64, err := NewMavenTarget(Target{Word: 8, Align: 8})
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	i386, err := NewMavenGoTypes("gc", "386")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	t386, err := NewMavenTarget(Target{Word: 4, Align: 4})
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	tavr, err := NewMavenTarget(Target{
+		Basics: map[string]TargetBasic{
+			"float64": {Size: 4, Align: 1},
+		},
+		Word:  2,
+		Align: 1,
+	})
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	st := types.NewStruct(
+		[]*types.Var{
+			types.NewVar(token.Pos(0), nil, "a", types.Typ[types.Int8]),
+			types.NewVar(token.Pos(0), nil, "b", types.Typ[types.Float64]),
+			types.NewVar(token.Pos(0), nil, "c", types.Typ[types.String]),
+			types.NewVar(token.Pos(0), nil, "d", types.NewArray(types.Typ[types.Int16], 3)),
+			types.NewVar(token.Pos(0), nil, "e", types.Typ[types.Complex64]),
+			types.NewVar(token.Pos(0), nil, "f", types.NewSlice(types.Typ[types.Int64])),
+			types.NewVar(token.Pos(0), nil, "g", types.NewInterfaceType(nil, nil)),
+			types.NewVar(token.Pos(0), nil, "h", types.Typ[types.Bool]),
+		},
+		nil,
+	)
+	zst := types.NewStruct(
+		[]*types.Var{
+			types.NewVar(token.Pos(0), nil, "a", types.Typ[types.Int32]),
+			types.NewVar(token.Pos(0), nil, "b", types.NewStruct(nil, nil)),
+		},
+		nil,
+	)
+	table := map[string]struct {
+		maven  MavenGoTypes
+		tp     types.Type
+		word   int64
+		align  int64
+		size   int64
+		talign int64
+		ptr    int64
+	}{
+		"amd64 like target should return the same results as gc/amd64": {
+			maven:  tamd64,
+			tp:     st,
+			word:   amd64.SysWord(),
+			align:  amd64.SysAlign(),
+			size:   amd64.Size(st),
+			talign: amd64.Align(st),
+			ptr:    amd64.Ptr(st),
+		},
+		"386 like target should return the same results as gc/386 on trailing zero size field": {
+			maven:  t386,
+			tp:     zst,
+			word:   i386.SysWord(),
+			align:  i386.SysAlign(),
+			size:   i386.Size(zst),
+			talign: i386.Align(zst),
+			ptr:    i386.Ptr(zst),
+		},
+		"386 like target should pad trailing zero size field": {
+			maven:  t386,
+			tp:     zst,
+			word:   4,
+			align:  4,
+			size:   8,
+			talign: 4,
+			ptr:    0,
+		},
+		"avr like target should return expected results": {
+			maven:  tavr,
+			tp:     st,
+			word:   2,
+			align:  1,
+			size:   1 + 4 + 4 + 6 + 8 + 6 + 4 + 1,
+			talign: 1,
+			ptr:    1 + 4 + 4 + 6 + 8 + 6 + 4,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			word := tcase.maven.SysWord()
+			align := tcase.maven.SysAlign()
+			size := tcase.maven.Size(tcase.tp)
+			talign := tcase.maven.Align(tcase.tp)
+			ptr := tcase.maven.Ptr(tcase.tp)
+			// check
+			if !reflect.DeepEqual(word, tcase.word) {
+				t.Errorf("actual %v doesn't equal to expected %v", word, tcase.word)
+			}
+			if !reflect.DeepEqual(align, tcase.align) {
+				t.Errorf("actual %v doesn't equal to expected %v", align, tcase.align)
+			}
+			if !reflect.DeepEqual(size, tcase.size) {
+				t.Errorf("actual %v doesn't equal to expected %v", size, tcase.size)
+			}
+			if !reflect.DeepEqual(talign, tcase.talign) {
+				t.Errorf("actual %v doesn't equal to expected %v", talign, tcase.talign)
+			}
+			if !reflect.DeepEqual(ptr, tcase.ptr) {
+				t.Errorf("actual %v doesn't equal to expected %v", ptr, tcase.ptr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package walkers
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/1pkg/gopium/gopium"
 )
@@ -9,10 +10,14 @@ import (
 // list of registered types walkers
 const (
 	// wast walkers
-	AstStd    gopium.WalkerName = "ast_std"
-	AstGo     gopium.WalkerName = "ast_go"
-	AstGoTree gopium.WalkerName = "ast_go_tree"
-	AstGopium gopium.WalkerName = "ast_gopium"
+	AstStd       gopium.WalkerName = "ast_std"
+	AstGo        gopium.WalkerName = "ast_go"
+	AstGoTree    gopium.WalkerName = "ast_go_tree"
+	AstGopium    gopium.WalkerName = "ast_gopium"
+	AstPatch     gopium.WalkerName = "ast_patch"
+	AstPatchFile gopium.WalkerName = "ast_patch_file"
+	// warch walkers
+	AstGoArch gopium.WalkerName = "ast_go_arch"
 	// wout walkers
 	FileJsonb gopium.WalkerName = "file_json"
 	FileXmlb  gopium.WalkerName = "file_xml"
@@ -21,21 +26,71 @@ const (
 	// wdiff walkers
 	SizeAlignFileMdt gopium.WalkerName = "size_align_file_md_table"
 	FieldsFileHtmlt  gopium.WalkerName = "fields_file_html_table"
+	// wmatrix walkers
+	SizeAlignMatrixFileMdt   gopium.WalkerName = "size_align_matrix_file_md_table"
+	SizeAlignMatrixFileHtmlt gopium.WalkerName = "size_align_matrix_file_html_table"
+	SizeAlignMatrixFileJsonb gopium.WalkerName = "size_align_matrix_file_json"
+	// wcheck walkers
+	Check gopium.WalkerName = "check"
 )
 
+// PerFile checks if named walker keeps results
+// of each package file separately, so its results
+// could be merged per file across several platforms
+func PerFile(name gopium.WalkerName) bool {
+	switch name {
+	case AstStd, AstGo, AstGoTree, AstGopium, AstPatch, Check:
+		return true
+	default:
+		return false
+	}
+}
+
 // Builder defines types gopium.WalkerBuilder implementation
 // that uses parser and exposer to pass it to related walkers
+// and target architectures to pass it to warch walkers
+// and strategies names with mavens options
+// to rebuild them in wmatrix walkers
+// and warnings writer to pass it to all walkers
 type Builder struct {
-	Parser  gopium.Parser  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Exposer gopium.Exposer `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Printer gopium.Printer `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Deep    bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	Bref    bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [14]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 48 bytes; - 🌺 gopium @1pkg
+	Parser     gopium.Parser         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Exposer    gopium.Exposer        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Printer    gopium.Printer        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Targets    []Target              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Strategies []gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Mavens     MavenOptions          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Warn       io.Writer             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Workers    int                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Deep       bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Bref       bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_          [6]byte               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; struct ptr scan size: 176 bytes; - 🌺 gopium @1pkg
 
 // Build Builder implementation
 func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
+	// in case parser is able to expand
+	// itself to multiple packages parsers
+	// wrap named walker with walkers pool
+	if exp, ok := b.Parser.(gopium.Expander); ok {
+		// check that named walker exists
+		if _, err := b.walker(name); err != nil {
+			return nil, err
+		}
+		return wpool{
+			expander: exp,
+			build: func(p gopium.Parser) (gopium.Walker, error) {
+				wb := b
+				wb.Parser = p
+				return wb.walker(name)
+			},
+			workers: b.Workers,
+		}, nil
+	}
+	return b.walker(name)
+}
+
+// walker builds single package walker by its name
+func (b Builder) walker(name gopium.WalkerName) (gopium.Walker, error) {
 	switch name {
 	// wast walkers
 	case AstStd:
@@ -43,6 +98,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Parser,
 			b.Exposer,
 			b.Printer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -51,6 +107,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Parser,
 			b.Exposer,
 			b.Printer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -59,6 +116,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Parser,
 			b.Exposer,
 			b.Printer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -67,6 +125,40 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Parser,
 			b.Exposer,
 			b.Printer,
+			b.Warn,
+			b.Deep,
+			b.Bref,
+		), nil
+	case AstPatch:
+		return astpatch.With(
+			b.Parser,
+			b.Exposer,
+			b.Printer,
+			b.Warn,
+			b.Deep,
+			b.Bref,
+		), nil
+	case AstPatchFile:
+		return astpatchfile.With(
+			b.Parser,
+			b.Exposer,
+			b.Printer,
+			b.Warn,
+			b.Deep,
+			b.Bref,
+		), nil
+	// warch walkers
+	case AstGoArch:
+		// warch walker requires
+		// at least one target architecture
+		if len(b.Targets) == 0 {
+			return nil, fmt.Errorf("walker %q requires target architectures", name)
+		}
+		return astgoarch.With(
+			b.Parser,
+			b.Printer,
+			b.Targets,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -75,6 +167,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return filejson.With(
 			b.Parser,
 			b.Exposer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -82,6 +175,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return filexml.With(
 			b.Parser,
 			b.Exposer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -89,6 +183,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return filecsv.With(
 			b.Parser,
 			b.Exposer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -96,6 +191,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return filemdt.With(
 			b.Parser,
 			b.Exposer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -104,6 +200,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return safilemdt.With(
 			b.Parser,
 			b.Exposer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -111,6 +208,44 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 		return ffilehtml.With(
 			b.Parser,
 			b.Exposer,
+			b.Warn,
+			b.Deep,
+			b.Bref,
+		), nil
+	// wmatrix walkers
+	case SizeAlignMatrixFileMdt:
+		return samatrixfilemdt.With(
+			b.Parser,
+			b.Mavens,
+			b.Strategies,
+			b.Warn,
+			b.Deep,
+			b.Bref,
+		), nil
+	case SizeAlignMatrixFileHtmlt:
+		return samatrixfilehtml.With(
+			b.Parser,
+			b.Mavens,
+			b.Strategies,
+			b.Warn,
+			b.Deep,
+			b.Bref,
+		), nil
+	case SizeAlignMatrixFileJsonb:
+		return samatrixfilejson.With(
+			b.Parser,
+			b.Mavens,
+			b.Strategies,
+			b.Warn,
+			b.Deep,
+			b.Bref,
+		), nil
+	// wcheck walkers
+	case Check:
+		return checkstd.With(
+			b.Parser,
+			b.Exposer,
+			b.Warn,
 			b.Deep,
 			b.Bref,
 		), nil
@@ -1,6 +1,7 @@
 package walkers
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"testing"
@@ -14,6 +15,8 @@ func TestBuilder(t *testing.T) {
 	b := Builder{
 		Parser:  mocks.Parser{},
 		Exposer: mocks.Maven{},
+		Mavens:  MavenOptions{Caches: []int64{32}},
+		Warn:    &bytes.Buffer{},
 		Deep:    true,
 		Bref:    true,
 	}
@@ -29,6 +32,7 @@ func TestBuilder(t *testing.T) {
 				b.Parser,
 				b.Exposer,
 				b.Printer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -39,6 +43,7 @@ func TestBuilder(t *testing.T) {
 				b.Parser,
 				b.Exposer,
 				b.Printer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -49,6 +54,7 @@ func TestBuilder(t *testing.T) {
 				b.Parser,
 				b.Exposer,
 				b.Printer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -59,16 +65,45 @@ func TestBuilder(t *testing.T) {
 				b.Parser,
 				b.Exposer,
 				b.Printer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
 		},
+		"`ast_patch` name should return expected walker": {
+			name: AstPatch,
+			w: astpatch.With(
+				b.Parser,
+				b.Exposer,
+				b.Printer,
+				b.Warn,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		"`ast_patch_file` name should return expected walker": {
+			name: AstPatchFile,
+			w: astpatchfile.With(
+				b.Parser,
+				b.Exposer,
+				b.Printer,
+				b.Warn,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		// warch walkers
+		"`ast_go_arch` name without target architectures should return builder error": {
+			name: AstGoArch,
+			err:  fmt.Errorf(`walker "ast_go_arch" requires target architectures`),
+		},
 		// wout walkers
 		"`file_json` name should return expected walker": {
 			name: FileJsonb,
 			w: filejson.With(
 				b.Parser,
 				b.Exposer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -78,6 +113,7 @@ func TestBuilder(t *testing.T) {
 			w: filexml.With(
 				b.Parser,
 				b.Exposer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -87,6 +123,7 @@ func TestBuilder(t *testing.T) {
 			w: filecsv.With(
 				b.Parser,
 				b.Exposer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -96,6 +133,7 @@ func TestBuilder(t *testing.T) {
 			w: filemdt.With(
 				b.Parser,
 				b.Exposer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -106,6 +144,7 @@ func TestBuilder(t *testing.T) {
 			w: safilemdt.With(
 				b.Parser,
 				b.Exposer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -115,6 +154,52 @@ func TestBuilder(t *testing.T) {
 			w: ffilehtml.With(
 				b.Parser,
 				b.Exposer,
+				b.Warn,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		// wmatrix walkers
+		"`size_align_matrix_file_md_table` name should return expected walker": {
+			name: SizeAlignMatrixFileMdt,
+			w: samatrixfilemdt.With(
+				b.Parser,
+				b.Mavens,
+				b.Strategies,
+				b.Warn,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		"`size_align_matrix_file_html_table` name should return expected walker": {
+			name: SizeAlignMatrixFileHtmlt,
+			w: samatrixfilehtml.With(
+				b.Parser,
+				b.Mavens,
+				b.Strategies,
+				b.Warn,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		"`size_align_matrix_file_json` name should return expected walker": {
+			name: SizeAlignMatrixFileJsonb,
+			w: samatrixfilejson.With(
+				b.Parser,
+				b.Mavens,
+				b.Strategies,
+				b.Warn,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		// wcheck walkers
+		"`check` name should return expected walker": {
+			name: Check,
+			w: checkstd.With(
+				b.Parser,
+				b.Exposer,
+				b.Warn,
 				b.Deep,
 				b.Bref,
 			),
@@ -144,3 +229,43 @@ func TestBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestPerFile(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		name    gopium.WalkerName
+		perfile bool
+	}{
+		"`ast_go` walker should keep results per file": {
+			name:    AstGo,
+			perfile: true,
+		},
+		"`ast_patch` walker should keep results per file": {
+			name:    AstPatch,
+			perfile: true,
+		},
+		"`check` walker should keep results per file": {
+			name:    Check,
+			perfile: true,
+		},
+		"`ast_patch_file` walker should not keep results per file": {
+			name: AstPatchFile,
+		},
+		"`file_json` walker should not keep results per file": {
+			name: FileJsonb,
+		},
+		"invalid walker should not keep results per file": {
+			name: "test",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			perfile := PerFile(tcase.name)
+			// check
+			if !reflect.DeepEqual(perfile, tcase.perfile) {
+				t.Errorf("actual %v doesn't equal to expected %v", perfile, tcase.perfile)
+			}
+		})
+	}
+}
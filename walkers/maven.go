@@ -1,11 +1,17 @@
 package walkers
 
 import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
 	"go/types"
+	"io"
 	"sync"
 
 	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/typepkg"
 )
 
 // ptrsizealign defines data transfer
@@ -24,10 +30,11 @@ type ptrsizealign struct {
 type maven struct {
 	exp   gopium.Exposer         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	loc   gopium.Locator         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	warn  io.Writer              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	ref   *collections.Reference `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	store sync.Map               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_     [48]byte               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
+	_     [24]byte               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 96 bytes; - 🌺 gopium @1pkg
 
 // has defines struct store id helper
 // that uses locator to build id
@@ -48,6 +55,54 @@ func (m *maven) has(tn *types.TypeName) (id string, loc string, ok bool) {
 	return id, loc, false
 }
 
+// hasanon defines anonymous struct store id helper
+// that uses locator to build id
+// for a synthetic anonymous structure type spec
+// and check that builded id has not been stored already
+func (m *maven) hasanon(ts *ast.TypeSpec) (id string, loc string, ok bool) {
+	// build id for the structure
+	id = typepkg.AnonymousID(m.loc, ts.Pos())
+	// build loc for the structure
+	loc = m.loc.Loc(ts.Pos())
+	// in case id of structure
+	// has been already stored
+	if _, ok := m.store.Load(id); ok {
+		return id, loc, true
+	}
+	// mark id of structure as stored
+	m.store.Store(id, struct{}{})
+	return id, loc, false
+}
+
+// anons defines anonymous structs helper
+// that goes through provided type and calls
+// provided callback on all nested anonymous
+// structs known by locator with their type specs
+// note: named types aren't traversed
+// as they are visited separately
+func (m *maven) anons(t types.Type, f func(*ast.TypeSpec, *types.Struct)) {
+	switch tp := t.(type) {
+	case *types.Pointer:
+		m.anons(tp.Elem(), f)
+	case *types.Slice:
+		m.anons(tp.Elem(), f)
+	case *types.Array:
+		m.anons(tp.Elem(), f)
+	case *types.Chan:
+		m.anons(tp.Elem(), f)
+	case *types.Map:
+		m.anons(tp.Key(), f)
+		m.anons(tp.Elem(), f)
+	case *types.Struct:
+		if ts, ok := m.loc.Anonymous(tp, nil); ok {
+			f(ts, tp)
+		}
+		for i := 0; i < tp.NumFields(); i++ {
+			m.anons(tp.Field(i).Type(), f)
+		}
+	}
+}
+
 // enum defines struct enumerating converting helper
 // that goes through all structure fields
 // and uses exposer to expose field DTO
@@ -78,7 +133,65 @@ func (m *maven) enum(name string, st *types.Struct) gopium.Struct {
 			Embedded: f.Embedded(),
 		})
 	}
-	return r
+	// calculate fields offsets and paddings
+	return collections.OffsetStruct(r)
+}
+
+// port defines struct platforms helper
+// that in case exposer is portable
+// converts struct to inner gopium format
+// on each exposer target platform and returns
+// context that carries all struct platforms variants
+// note: struct variant on exposer own platform
+// is original struct itself, while variants on
+// other platforms don't use reference sizes
+func (m *maven) port(ctx context.Context, o gopium.Struct, st *types.Struct) context.Context {
+	// skip non portable exposers
+	p, ok := m.exp.(gopium.Portable)
+	if !ok {
+		return ctx
+	}
+	names := p.Platforms()
+	platforms := make([]gopium.Platform, 0, len(names))
+	for i, name := range names {
+		// use original struct
+		// for own exposer platform
+		if i == 0 {
+			platforms = append(platforms, gopium.Platform{Name: name, Struct: o})
+			continue
+		}
+		// skip unknown platforms
+		exp, ok := p.Platform(name)
+		if !ok {
+			continue
+		}
+		// convert struct with platform maven
+		pm := &maven{exp: exp, loc: m.loc, warn: m.warn}
+		platforms = append(platforms, gopium.Platform{Name: name, Struct: pm.enum(o.Name, st)})
+	}
+	return gopium.WithPlatforms(ctx, platforms)
+}
+
+// access defines struct fields access helper
+// that returns context that carries
+// struct fields co-access graph,
+// struct fields profile hotness and
+// struct atomic fields
+// in case locator has them for the struct
+func (m *maven) access(ctx context.Context, p token.Pos) context.Context {
+	// skip structs without co-access graph
+	if ca, ok := m.loc.CoAccess(p); ok {
+		ctx = gopium.WithCoAccess(ctx, ca)
+	}
+	// skip structs without fields hotness
+	if h, ok := m.loc.Hotness(p, "", 0); ok {
+		ctx = gopium.WithHotness(ctx, h)
+	}
+	// skip structs without atomic fields
+	if a, ok := m.loc.Atomic(p, 0); ok {
+		ctx = gopium.WithAtomics(ctx, a)
+	}
+	return ctx
 }
 
 // refsa defines ptr and size and align getter
@@ -95,8 +208,8 @@ func (m *maven) refpsa(t types.Type) ptrsizealign {
 			align: m.exp.Align(t),
 		}
 	}
-	// for refsize only named structures
-	// and arrays should be calculated
+	// for refsize only named and anonymous
+	// structures and arrays should be calculated
 	// not with default exposer size
 	switch tp := t.(type) {
 	case *types.Array:
@@ -115,12 +228,29 @@ func (m *maven) refpsa(t types.Type) ptrsizealign {
 		if _, ok := tp.Underlying().(*types.Struct); !ok {
 			break
 		}
+		// in case it's a generic struct instance skip it
+		// as generic struct ref doesn't match instance sizes
+		if tp.TypeArgs().Len() > 0 {
+			break
+		}
 		// get id for named structures
 		id := m.loc.ID(tp.Obj().Pos())
 		// get size of the structure from ref
 		if sa, ok := m.ref.Get(id).(ptrsizealign); ok {
 			return sa
 		}
+	case *types.Struct:
+		// in case it's not an anonymous struct skip it
+		ts, ok := m.loc.Anonymous(tp, nil)
+		if !ok {
+			break
+		}
+		// get id for anonymous structures
+		id := typepkg.AnonymousID(m.loc, ts.Pos())
+		// get size of the structure from ref
+		if sa, ok := m.ref.Get(id).(ptrsizealign); ok {
+			return sa
+		}
 	}
 	// just use default exposer size
 	return ptrsizealign{
@@ -145,3 +275,36 @@ func (m *maven) refst(name string) func(gopium.Struct) {
 		m.ref.Set(name, ptrsizealign{size: stsize, align: stalign, ptr: ptrsize})
 	}
 }
+
+// pin defines pinned struct helper
+// that checks if structure layout is relied on
+// and if result structure fields were moved
+// then it warns about it with pinning reasons
+// and returns original structure back
+// otherwise it returns result structure back
+func (m *maven) pin(p token.Pos, o gopium.Struct, r gopium.Struct) gopium.Struct {
+	// skip structs that aren't pinned
+	reason, ok := m.loc.Pin(p, "")
+	if !ok {
+		return r
+	}
+	// skip structs with unmoved fields
+	moved := len(o.Fields) != len(r.Fields)
+	for i := 0; !moved && i < len(o.Fields); i++ {
+		moved = o.Fields[i].Name != r.Fields[i].Name || o.Fields[i].Type != r.Fields[i].Type
+	}
+	if !moved {
+		return r
+	}
+	// warn about pinned struct
+	// note: warn is expected to be
+	// safe for concurrent writes
+	fmt.Fprintf(
+		m.warn,
+		"%s: struct %s is pinned by %s usage, its fields won't be changed\n",
+		m.loc.Root().Position(p),
+		o.Name,
+		reason,
+	)
+	return o
+}
@@ -1,6 +1,8 @@
 package walkers
 
 import (
+	"bytes"
+	"context"
 	"go/token"
 	"go/types"
 	"reflect"
@@ -9,6 +11,7 @@ import (
 	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
 	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
 )
 
 func TestMavenHas(t *testing.T) {
@@ -142,18 +145,20 @@ func TestMavenEnum(t *testing.T) {
 						Ptr:   16,
 					},
 					{
-						Name:  "b",
-						Type:  "string",
-						Size:  16,
-						Align: 8,
-						Ptr:   16,
+						Name:   "b",
+						Type:   "string",
+						Size:   16,
+						Align:  8,
+						Ptr:    16,
+						Offset: 16,
 					},
 					{
-						Name:  "c",
-						Type:  "string",
-						Size:  16,
-						Align: 8,
-						Ptr:   16,
+						Name:   "c",
+						Type:   "string",
+						Size:   16,
+						Align:  8,
+						Ptr:    16,
+						Offset: 32,
 					},
 				},
 			},
@@ -187,6 +192,140 @@ func TestMavenEnum(t *testing.T) {
 	}
 }
 
+func TestMavenPort(t *testing.T) {
+	// prepare
+	mamd64, err := typepkg.NewMavenGoTypes("gc", "amd64")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	mport, err := typepkg.NewMavenPortable("gc", "amd64", []string{"386"})
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+	}
+	st := types.NewStruct(
+		[]*types.Var{
+			types.NewVar(token.Pos(0), nil, "a", types.NewArray(types.Typ[types.Int32], 3)),
+			types.NewVar(token.Pos(0), nil, "b", types.Typ[types.Int64]),
+		},
+		nil,
+	)
+	o := gopium.Struct{Name: "test"}
+	table := map[string]struct {
+		exp       gopium.Exposer
+		platforms []gopium.Platform
+	}{
+		"non portable exposer should return no platforms": {
+			exp: mamd64,
+		},
+		"portable exposer should return expected platforms": {
+			exp: mport,
+			platforms: []gopium.Platform{
+				{
+					Name:   "amd64",
+					Struct: o,
+				},
+				{
+					Name: "386",
+					Struct: gopium.Struct{
+						Name: "test",
+						Fields: []gopium.Field{
+							{
+								Name:  "a",
+								Type:  "[3]int32",
+								Size:  12,
+								Align: 4,
+							},
+							{
+								Name:   "b",
+								Type:   "int64",
+								Size:   8,
+								Align:  4,
+								Offset: 12,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			m := maven{exp: tcase.exp, loc: mocks.Locator{}}
+			// exec
+			platforms := gopium.Platforms(m.port(context.Background(), o, st))
+			// check
+			if !reflect.DeepEqual(platforms, tcase.platforms) {
+				t.Errorf("actual %v doesn't equal to expected %v", platforms, tcase.platforms)
+			}
+		})
+	}
+}
+
+func TestMavenAccess(t *testing.T) {
+	// prepare
+	m := maven{
+		loc: mocks.Locator{
+			Accs: map[token.Pos]gopium.CoAccess{
+				token.Pos(1): {
+					"a": {"b": 1},
+					"b": {"a": 1},
+				},
+			},
+			Heats: map[token.Pos]gopium.Hotness{
+				token.Pos(1): {"a": 10},
+				token.Pos(3): {"b": 5},
+			},
+			Atoms: map[token.Pos]gopium.Atomics{
+				token.Pos(4): {"c": 64},
+			},
+		},
+	}
+	table := map[string]struct {
+		pos token.Pos
+		ca  gopium.CoAccess
+		h   gopium.Hotness
+		a   gopium.Atomics
+	}{
+		"struct without co-access graph and hotness should return empty graph and hotness": {
+			pos: token.Pos(2),
+		},
+		"struct with co-access graph and hotness should return expected graph and hotness": {
+			pos: token.Pos(1),
+			ca: gopium.CoAccess{
+				"a": {"b": 1},
+				"b": {"a": 1},
+			},
+			h: gopium.Hotness{"a": 10},
+		},
+		"struct with only hotness should return expected hotness": {
+			pos: token.Pos(3),
+			h:   gopium.Hotness{"b": 5},
+		},
+		"struct with only atomic fields should return expected atomic fields": {
+			pos: token.Pos(4),
+			a:   gopium.Atomics{"c": 64},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ctx := m.access(context.Background(), tcase.pos)
+			ca, h, a := gopium.CoAccessed(ctx), gopium.Profiled(ctx), gopium.AtomicAccessed(ctx)
+			// check
+			if !reflect.DeepEqual(ca, tcase.ca) {
+				t.Errorf("actual %v doesn't equal to expected %v", ca, tcase.ca)
+			}
+			if !reflect.DeepEqual(h, tcase.h) {
+				t.Errorf("actual %v doesn't equal to expected %v", h, tcase.h)
+			}
+			if !reflect.DeepEqual(a, tcase.a) {
+				t.Errorf("actual %v doesn't equal to expected %v", a, tcase.a)
+			}
+		})
+	}
+}
+
 func TestMavenRefsa(t *testing.T) {
 	// prepare
 	ref := collections.NewReference(true)
@@ -342,3 +481,105 @@ func TestMavenRefst(t *testing.T) {
 		})
 	}
 }
+
+func TestMavenPin(t *testing.T) {
+	// prepare
+	m := maven{
+		loc: mocks.Locator{
+			Pins: map[token.Pos]string{
+				token.Pos(1): "reflect",
+			},
+		},
+	}
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{Name: "a", Type: "bool"},
+			{Name: "b", Type: "int64"},
+		},
+	}
+	table := map[string]struct {
+		tn   *types.TypeName
+		r    gopium.Struct
+		st   gopium.Struct
+		warn string
+	}{
+		"unpinned struct should return result struct": {
+			tn: types.NewTypeName(token.Pos(10), nil, "test", types.Typ[types.String]),
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "b", Type: "int64"},
+					{Name: "a", Type: "bool"},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "b", Type: "int64"},
+					{Name: "a", Type: "bool"},
+				},
+			},
+		},
+		"pinned struct with unmoved fields should return result struct": {
+			tn: types.NewTypeName(token.Pos(1), nil, "test", types.Typ[types.String]),
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// test"},
+				Fields: []gopium.Field{
+					{Name: "a", Type: "bool"},
+					{Name: "b", Type: "int64"},
+				},
+			},
+			st: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// test"},
+				Fields: []gopium.Field{
+					{Name: "a", Type: "bool"},
+					{Name: "b", Type: "int64"},
+				},
+			},
+		},
+		"pinned struct with reordered fields should return original struct": {
+			tn: types.NewTypeName(token.Pos(1), nil, "test", types.Typ[types.String]),
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "b", Type: "int64"},
+					{Name: "a", Type: "bool"},
+				},
+			},
+			st:   o,
+			warn: "-: struct test is pinned by reflect usage, its fields won't be changed\n",
+		},
+		"pinned struct with padded fields should return original struct": {
+			tn: types.NewTypeName(token.Pos(1), nil, "test", types.Typ[types.String]),
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{Name: "a", Type: "bool"},
+					{Name: "b", Type: "int64"},
+					{Name: "_", Type: "[8]byte"},
+				},
+			},
+			st:   o,
+			warn: "-: struct test is pinned by reflect usage, its fields won't be changed\n",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			var buf bytes.Buffer
+			m.warn = &buf
+			// exec
+			st := m.pin(tcase.tn.Pos(), o, tcase.r)
+			// check
+			if !reflect.DeepEqual(st, tcase.st) {
+				t.Errorf("actual %v doesn't equal to expected %v", st, tcase.st)
+			}
+			if !reflect.DeepEqual(buf.String(), tcase.warn) {
+				t.Errorf("actual %v doesn't equal to expected %v", buf.String(), tcase.warn)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package walkers
 
 import (
 	"context"
+	"fmt"
+	"go/ast"
 	"go/types"
+	"io"
 	"regexp"
+	"strings"
 	"sync"
 
 	"github.com/1pkg/gopium/collections"
@@ -34,14 +38,15 @@ type govisit func(context.Context, *types.Scope)
 type prepare func() (*maven, context.CancelFunc)
 
 // with helps to create prepare func
-// with exposer, locator and backref
-func with(exp gopium.Exposer, loc gopium.Locator, bref bool) prepare {
+// with exposer, locator, backref
+// and pinned structs warnings writer
+func with(exp gopium.Exposer, loc gopium.Locator, bref bool, warn io.Writer) prepare {
 	return func() (*maven, context.CancelFunc) {
 		// create visiting maven with reference
 		// and return it back,
 		// with ref prune cancelation func
 		ref := collections.NewReference(bref)
-		return &maven{exp: exp, loc: loc, ref: ref}, ref.Prune
+		return &maven{exp: exp, loc: loc, warn: warn, ref: ref}, ref.Prune
 	}
 }
 
@@ -180,7 +185,8 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 		// in case it does and object is
 		// a type name and it's not an alias for struct
 		// then apply strategy to it
-		if tn, ok := s.Lookup(name).(*types.TypeName); ok && !tn.IsAlias() {
+		obj := s.Lookup(name)
+		if tn, ok := obj.(*types.TypeName); ok && !tn.IsAlias() {
 			// if underlying type is struct
 			if st, ok := tn.Type().Underlying().(*types.Struct); ok {
 				// structure's name, id and loc
@@ -191,6 +197,14 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 				if id, loc, ok = m.has(tn); ok {
 					continue
 				}
+				// generic structs are visited only
+				// through their concrete instances
+				// so skip generic structs without them
+				named, ok := tn.Type().(*types.Named)
+				generic := ok && named.TypeParams().Len() > 0
+				if _, ok := m.loc.Instances(tn.Pos(), nil); generic && !ok {
+					continue
+				}
 				// create struct ref notifier
 				notif := m.refst(id)
 				// collect the structure's visiting
@@ -198,11 +212,22 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 				vclos = append(vclos, func() {
 					// decrement visits wait group
 					defer wg.Done()
-					// convert original struct
-					// to inner gopium format
-					o := m.enum(name, st)
-					// apply provided strategy
-					r, err := stg.Apply(ctx, o)
+					var o, r gopium.Struct
+					var err error
+					if generic {
+						// visit all generic struct instances
+						o, r, err = vinst(ctx, tn, id, loc, stg, m, ch)
+					} else {
+						// convert original struct
+						// to inner gopium format
+						o = m.enum(name, st)
+						// apply provided strategy
+						// with struct platforms variants
+						// and fields co-access graph
+						// and keep pinned structs untouched
+						r, err = stg.Apply(m.port(m.access(ctx, tn.Pos()), o, st), o)
+						r = m.pin(tn.Pos(), o, r)
+					}
 					// notify ref with result structure
 					notif(r)
 					// and push results to the chan
@@ -216,6 +241,53 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 				})
 			}
 		}
+		// go through all anonymous structs
+		// nested inside type names and vars types
+		// and apply strategy to them as well
+		var t types.Type
+		switch obj := obj.(type) {
+		case *types.TypeName:
+			if !obj.IsAlias() {
+				t = obj.Type().Underlying()
+			}
+		case *types.Var:
+			t = obj.Type()
+		}
+		m.anons(t, func(ts *ast.TypeSpec, st *types.Struct) {
+			// in case id of anonymous structure
+			// has been already visited
+			id, loc, ok := m.hasanon(ts)
+			if ok {
+				return
+			}
+			// create struct ref notifier
+			notif := m.refst(id)
+			// collect the anonymous structure's visiting
+			// closure that applies strategy to it
+			vclos = append(vclos, func() {
+				// decrement visits wait group
+				defer wg.Done()
+				// convert original struct
+				// to inner gopium format
+				o := m.enum(ts.Name.Name, st)
+				// apply provided strategy
+				// with struct platforms variants
+				// and fields co-access graph
+				// and keep pinned structs untouched
+				r, err := stg.Apply(m.port(m.access(ctx, ts.Pos()), o, st), o)
+				r = m.pin(ts.Pos(), o, r)
+				// notify ref with result structure
+				notif(r)
+				// and push results to the chan
+				ch <- applied{
+					ID:  id,
+					Loc: loc,
+					O:   o,
+					R:   r,
+					Err: err,
+				}
+			})
+		})
 	}
 	for _, vclos := range vclos {
 		// manage context actions
@@ -238,3 +310,166 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 	// wait until all visits are finished
 	wg.Wait()
 }
+
+// vinst defines generic struct visiting helper
+// that converts all concrete generic struct instances
+// to inner gopium format and applies strategy to them,
+// then it pushes instances results to the provided chan
+// and returns generic struct origin and result
+// with the fields order that is optimal across all instances
+func vinst(
+	ctx context.Context,
+	tn *types.TypeName,
+	id string,
+	loc string,
+	stg gopium.Strategy,
+	m *maven,
+	ch appliedCh,
+) (gopium.Struct, gopium.Struct, error) {
+	insts, _ := m.loc.Instances(tn.Pos(), nil)
+	origins := make([]gopium.Struct, 0, len(insts))
+	results := make([]gopium.Struct, 0, len(insts))
+	for _, inst := range insts {
+		// build instance type args suffix
+		targs := inst.TypeArgs()
+		args := make([]string, 0, targs.Len())
+		for i := 0; i < targs.Len(); i++ {
+			args = append(args, m.exp.Name(targs.At(i)))
+		}
+		suffix := fmt.Sprintf("[%s]", strings.Join(args, ", "))
+		// convert instance struct to inner gopium format
+		// and apply provided strategy to it
+		// with struct platforms variants
+		// and origin fields co-access graph
+		ist := inst.Underlying().(*types.Struct)
+		o := m.enum(tn.Name()+suffix, ist)
+		r, err := stg.Apply(m.port(m.access(ctx, tn.Pos()), o, ist), o)
+		if err != nil {
+			return o, r, err
+		}
+		r = m.pin(tn.Pos(), o, r)
+		origins = append(origins, o)
+		results = append(results, r)
+		// push instance results to the chan
+		ch <- applied{
+			ID:  id + suffix,
+			Loc: loc,
+			O:   o,
+			R:   r,
+		}
+	}
+	// pick instance result with fields order
+	// that has the smallest total size
+	// and then ptr scan size across all instances
+	var best int
+	var bsize, bptr int64 = -1, -1
+	for i := range results {
+		var size, ptr int64
+		for _, o := range origins {
+			s, _, p := collections.SizeAlignPtr(reorder(o, results[i]))
+			size += s
+			ptr += p
+		}
+		if bsize < 0 || size < bsize || (size == bsize && ptr < bptr) {
+			best, bsize, bptr = i, size, ptr
+		}
+	}
+	// build generic origin and result from
+	// the best instance with generic fields types
+	// note: generic fields can't be sized
+	// so only their types names are used
+	st := tn.Type().Underlying().(*types.Struct)
+	gtypes := make(map[string]string, st.NumFields())
+	o, r := collections.CopyStruct(origins[best]), collections.CopyStruct(results[best])
+	o.Name, r.Name = tn.Name(), tn.Name()
+	for i := range o.Fields {
+		f := st.Field(i)
+		o.Fields[i].Type = m.exp.Name(f.Type())
+		gtypes[f.Name()] = o.Fields[i].Type
+	}
+	for i, f := range r.Fields {
+		if t, ok := gtypes[f.Name]; ok && f.Name != "_" {
+			r.Fields[i].Type = t
+		}
+	}
+	return o, strip(o, r), nil
+}
+
+// strip helps to strip generic result struct
+// from size dependent pads and annotations
+// that are specific to the best instance only,
+// so only origin blank fields and notes are kept
+func strip(o gopium.Struct, r gopium.Struct) gopium.Struct {
+	// collect origin blank fields types
+	// and origin fields by their names
+	blanks := make(map[string]int, len(o.Fields))
+	fields := make(map[string]gopium.Field, len(o.Fields))
+	for _, f := range o.Fields {
+		if f.Name == "_" {
+			blanks[f.Type]++
+			continue
+		}
+		fields[f.Name] = f
+	}
+	// skip all result blank fields
+	// that don't exist in origin
+	// and strip remaining fields notes
+	rfields := r.Fields[:0:0]
+	for _, f := range r.Fields {
+		if f.Name == "_" {
+			if blanks[f.Type] == 0 {
+				continue
+			}
+			blanks[f.Type]--
+			rfields = append(rfields, f)
+			continue
+		}
+		of := fields[f.Name]
+		f.Doc = unnote(of.Doc, f.Doc)
+		f.Comment = unnote(of.Comment, f.Comment)
+		rfields = append(rfields, f)
+	}
+	r.Fields = rfields
+	r.Doc = unnote(o.Doc, r.Doc)
+	r.Comment = unnote(o.Comment, r.Comment)
+	return r
+}
+
+// unnote helps to filter result notes
+// from stamped notes that don't exist in origin
+func unnote(o []string, r []string) []string {
+	notes := make(map[string]bool, len(o))
+	for _, note := range o {
+		notes[note] = true
+	}
+	rnotes := r[:0:0]
+	for _, note := range r {
+		if notes[note] || !strings.Contains(note, gopium.STAMP) {
+			rnotes = append(rnotes, note)
+		}
+	}
+	return rnotes
+}
+
+// reorder helps to reorder origin struct fields
+// accordingly to result struct fields names order
+// all blank origin fields are placed at the end
+// and all blank result fields are skipped
+func reorder(o gopium.Struct, r gopium.Struct) gopium.Struct {
+	fields := make(map[string]gopium.Field, len(o.Fields))
+	for _, f := range o.Fields {
+		fields[f.Name] = f
+	}
+	st := gopium.Struct{Fields: make([]gopium.Field, 0, len(o.Fields))}
+	for _, f := range r.Fields {
+		if of, ok := fields[f.Name]; ok && f.Name != "_" {
+			st.Fields = append(st.Fields, of)
+		}
+	}
+	for _, f := range o.Fields {
+		if f.Name == "_" {
+			st.Fields = append(st.Fields, f)
+		}
+	}
+	return st
+}
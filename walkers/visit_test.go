@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"go/types"
+	"io"
 	"reflect"
 	"regexp"
 	"testing"
@@ -75,7 +76,7 @@ func TestWithVisit(t *testing.T) {
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
 			// exec
-			gvisit := with(tcase.exp, tcase.loc, tcase.bref).
+			gvisit := with(tcase.exp, tcase.loc, tcase.bref, io.Discard).
 				visit(tcase.r, tcase.stg, tcase.ch, tcase.deep)
 			gvisit(tcase.ctx, tcase.s)
 			// check
@@ -147,6 +148,7 @@ func TestVscope(t *testing.T) {
 							Size:     16,
 							Align:    8,
 							Ptr:      8,
+							Offset:   16,
 							Exported: true,
 						},
 						{
@@ -155,6 +157,7 @@ func TestVscope(t *testing.T) {
 							Size:     16,
 							Align:    8,
 							Ptr:      8,
+							Offset:   32,
 							Exported: true,
 						},
 					},
@@ -209,10 +212,11 @@ func TestVscope(t *testing.T) {
 							Embedded: true,
 						},
 						{
-							Name:  "b",
-							Type:  "float64",
-							Size:  8,
-							Align: 8,
+							Name:   "b",
+							Type:   "float64",
+							Size:   8,
+							Align:  8,
+							Offset: 8,
 						},
 					},
 				},
@@ -231,10 +235,29 @@ func TestVscope(t *testing.T) {
 							Type:     "struct{b github.com/1pkg/gopium/tests/data/flat.b; z github.com/1pkg/gopium/tests/data/flat.A}",
 							Size:     24,
 							Align:    8,
+							Offset:   24,
 							Exported: true,
 						},
 					},
 				},
+				"tests_data_flat_file.go:23:4": {
+					Name: "C.A",
+					Fields: []gopium.Field{
+						{
+							Name:  "b",
+							Type:  "github.com/1pkg/gopium/tests/data/flat.b",
+							Size:  16,
+							Align: 8,
+						},
+						{
+							Name:   "z",
+							Type:   "github.com/1pkg/gopium/tests/data/flat.A",
+							Size:   8,
+							Align:  8,
+							Offset: 16,
+						},
+					},
+				},
 				"tests_data_flat_file.go:29": {
 					Name: "c1",
 					Fields: []gopium.Field{
@@ -250,6 +273,7 @@ func TestVscope(t *testing.T) {
 							Type:     "struct{b github.com/1pkg/gopium/tests/data/flat.b; z github.com/1pkg/gopium/tests/data/flat.A}",
 							Size:     24,
 							Align:    8,
+							Offset:   24,
 							Exported: true,
 						},
 					},
@@ -264,16 +288,19 @@ func TestVscope(t *testing.T) {
 							Align: 1,
 						},
 						{
-							Name:  "b",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "b",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 13,
 						},
 						{
-							Name:  "_",
-							Type:  "int64",
-							Size:  8,
-							Align: 8,
+							Name:   "_",
+							Type:   "int64",
+							Size:   8,
+							Align:  8,
+							Offset: 16,
+							Pad:    2,
 						},
 					},
 				},
@@ -291,13 +318,16 @@ func TestVscope(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/flat.D",
 							Size:     24,
 							Align:    8,
+							Offset:   8,
+							Pad:      7,
 							Exported: true,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 32,
 						},
 					},
 				},
@@ -344,13 +374,16 @@ func TestVscope(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/flat.D",
 							Size:     24,
 							Align:    8,
+							Offset:   8,
+							Pad:      7,
 							Exported: true,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 32,
 						},
 					},
 				},
@@ -386,10 +419,11 @@ func TestVscope(t *testing.T) {
 							Embedded: true,
 						},
 						{
-							Name:  "b",
-							Type:  "float64",
-							Size:  8,
-							Align: 8,
+							Name:   "b",
+							Type:   "float64",
+							Size:   8,
+							Align:  8,
+							Offset: 8,
 						},
 					},
 				},
@@ -408,10 +442,29 @@ func TestVscope(t *testing.T) {
 							Type:     "struct{b github.com/1pkg/gopium/tests/data/nested.b; z github.com/1pkg/gopium/tests/data/nested.A}",
 							Size:     24,
 							Align:    8,
+							Offset:   24,
 							Exported: true,
 						},
 					},
 				},
+				"tests_data_nested_file.go:18:4": {
+					Name: "C.A",
+					Fields: []gopium.Field{
+						{
+							Name:  "b",
+							Type:  "github.com/1pkg/gopium/tests/data/nested.b",
+							Size:  16,
+							Align: 8,
+						},
+						{
+							Name:   "z",
+							Type:   "github.com/1pkg/gopium/tests/data/nested.A",
+							Size:   8,
+							Align:  8,
+							Offset: 16,
+						},
+					},
+				},
 				"tests_data_nested_file.go:63": {
 					Name: "Z",
 					Fields: []gopium.Field{
@@ -427,13 +480,16 @@ func TestVscope(t *testing.T) {
 							Size:     48,
 							Align:    8,
 							Ptr:      8,
+							Offset:   8,
+							Pad:      7,
 							Exported: true,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 56,
 						},
 					},
 				},
@@ -468,16 +524,18 @@ func TestVscope(t *testing.T) {
 							Exported: true,
 						},
 						{
-							Name:  "a",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "a",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 24,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 25,
 						},
 					},
 				},
@@ -497,6 +555,7 @@ func TestVscope(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/multi.AZ",
 							Size:     32,
 							Align:    8,
+							Offset:   16,
 							Exported: true,
 							Embedded: true,
 						},
@@ -505,6 +564,7 @@ func TestVscope(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/multi.D",
 							Size:     24,
 							Align:    8,
+							Offset:   48,
 							Exported: true,
 							Embedded: true,
 						},
@@ -513,6 +573,7 @@ func TestVscope(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/multi.D",
 							Size:     24,
 							Align:    8,
+							Offset:   72,
 							Exported: true,
 						},
 					},
@@ -623,6 +684,7 @@ func TestVdeep(t *testing.T) {
 							Size:     16,
 							Align:    8,
 							Ptr:      8,
+							Offset:   16,
 							Exported: true,
 						},
 						{
@@ -631,6 +693,7 @@ func TestVdeep(t *testing.T) {
 							Size:     16,
 							Align:    8,
 							Ptr:      8,
+							Offset:   32,
 							Exported: true,
 						},
 					},
@@ -685,10 +748,11 @@ func TestVdeep(t *testing.T) {
 							Embedded: true,
 						},
 						{
-							Name:  "b",
-							Type:  "float64",
-							Size:  8,
-							Align: 8,
+							Name:   "b",
+							Type:   "float64",
+							Size:   8,
+							Align:  8,
+							Offset: 8,
 						},
 					},
 				},
@@ -707,10 +771,29 @@ func TestVdeep(t *testing.T) {
 							Type:     "struct{b github.com/1pkg/gopium/tests/data/flat.b; z github.com/1pkg/gopium/tests/data/flat.A}",
 							Size:     24,
 							Align:    8,
+							Offset:   24,
 							Exported: true,
 						},
 					},
 				},
+				"tests_data_flat_file.go:23:4": {
+					Name: "C.A",
+					Fields: []gopium.Field{
+						{
+							Name:  "b",
+							Type:  "github.com/1pkg/gopium/tests/data/flat.b",
+							Size:  16,
+							Align: 8,
+						},
+						{
+							Name:   "z",
+							Type:   "github.com/1pkg/gopium/tests/data/flat.A",
+							Size:   8,
+							Align:  8,
+							Offset: 16,
+						},
+					},
+				},
 				"tests_data_flat_file.go:29": {
 					Name: "c1",
 					Fields: []gopium.Field{
@@ -726,6 +809,7 @@ func TestVdeep(t *testing.T) {
 							Type:     "struct{b github.com/1pkg/gopium/tests/data/flat.b; z github.com/1pkg/gopium/tests/data/flat.A}",
 							Size:     24,
 							Align:    8,
+							Offset:   24,
 							Exported: true,
 						},
 					},
@@ -740,16 +824,19 @@ func TestVdeep(t *testing.T) {
 							Align: 1,
 						},
 						{
-							Name:  "b",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "b",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 13,
 						},
 						{
-							Name:  "_",
-							Type:  "int64",
-							Size:  8,
-							Align: 8,
+							Name:   "_",
+							Type:   "int64",
+							Size:   8,
+							Align:  8,
+							Offset: 16,
+							Pad:    2,
 						},
 					},
 				},
@@ -767,13 +854,16 @@ func TestVdeep(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/flat.D",
 							Size:     24,
 							Align:    8,
+							Offset:   8,
+							Pad:      7,
 							Exported: true,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 32,
 						},
 					},
 				},
@@ -820,13 +910,16 @@ func TestVdeep(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/flat.D",
 							Size:     24,
 							Align:    8,
+							Offset:   8,
+							Pad:      7,
 							Exported: true,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 32,
 						},
 					},
 				},
@@ -862,10 +955,11 @@ func TestVdeep(t *testing.T) {
 							Embedded: true,
 						},
 						{
-							Name:  "b",
-							Type:  "float64",
-							Size:  8,
-							Align: 8,
+							Name:   "b",
+							Type:   "float64",
+							Size:   8,
+							Align:  8,
+							Offset: 8,
 						},
 					},
 				},
@@ -884,10 +978,29 @@ func TestVdeep(t *testing.T) {
 							Type:     "struct{b github.com/1pkg/gopium/tests/data/nested.b; z github.com/1pkg/gopium/tests/data/nested.A}",
 							Size:     24,
 							Align:    8,
+							Offset:   24,
 							Exported: true,
 						},
 					},
 				},
+				"tests_data_nested_file.go:18:4": {
+					Name: "C.A",
+					Fields: []gopium.Field{
+						{
+							Name:  "b",
+							Type:  "github.com/1pkg/gopium/tests/data/nested.b",
+							Size:  16,
+							Align: 8,
+						},
+						{
+							Name:   "z",
+							Type:   "github.com/1pkg/gopium/tests/data/nested.A",
+							Size:   8,
+							Align:  8,
+							Offset: 16,
+						},
+					},
+				},
 				"tests_data_nested_file.go:25": {
 					Name: "B",
 					Fields: []gopium.Field{
@@ -912,10 +1025,11 @@ func TestVdeep(t *testing.T) {
 							Embedded: true,
 						},
 						{
-							Name:  "b",
-							Type:  "float64",
-							Size:  8,
-							Align: 8,
+							Name:   "b",
+							Type:   "float64",
+							Size:   8,
+							Align:  8,
+							Offset: 8,
 						},
 					},
 				},
@@ -968,13 +1082,16 @@ func TestVdeep(t *testing.T) {
 							Size:     48,
 							Align:    8,
 							Ptr:      8,
+							Offset:   8,
+							Pad:      7,
 							Exported: true,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 56,
 						},
 					},
 				},
@@ -1009,16 +1126,18 @@ func TestVdeep(t *testing.T) {
 							Exported: true,
 						},
 						{
-							Name:  "a",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "a",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 24,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 25,
 						},
 					},
 				},
@@ -1038,6 +1157,7 @@ func TestVdeep(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/multi.AZ",
 							Size:     32,
 							Align:    8,
+							Offset:   16,
 							Exported: true,
 							Embedded: true,
 						},
@@ -1046,6 +1166,7 @@ func TestVdeep(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/multi.D",
 							Size:     24,
 							Align:    8,
+							Offset:   48,
 							Exported: true,
 							Embedded: true,
 						},
@@ -1054,6 +1175,7 @@ func TestVdeep(t *testing.T) {
 							Type:     "github.com/1pkg/gopium/tests/data/multi.D",
 							Size:     24,
 							Align:    8,
+							Offset:   72,
 							Exported: true,
 						},
 					},
@@ -1069,16 +1191,18 @@ func TestVdeep(t *testing.T) {
 							Exported: true,
 						},
 						{
-							Name:  "a",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "a",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 8,
 						},
 						{
-							Name:  "z",
-							Type:  "bool",
-							Size:  1,
-							Align: 1,
+							Name:   "z",
+							Type:   "bool",
+							Size:   1,
+							Align:  1,
+							Offset: 9,
 						},
 					},
 				},
@@ -1128,3 +1252,82 @@ func TestVdeep(t *testing.T) {
 		})
 	}
 }
+
+func TestStrip(t *testing.T) {
+	// prepare
+	note := "// struct size: 24 bytes; - " + gopium.STAMP
+	table := map[string]struct {
+		o gopium.Struct
+		r gopium.Struct
+		s gopium.Struct
+	}{
+		"empty structs should be stripped to empty struct": {},
+		"struct with new pads and notes should be stripped": {
+			o: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// test"},
+				Fields: []gopium.Field{
+					{Name: "a", Type: "bool"},
+					{Name: "b", Type: "T", Doc: []string{"// b"}},
+				},
+			},
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// test", note},
+				Fields: []gopium.Field{
+					{Name: "b", Type: "T", Doc: []string{"// b", note}},
+					{Name: "a", Type: "bool", Comment: []string{note}},
+					{Name: "_", Type: "[7]byte"},
+				},
+			},
+			s: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// test"},
+				Fields: []gopium.Field{
+					{Name: "b", Type: "T", Doc: []string{"// b"}},
+					{Name: "a", Type: "bool", Comment: []string{}},
+				},
+			},
+		},
+		"struct with origin pads and notes should keep them": {
+			o: gopium.Struct{
+				Name:    "test",
+				Comment: []string{note},
+				Fields: []gopium.Field{
+					{Name: "a", Type: "bool"},
+					{Name: "_", Type: "[7]byte"},
+					{Name: "b", Type: "T"},
+				},
+			},
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{note},
+				Fields: []gopium.Field{
+					{Name: "b", Type: "T"},
+					{Name: "_", Type: "[7]byte"},
+					{Name: "a", Type: "bool"},
+					{Name: "_", Type: "[7]byte"},
+				},
+			},
+			s: gopium.Struct{
+				Name:    "test",
+				Comment: []string{note},
+				Fields: []gopium.Field{
+					{Name: "b", Type: "T"},
+					{Name: "_", Type: "[7]byte"},
+					{Name: "a", Type: "bool"},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			s := strip(tcase.o, tcase.r)
+			// check
+			if !reflect.DeepEqual(s, tcase.s) {
+				t.Errorf("actual %v doesn't equal to expected %v", s, tcase.s)
+			}
+		})
+	}
+}
@@ -0,0 +1,749 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/fmtio/astutil"
+	"github.com/1pkg/gopium/gopium"
+
+	goastutil "golang.org/x/tools/go/ast/astutil"
+)
+
+// list of warch presets
+var (
+	astgoarch = warch{
+		apply:  astutil.UFFN,
+		writer: fmtio.Origin{Writter: fmtio.Files{Ext: fmtio.GO}},
+	}
+)
+
+// Target defines single walker target architecture
+// with exposer and strategy built for the architecture
+type Target struct {
+	Exposer  gopium.Exposer  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Strategy gopium.Strategy `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Arch     string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [16]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 40 bytes; - 🌺 gopium @1pkg
+
+// warch defines packages walker ast sync implementation
+// that visits package once per target architecture and
+// moves structs which results differ across architectures
+// to build constrained per architecture files
+type warch struct {
+	writer  gopium.CategoryWriter        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser  gopium.Parser                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	printer gopium.Printer               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	apply   func(io.Writer) gopium.Apply `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	warn    io.Writer                    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	targets []Target                     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep    bool                         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref    bool                         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [30]byte                     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 80 bytes; - 🌺 gopium @1pkg
+
+// With erich warch walker with external visiting parameters
+// parser, printer instances, target architectures, warnings writer and additional visiting flags
+func (w warch) With(xp gopium.Parser, p gopium.Printer, targets []Target, warn io.Writer, deep bool, bref bool) warch {
+	w.parser = xp
+	w.printer = p
+	w.targets = targets
+	w.warn = warn
+	w.deep = deep
+	w.bref = bref
+	return w
+}
+
+// Visit warch implementation uses visit function helper
+// to go through all structs decls inside the package
+// and applies target strategies to them to get results
+// for each target architecture, then overrides ast files
+// and creates build constrained files for structs
+// which results differ across target architectures
+func (w warch) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	// use parser to parse types pkg data
+	// we don't care about fset
+	pkg, loc, err := w.parser.ParseTypes(ctx)
+	if err != nil {
+		return err
+	}
+	// visit package for each target
+	// and collect results separately
+	hs := make([]collections.Hierarchic, 0, len(w.targets))
+	for _, t := range w.targets {
+		// use target strategy if any
+		// otherwise fallback to
+		// provided strategy
+		tstg := stg
+		if t.Strategy != nil {
+			tstg = t.Strategy
+		}
+		h, err := w.collect(ctx, pkg, loc, regex, t.Exposer, tstg)
+		if err != nil {
+			return err
+		}
+		hs = append(hs, h)
+	}
+	// run sync write
+	// with collected strategies results
+	return w.write(ctx, hs)
+}
+
+// collect warch helps to visit types package
+// with target exposer and strategy
+// and collect all strategy results
+func (w warch) collect(
+	ctx context.Context,
+	pkg *types.Package,
+	loc gopium.Locator,
+	regex *regexp.Regexp,
+	exp gopium.Exposer,
+	stg gopium.Strategy,
+) (collections.Hierarchic, error) {
+	// create govisit func
+	// using visit helper
+	// and run it on pkg scope
+	ch := make(appliedCh)
+	gvisit := with(exp, loc, w.bref, w.warn).
+		visit(regex, stg, ch, w.deep)
+	// prepare separate cancelation
+	// context for visiting
+	gctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// run visiting in separate goroutine
+	go gvisit(gctx, pkg.Scope())
+	// prepare struct storage
+	h := collections.NewHierarchic("")
+	for applied := range ch {
+		// in case any error happened
+		// just return error back
+		// it auto cancels context
+		if applied.Err != nil {
+			return h, applied.Err
+		}
+		// push struct to storage
+		h.Push(applied.ID, applied.Loc, applied.R)
+	}
+	return h, ctx.Err()
+}
+
+// write warch helps to sync and persist
+// strategies results of all targets
+// to ast files and per architecture files
+func (w warch) write(ctx context.Context, hs []collections.Hierarchic) error {
+	// skip empty writes
+	if len(hs) == 0 || hs[0].Len() == 0 {
+		return nil
+	}
+	// print package files sources
+	// updated with results for each target
+	// and original package files sources
+	srcs := make([]map[string][]byte, 0, len(hs)+1)
+	for _, h := range hs {
+		src, err := w.print(ctx, h, w.apply(w.warn))
+		if err != nil {
+			return err
+		}
+		srcs = append(srcs, src)
+	}
+	src, err := w.print(ctx, hs[0], nil)
+	if err != nil {
+		return err
+	}
+	srcs = append(srcs, src)
+	// add writer root category
+	// in case any error happened
+	// just return error back
+	if err := w.writer.Category(hs[0].Rcat()); err != nil {
+		return err
+	}
+	// go through all package files
+	// in stable order and split them
+	// to common and per architecture files,
+	// generated files are collected separately
+	// as they could clash with existing files
+	names := make([]string, 0, len(srcs[0]))
+	for name := range srcs[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	files := make(map[string][]byte, len(names))
+	gens := make(map[string][]byte)
+	for _, name := range names {
+		sfiles, err := w.split(name, srcs)
+		if err != nil {
+			return err
+		}
+		for fname, src := range sfiles {
+			if fname == name {
+				files[fname] = src
+			} else {
+				gens[fname] = src
+			}
+		}
+	}
+	// merge generated files declarations
+	// into existing package or disk files
+	// instead of overriding them
+	for fname, src := range gens {
+		dst, ok := files[fname]
+		if !ok {
+			dst, ok = srcs[len(srcs)-1][fname]
+		}
+		if !ok {
+			data, err := os.ReadFile(fname)
+			switch {
+			case err == nil:
+				dst, ok = data, true
+			case !errors.Is(err, fs.ErrNotExist):
+				return err
+			}
+		}
+		if ok {
+			merged, err := merge(fname, dst, src)
+			if err != nil {
+				return err
+			}
+			src = merged
+		}
+		files[fname] = src
+	}
+	// persist all files
+	// in stable order
+	fnames := make([]string, 0, len(files))
+	for fname := range files {
+		fnames = append(fnames, fname)
+	}
+	sort.Strings(fnames)
+	for _, fname := range fnames {
+		if err := w.persist(ctx, fname, files[fname]); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// print warch helps to parse ast package,
+// update it with results using provided apply
+// if any and print all package files sources
+func (w warch) print(
+	ctx context.Context,
+	h collections.Hierarchic,
+	apply gopium.Apply,
+) (map[string][]byte, error) {
+	// use parser to parse ast pkg data
+	pkg, loc, err := w.parser.ParseAst(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// run ast apply with strategy result
+	// to update ast.Package if needed
+	if apply != nil {
+		pkg, err = apply(ctx, pkg, loc, h)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// print all package files
+	// with their latest file sets
+	srcs := make(map[string][]byte, len(pkg.Files))
+	for name, file := range pkg.Files {
+		fset, _ := loc.Fset(name, nil)
+		var buf bytes.Buffer
+		if err := w.printer.Print(ctx, &buf, fset, file); err != nil {
+			return nil, err
+		}
+		srcs[name] = buf.Bytes()
+	}
+	return srcs, nil
+}
+
+// split warch helps to split printed package file
+// sources to common file and build constrained
+// per architecture files, the last provided
+// sources are treated as original sources
+func (w warch) split(name string, srcs []map[string][]byte) (map[string][]byte, error) {
+	base, test, goos, goarch := fconstraint(name)
+	// files which are already constrained
+	// to target architecture by their names
+	// are synced only with the target results
+	// and files constrained to other architectures
+	// are kept untouched as well as fallback files
+	if goarch != "" {
+		for i, t := range w.targets {
+			if goarch == t.Arch {
+				return map[string][]byte{name: srcs[i][name]}, nil
+			}
+		}
+		return nil, nil
+	}
+	if strings.HasSuffix(base, "_other") {
+		return nil, nil
+	}
+	// file name goos constraint
+	// is lost by generated files names
+	// so convert it to build constraint
+	var fexpr constraint.Expr
+	if goos != "" {
+		fexpr = &constraint.TagExpr{Tag: goos}
+	}
+	// fname builds generated file name
+	// by adding suffix before test suffix
+	fname := func(suffix string) string {
+		if test {
+			return fmt.Sprintf("%s_%s_test.go", base, suffix)
+		}
+		return fmt.Sprintf("%s_%s.go", base, suffix)
+	}
+	// collect file declarations for all sources
+	decls := make([]fdecls, 0, len(srcs))
+	for _, src := range srcs {
+		d, err := parseDecls(name, src[name])
+		if err != nil {
+			return nil, err
+		}
+		if fexpr != nil {
+			if d.build != nil {
+				d.build = &constraint.AndExpr{X: d.build, Y: fexpr}
+			} else {
+				d.build = fexpr
+			}
+		}
+		if len(decls) > 0 && len(d.types) != len(decls[0].types) {
+			return nil, fmt.Errorf("can't match %q type declarations across target architectures", name)
+		}
+		decls = append(decls, d)
+	}
+	// find type declarations that
+	// differ across target architectures
+	var diff []int
+	for i := range decls[0].types {
+		for _, d := range decls[1:len(w.targets)] {
+			if d.types[i].text != decls[0].types[i].text {
+				diff = append(diff, i)
+				break
+			}
+		}
+	}
+	// in case there are no differences
+	// just sync file with the first target results
+	if len(diff) == 0 {
+		return map[string][]byte{name: srcs[0][name]}, nil
+	}
+	// keep common declarations in original file
+	// and move differing declarations to files
+	// constrained by each target architecture
+	// plus fallback file with original declarations
+	// constrained by all other architectures
+	files := make(map[string][]byte, len(w.targets)+2)
+	files[name] = decls[0].cut(diff)
+	var others constraint.Expr
+	for i, t := range w.targets {
+		tag := &constraint.TagExpr{Tag: t.Arch}
+		files[fname(t.Arch)] = decls[i].variant(diff, tag)
+		var not constraint.Expr = &constraint.NotExpr{X: tag}
+		if others != nil {
+			not = &constraint.AndExpr{X: others, Y: not}
+		}
+		others = not
+	}
+	files[fname("other")] = decls[len(w.targets)].variant(diff, others)
+	return files, nil
+}
+
+// list of known goos and goarch values
+// that constrain files by their names
+// note: lists mirror go/build known lists
+var (
+	knownos = map[string]bool{
+		"aix":       true,
+		"android":   true,
+		"darwin":    true,
+		"dragonfly": true,
+		"freebsd":   true,
+		"hurd":      true,
+		"illumos":   true,
+		"ios":       true,
+		"js":        true,
+		"linux":     true,
+		"nacl":      true,
+		"netbsd":    true,
+		"openbsd":   true,
+		"plan9":     true,
+		"solaris":   true,
+		"wasip1":    true,
+		"windows":   true,
+		"zos":       true,
+	}
+	knownarch = map[string]bool{
+		"386":         true,
+		"amd64":       true,
+		"amd64p32":    true,
+		"arm":         true,
+		"armbe":       true,
+		"arm64":       true,
+		"arm64be":     true,
+		"loong64":     true,
+		"mips":        true,
+		"mipsle":      true,
+		"mips64":      true,
+		"mips64le":    true,
+		"mips64p32":   true,
+		"mips64p32le": true,
+		"ppc":         true,
+		"ppc64":       true,
+		"ppc64le":     true,
+		"riscv":       true,
+		"riscv64":     true,
+		"s390":        true,
+		"s390x":       true,
+		"sparc":       true,
+		"sparc64":     true,
+		"wasm":        true,
+	}
+)
+
+// fconstraint helps to parse file name
+// implicit goos and goarch build constraints
+// the same way as go/build does it and returns
+// file name without extension and test suffix
+func fconstraint(name string) (base string, test bool, goos string, goarch string) {
+	base = strings.TrimSuffix(name, filepath.Ext(name))
+	if strings.HasSuffix(base, "_test") {
+		base, test = strings.TrimSuffix(base, "_test"), true
+	}
+	// the first file name element
+	// is never treated as constraint
+	fbase := filepath.Base(base)
+	i := strings.Index(fbase, "_")
+	if i < 0 {
+		return base, test, "", ""
+	}
+	l := strings.Split(fbase[i:], "_")
+	if n := len(l); n >= 2 && knownos[l[n-2]] && knownarch[l[n-1]] {
+		return base, test, l[n-2], l[n-1]
+	}
+	if n := len(l); n >= 1 && knownos[l[n-1]] {
+		return base, test, l[n-1], ""
+	}
+	if n := len(l); n >= 1 && knownarch[l[n-1]] {
+		return base, test, "", l[n-1]
+	}
+	return base, test, "", ""
+}
+
+// merge helps to merge type declarations
+// of generated file source into existing
+// file source, existing file build constraint
+// combined with its file name constraints
+// has to be equivalent to generated one
+func merge(name string, dst, src []byte) ([]byte, error) {
+	dd, err := parseDecls(name, dst)
+	if err != nil {
+		return nil, err
+	}
+	sd, err := parseDecls(name, src)
+	if err != nil {
+		return nil, err
+	}
+	expr := dd.build
+	_, _, goos, goarch := fconstraint(name)
+	for _, tag := range []string{goos, goarch} {
+		if tag == "" {
+			continue
+		}
+		var texpr constraint.Expr = &constraint.TagExpr{Tag: tag}
+		if expr != nil {
+			texpr = &constraint.AndExpr{X: expr, Y: texpr}
+		}
+		expr = texpr
+	}
+	if dd.pkg != sd.pkg || expr == nil || sd.build == nil || !equivalent(expr, sd.build) {
+		return nil, fmt.Errorf("can't merge type declarations to existing file %q with different build constraint", name)
+	}
+	// append generated type declarations
+	// to the end of existing file source
+	buf := bytes.NewBuffer(append([]byte(nil), dst...))
+	for _, t := range sd.types {
+		fmt.Fprintf(buf, "\n%s\n", t.text)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, buf.Bytes(), parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	// add generated file imports
+	// unused ones are removed on persist
+	ifile, err := parser.ParseFile(token.NewFileSet(), name, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+	for _, imp := range ifile.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		var iname string
+		if imp.Name != nil {
+			iname = imp.Name.Name
+		}
+		goastutil.AddNamedImport(fset, file, iname, path)
+	}
+	var out bytes.Buffer
+	if err := format.Node(&out, fset, file); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// equivalent checks if provided build constraints
+// are satisfied by exactly the same tags sets
+func equivalent(x, y constraint.Expr) bool {
+	// collect all tags
+	// used by both constraints
+	var tags []string
+	seen := make(map[string]bool)
+	var walk func(expr constraint.Expr)
+	walk = func(expr constraint.Expr) {
+		switch expr := expr.(type) {
+		case *constraint.TagExpr:
+			if !seen[expr.Tag] {
+				seen[expr.Tag] = true
+				tags = append(tags, expr.Tag)
+			}
+		case *constraint.NotExpr:
+			walk(expr.X)
+		case *constraint.AndExpr:
+			walk(expr.X)
+			walk(expr.Y)
+		case *constraint.OrExpr:
+			walk(expr.X)
+			walk(expr.Y)
+		}
+	}
+	walk(x)
+	walk(y)
+	// evaluate both constraints
+	// on all tags combinations
+	for mask := 0; mask < 1<<len(tags); mask++ {
+		ok := func(tag string) bool {
+			for i, t := range tags {
+				if t == tag {
+					return mask&(1<<i) != 0
+				}
+			}
+			return false
+		}
+		if x.Eval(ok) != y.Eval(ok) {
+			return false
+		}
+	}
+	return true
+}
+
+// persist warch helps to clean up provided
+// file source from empty type declarations
+// and unused imports and then to print it
+// to generated writer
+func (w warch) persist(ctx context.Context, name string, src []byte) error {
+	// parse file source back to ast
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	// remove type declarations
+	// left without any spec
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		if gdecl, ok := decl.(*ast.GenDecl); ok && gdecl.Tok == token.TYPE && len(gdecl.Specs) == 0 {
+			continue
+		}
+		decls = append(decls, decl)
+	}
+	file.Decls = decls
+	// remove imports that are not
+	// used by file declarations anymore
+	for _, imp := range append([]*ast.ImportSpec(nil), file.Imports...) {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || goastutil.UsesImport(file, path) {
+			continue
+		}
+		var name string
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		goastutil.DeleteNamedImport(fset, file, name, path)
+	}
+	// generate relevant writer
+	// in case any error happened
+	// just return error back
+	writer, err := w.writer.Generate(name)
+	if err != nil {
+		return err
+	}
+	// write updated ast file
+	// in case any error happened
+	// just return error back
+	if err := w.printer.Print(ctx, writer, fset, file); err != nil {
+		return err
+	}
+	// flush writer result
+	return writer.Close()
+}
+
+// tdecl defines single type declaration
+// source with its offsets inside the file
+type tdecl struct {
+	text string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	beg  int    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	end  int    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 8 bytes; - 🌺 gopium @1pkg
+
+// fdecls defines file source declarations
+// that could be moved to other files
+type fdecls struct {
+	build   constraint.Expr `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	pkg     string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	src     []byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	imports []string        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	types   []tdecl         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [24]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 88 bytes; - 🌺 gopium @1pkg
+
+// parseDecls parses provided file source
+// and collects its build constraint, imports
+// and each type declaration source
+func parseDecls(name string, src []byte) (fdecls, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	if err != nil {
+		return fdecls{}, err
+	}
+	d := fdecls{pkg: file.Name.Name, src: src}
+	// find file build constraint
+	// among comments before package clause
+	for _, cg := range file.Comments {
+		if cg.Pos() > file.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if !constraint.IsGoBuild(c.Text) {
+				continue
+			}
+			if expr, err := constraint.Parse(c.Text); err == nil {
+				d.build = expr
+			}
+		}
+	}
+	// offset converts pos to file offset
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+	// go through all file declarations
+	// and collect imports and types sources
+	for _, decl := range file.Decls {
+		gdecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		switch gdecl.Tok {
+		case token.IMPORT:
+			d.imports = append(d.imports, string(src[offset(gdecl.Pos()):offset(gdecl.End())]))
+		case token.TYPE:
+			for _, spec := range gdecl.Specs {
+				tspec := spec.(*ast.TypeSpec)
+				end := offset(tspec.End())
+				// include trailing line comment
+				if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+					if strings.HasPrefix(strings.TrimSpace(string(src[end:end+i])), "//") {
+						end += i
+					}
+				}
+				var beg int
+				var text string
+				if gdecl.Lparen.IsValid() {
+					// grouped type spec needs
+					// its own type keyword
+					beg = offset(tspec.Pos())
+					text = fmt.Sprintf("type %s", src[beg:end])
+					if tspec.Doc != nil {
+						beg = offset(tspec.Doc.Pos())
+						text = fmt.Sprintf("%s\n%s", src[beg:offset(tspec.Doc.End())], text)
+					}
+				} else {
+					beg = offset(gdecl.Pos())
+					if gdecl.Doc != nil {
+						beg = offset(gdecl.Doc.Pos())
+					}
+					text = string(src[beg:end])
+				}
+				d.types = append(d.types, tdecl{text: text, beg: beg, end: end})
+			}
+		}
+	}
+	return d, nil
+}
+
+// cut returns file source without
+// type declarations with provided indexes
+func (d fdecls) cut(diff []int) []byte {
+	src := append([]byte(nil), d.src...)
+	// cut declarations from the end
+	// to keep offsets of previous ones
+	for i := len(diff) - 1; i >= 0; i-- {
+		t := d.types[diff[i]]
+		// cut declaration lines entirely
+		// including indentation and line break
+		beg, end := t.beg, t.end
+		for beg > 0 && (src[beg-1] == ' ' || src[beg-1] == '\t') {
+			beg--
+		}
+		if end < len(src) && src[end] == '\n' {
+			end++
+		}
+		src = append(src[:beg], src[end:]...)
+	}
+	return src
+}
+
+// variant returns new file source
+// that contains only type declarations
+// with provided indexes and file imports
+// constrained by provided build constraint
+func (d fdecls) variant(diff []int, expr constraint.Expr) []byte {
+	// combine file build constraint
+	// with provided build constraint
+	if d.build != nil {
+		expr = &constraint.AndExpr{X: d.build, Y: expr}
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "//go:build %s\n\n", expr)
+	fmt.Fprintf(&buf, "package %s\n\n", d.pkg)
+	for _, imp := range d.imports {
+		fmt.Fprintf(&buf, "%s\n\n", imp)
+	}
+	for _, i := range diff {
+		fmt.Fprintf(&buf, "%s\n\n", d.types[i].text)
+	}
+	return buf.Bytes()
+}
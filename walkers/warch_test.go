@@ -0,0 +1,642 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/fmtio/astutil"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestWarch(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	targets := make([]Target, 0, 2)
+	for _, arch := range []string{"amd64", "386"} {
+		m, err := typepkg.NewMavenGoTypes("gc", arch, 64, 64, 64)
+		if !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		stg, err := strategies.Builder{Curator: m}.Build(strategies.Pack)
+		if !reflect.DeepEqual(err, nil) {
+			t.Fatalf("actual %v doesn't equal to %v", err, nil)
+		}
+		targets = append(targets, Target{Arch: arch, Exposer: m, Strategy: stg})
+	}
+	np, err := strategies.Builder{}.Build(strategies.Ignore)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	p := fmtio.Gofmt{}
+	table := map[string]struct {
+		ctx     context.Context
+		p       gopium.Parser
+		a       func(io.Writer) gopium.Apply
+		w       gopium.CategoryWriter
+		targets []Target
+		stg     gopium.Strategy
+		sts     map[string][]byte
+		err     error
+	}{
+		"empty pkg should visit nothing": {
+			ctx:     context.Background(),
+			p:       data.NewParser("empty"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: targets,
+			sts:     map[string][]byte{},
+		},
+		"arch pkg should split structs that differ across targets": {
+			ctx:     context.Background(),
+			p:       data.NewParser("arch"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: targets,
+			sts: map[string][]byte{
+				"tests_data_arch_file.go": []byte(`
+//go:build tests_data
+
+package arch
+
+// Common is laid out
+// identically on all archs
+type Common struct {
+	B int32
+	A bool
+	C bool
+}
+
+type (
+	// Flag is common
+	Flag bool
+)
+`),
+				"tests_data_arch_file_386.go": []byte(`
+//go:build tests_data && 386
+
+package arch
+
+import (
+	"sync"
+)
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	B [3]int32
+	A int64
+}
+
+// Grouped depends
+// on int64 alignment too
+type Grouped struct {
+	B [3]int32
+	A int64
+	C sync.Mutex
+}
+`),
+				"tests_data_arch_file_amd64.go": []byte(`
+//go:build tests_data && amd64
+
+package arch
+
+import (
+	"sync"
+)
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	A int64
+	B [3]int32
+}
+
+// Grouped depends
+// on int64 alignment too
+type Grouped struct {
+	A int64
+	B [3]int32
+	C sync.Mutex
+}
+`),
+				"tests_data_arch_file_other.go": []byte(`
+//go:build tests_data && !amd64 && !386
+
+package arch
+
+import (
+	"sync"
+)
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	A int64
+	B [3]int32
+}
+
+// Grouped depends
+// on int64 alignment too
+type Grouped struct {
+	A int64
+	B [3]int32
+	C sync.Mutex
+}
+`),
+			},
+		},
+		"archmerge pkg should merge moved structs into existing architectures files": {
+			ctx:     context.Background(),
+			p:       data.NewParser("archmerge"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: targets,
+			sts: map[string][]byte{
+				"tests_data_archmerge_file.go": []byte(`
+//go:build tests_data
+
+package archmerge
+
+// Common is laid out
+// identically on all archs
+type Common struct {
+	B int32
+	A bool
+	C bool
+}
+`),
+				"tests_data_archmerge_file_386.go": []byte(`
+//go:build tests_data
+
+package archmerge
+
+import "sync"
+
+const archName = "386"
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	B [3]int32
+	A int64
+	C sync.Mutex
+}
+`),
+				"tests_data_archmerge_file_amd64.go": []byte(`
+//go:build tests_data
+
+package archmerge
+
+import "sync"
+
+const archName = "amd64"
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	A int64
+	B [3]int32
+	C sync.Mutex
+}
+`),
+				"tests_data_archmerge_file_other.go": []byte(`
+//go:build tests_data && !amd64 && !386
+
+package archmerge
+
+import (
+	"sync"
+)
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	A int64
+	B [3]int32
+	C sync.Mutex
+}
+`),
+			},
+		},
+		"arch pkg should keep all structs in original file with single target": {
+			ctx:     context.Background(),
+			p:       data.NewParser("arch"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: targets[1:],
+			sts: map[string][]byte{
+				"tests_data_arch_file.go": []byte(`
+//go:build tests_data
+
+package arch
+
+import (
+	"sync"
+)
+
+// Common is laid out
+// identically on all archs
+type Common struct {
+	B int32
+	A bool
+	C bool
+}
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	B [3]int32
+	A int64
+}
+
+type (
+	// Grouped depends
+	// on int64 alignment too
+	Grouped struct {
+		B [3]int32
+		A int64
+		C sync.Mutex
+	}
+	// Flag is common
+	Flag bool
+)
+`),
+			},
+		},
+		"arch pkg should fallback to provided strategy without target strategy": {
+			ctx:     context.Background(),
+			p:       data.NewParser("arch"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: []Target{{Arch: "amd64", Exposer: targets[0].Exposer}, {Arch: "386", Exposer: targets[1].Exposer}},
+			stg:     np,
+			sts: map[string][]byte{
+				"tests_data_arch_file.go": []byte(`
+//go:build tests_data
+
+package arch
+
+import (
+	"sync"
+)
+
+// Common is laid out
+// identically on all archs
+type Common struct {
+	A bool
+	B int32
+	C bool
+}
+
+// Variant depends
+// on int64 alignment
+type Variant struct {
+	A int64
+	B [3]int32
+}
+
+type (
+	// Grouped depends
+	// on int64 alignment too
+	Grouped struct {
+		A int64
+		B [3]int32
+		C sync.Mutex
+	}
+	// Flag is common
+	Flag bool
+)
+`),
+			},
+		},
+		"arch pkg should visit nothing on canceled context": {
+			ctx:     cctx,
+			p:       data.NewParser("arch"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: targets,
+			sts:     map[string][]byte{},
+			err:     context.Canceled,
+		},
+		"arch pkg should visit nothing on type parser error": {
+			ctx:     context.Background(),
+			p:       mocks.Parser{Typeserr: errors.New("test-1")},
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: targets,
+			sts:     map[string][]byte{},
+			err:     errors.New("test-1"),
+		},
+		"arch pkg should visit nothing on ast parser error": {
+			ctx:     context.Background(),
+			p:       mocks.Parser{Parser: data.NewParser("arch"), Asterr: errors.New("test-2")},
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: targets,
+			sts:     map[string][]byte{},
+			err:     errors.New("test-2"),
+		},
+		"arch pkg should visit nothing on strategy error": {
+			ctx:     context.Background(),
+			p:       data.NewParser("arch"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: []Target{{Arch: "amd64", Exposer: targets[0].Exposer, Strategy: &mocks.Strategy{Err: errors.New("test-3")}}},
+			sts:     map[string][]byte{},
+			err:     errors.New("test-3"),
+		},
+		"arch pkg should visit nothing on apply error": {
+			ctx:     context.Background(),
+			p:       data.NewParser("arch"),
+			a:       (&mocks.Apply{Err: errors.New("test-4")}).Report,
+			w:       data.Writer{Writer: &mocks.Writer{}},
+			targets: targets,
+			sts:     map[string][]byte{},
+			err:     errors.New("test-4"),
+		},
+		"arch pkg should visit nothing on cat persist error": {
+			ctx:     context.Background(),
+			p:       data.NewParser("arch"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{Cerr: errors.New("test-5")}},
+			targets: targets,
+			sts:     map[string][]byte{},
+			err:     errors.New("test-5"),
+		},
+		"arch pkg should visit nothing on persist error": {
+			ctx:     context.Background(),
+			p:       data.NewParser("arch"),
+			a:       astutil.UFFN,
+			w:       data.Writer{Writer: &mocks.Writer{Gerr: errors.New("test-6")}},
+			targets: targets,
+			sts:     map[string][]byte{},
+			err:     errors.New("test-6"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			warch := warch{
+				apply:  tcase.a,
+				writer: tcase.w,
+			}.With(tcase.p, p, tcase.targets, io.Discard, false, false)
+			// exec
+			err := warch.Visit(tcase.ctx, regexp.MustCompile(`.*`), tcase.stg)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				w := (tcase.w.(data.Writer)).Writer.(*mocks.Writer)
+				for id, rwc := range w.RWCs {
+					// read rwc to buffer
+					var buf bytes.Buffer
+					_, err := buf.ReadFrom(rwc)
+					if !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					// check all files
+					// against bytes map
+					if st, ok := tcase.sts[id]; ok {
+						// format actual and expected identically
+						actual := strings.Trim(buf.String(), "\n")
+						expected := strings.Trim(string(st), "\n")
+						if !reflect.DeepEqual(actual, expected) {
+							t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+						}
+						delete(tcase.sts, id)
+					} else {
+						t.Errorf("actual %v %s doesn't equal to expected %v", id, buf.String(), "")
+					}
+				}
+				// check that map has been drained
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
+
+func TestWarchSplit(t *testing.T) {
+	// prepare
+	w := warch{targets: []Target{{Arch: "amd64"}, {Arch: "386"}}}
+	src := func(build string, b string) []byte {
+		return []byte(fmt.Sprintf(`%s
+package arch
+
+type A struct {
+	%s
+}
+
+type B struct {
+	A bool
+}
+`, build, b))
+	}
+	variant := func(build string, b string) []byte {
+		return []byte(fmt.Sprintf(`//go:build %s
+
+package arch
+
+type A struct {
+	%s
+}
+
+`, build, b))
+	}
+	common := func(build string) []byte {
+		return []byte(fmt.Sprintf(`%s
+package arch
+
+
+type B struct {
+	A bool
+}
+`, build))
+	}
+	srcs := func(name string, build string) []map[string][]byte {
+		return []map[string][]byte{
+			{name: src(build, "A int64")},
+			{name: src(build, "B int32")},
+			{name: src(build, "C bool")},
+		}
+	}
+	table := map[string]struct {
+		name  string
+		srcs  []map[string][]byte
+		files map[string][]byte
+	}{
+		"plain file should be split to architectures files": {
+			name: "file.go",
+			srcs: srcs("file.go", ""),
+			files: map[string][]byte{
+				"file.go":       common(""),
+				"file_amd64.go": variant("amd64", "A int64"),
+				"file_386.go":   variant("386", "B int32"),
+				"file_other.go": variant("!amd64 && !386", "C bool"),
+			},
+		},
+		"goos file should be split to architectures files with goos constraint": {
+			name: "file_linux.go",
+			srcs: srcs("file_linux.go", "//go:build tests_data\n"),
+			files: map[string][]byte{
+				"file_linux.go":       common("//go:build tests_data\n"),
+				"file_linux_amd64.go": variant("tests_data && linux && amd64", "A int64"),
+				"file_linux_386.go":   variant("tests_data && linux && 386", "B int32"),
+				"file_linux_other.go": variant("tests_data && linux && !amd64 && !386", "C bool"),
+			},
+		},
+		"test file should be split to architectures test files": {
+			name: "file_test.go",
+			srcs: srcs("file_test.go", ""),
+			files: map[string][]byte{
+				"file_test.go":       common(""),
+				"file_amd64_test.go": variant("amd64", "A int64"),
+				"file_386_test.go":   variant("386", "B int32"),
+				"file_other_test.go": variant("!amd64 && !386", "C bool"),
+			},
+		},
+		"target goarch file should be synced with target results": {
+			name: "file_386.go",
+			srcs: srcs("file_386.go", ""),
+			files: map[string][]byte{
+				"file_386.go": src("", "B int32"),
+			},
+		},
+		"target goos goarch test file should be synced with target results": {
+			name: "file_linux_amd64_test.go",
+			srcs: srcs("file_linux_amd64_test.go", ""),
+			files: map[string][]byte{
+				"file_linux_amd64_test.go": src("", "A int64"),
+			},
+		},
+		"other goarch file should be kept untouched": {
+			name: "file_arm64.go",
+			srcs: srcs("file_arm64.go", ""),
+		},
+		"fallback test file should be kept untouched": {
+			name: "file_other_test.go",
+			srcs: srcs("file_other_test.go", ""),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			files, err := w.split(tcase.name, tcase.srcs)
+			// check
+			if !reflect.DeepEqual(err, nil) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			if !reflect.DeepEqual(len(files), len(tcase.files)) {
+				t.Errorf("actual %v doesn't equal to expected %v", len(files), len(tcase.files))
+			}
+			for fname, file := range tcase.files {
+				if !bytes.Equal(files[fname], file) {
+					t.Errorf("name %v actual %s doesn't equal to expected %s", fname, files[fname], file)
+				}
+			}
+		})
+	}
+}
+
+func TestWarchMerge(t *testing.T) {
+	// prepare
+	src := []byte(`//go:build tests_data && amd64
+
+package arch
+
+import (
+	"sync"
+)
+
+type A struct {
+	A sync.Mutex
+}
+`)
+	table := map[string]struct {
+		name string
+		dst  []byte
+		src  []byte
+		file []byte
+		err  error
+	}{
+		"existing file with equivalent build constraint should be merged": {
+			name: "file_amd64.go",
+			dst:  []byte("//go:build tests_data\n\npackage arch\n\nconst name = \"amd64\"\n"),
+			src:  src,
+			file: []byte(`//go:build tests_data
+
+package arch
+
+import "sync"
+
+const name = "amd64"
+
+type A struct {
+	A sync.Mutex
+}
+`),
+		},
+		"existing file with equivalent explicit build constraint should be merged": {
+			name: "file_gen.go",
+			dst:  []byte("//go:build amd64 && tests_data\n\npackage arch\n"),
+			src:  src,
+			file: []byte(`//go:build amd64 && tests_data
+
+package arch
+
+import "sync"
+
+type A struct {
+	A sync.Mutex
+}
+`),
+		},
+		"existing file with different build constraint should return error": {
+			name: "file_amd64.go",
+			dst:  []byte("//go:build linux\n\npackage arch\n"),
+			src:  src,
+			err:  errors.New(`can't merge type declarations to existing file "file_amd64.go" with different build constraint`),
+		},
+		"existing file without build constraint should return error": {
+			name: "file_gen.go",
+			dst:  []byte("package arch\n"),
+			src:  src,
+			err:  errors.New(`can't merge type declarations to existing file "file_gen.go" with different build constraint`),
+		},
+		"existing file of other package should return error": {
+			name: "file_amd64.go",
+			dst:  []byte("//go:build tests_data\n\npackage other\n"),
+			src:  src,
+			err:  errors.New(`can't merge type declarations to existing file "file_amd64.go" with different build constraint`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			file, err := merge(tcase.name, tcase.dst, tcase.src)
+			// check
+			if !reflect.DeepEqual(string(file), string(tcase.file)) {
+				t.Errorf("actual %s doesn't equal to expected %s", file, tcase.file)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
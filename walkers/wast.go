@@ -2,6 +2,7 @@ package walkers
 
 import (
 	"context"
+	"io"
 	"regexp"
 
 	"github.com/1pkg/gopium/collections"
@@ -32,27 +33,39 @@ var (
 		persister: astutil.Package{},
 		writer:    fmtio.Origin{Writter: fmtio.Files{Ext: fmtio.GOPIUM}},
 	}
+	astpatch = wast{
+		apply:     astutil.UFFN,
+		persister: astutil.Patch{},
+		writer:    fmtio.Origin{Writter: fmtio.Stdout{}},
+	}
+	astpatchfile = wast{
+		apply:     astutil.UFFN,
+		persister: astutil.Patch{},
+		writer:    fmtio.Origin{Writter: fmtio.File{Name: gopium.NAME, Ext: fmtio.PATCH}},
+	}
 )
 
 // wast defines packages walker ast sync implementation
 type wast struct {
-	persister gopium.Persister      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	writer    gopium.CategoryWriter `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	parser    gopium.Parser         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	exposer   gopium.Exposer        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	printer   gopium.Printer        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	apply     gopium.Apply          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	deep      bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	bref      bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_         [38]byte              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 88 bytes; - 🌺 gopium @1pkg
+	persister gopium.Persister             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	writer    gopium.CategoryWriter        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser    gopium.Parser                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exposer   gopium.Exposer               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	printer   gopium.Printer               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	apply     func(io.Writer) gopium.Apply `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	warn      io.Writer                    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep      bool                         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref      bool                         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_         [22]byte                     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 104 bytes; - 🌺 gopium @1pkg
 
 // With erich wast walker with external visiting parameters
-// parser, exposer, printer instances and additional visiting flags
-func (w wast) With(xp gopium.Parser, exp gopium.Exposer, p gopium.Printer, deep bool, bref bool) wast {
+// parser, exposer, printer instances, warnings writer and additional visiting flags
+func (w wast) With(xp gopium.Parser, exp gopium.Exposer, p gopium.Printer, warn io.Writer, deep bool, bref bool) wast {
 	w.parser = xp
 	w.exposer = exp
 	w.printer = p
+	w.warn = warn
 	w.deep = deep
 	w.bref = bref
 	return w
@@ -73,7 +86,7 @@ func (w wast) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strate
 	// using visit helper
 	// and run it on pkg scope
 	ch := make(appliedCh)
-	gvisit := with(w.exposer, loc, w.bref).
+	gvisit := with(w.exposer, loc, w.bref, w.warn).
 		visit(regex, stg, ch, w.deep)
 	// prepare separate cancelation
 	// context for visiting
@@ -114,7 +127,7 @@ func (w wast) write(ctx context.Context, h collections.Hierarchic) error {
 	// to update ast.Package
 	// in case any error happened
 	// just return error back
-	pkg, err = w.apply(ctx, pkg, loc, h)
+	pkg, err = w.apply(w.warn)(ctx, pkg, loc, h)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"regexp"
 	"strings"
@@ -36,12 +37,16 @@ func TestWast(t *testing.T) {
 	if !reflect.DeepEqual(err, nil) {
 		t.Fatalf("actual %v doesn't equal to %v", err, nil)
 	}
+	pckn, err := strategies.Builder{Curator: m}.Build(strategies.Pack, strategies.CacheL1D, strategies.StNoteCom)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
 	p := fmtio.Gofmt{}
 	table := map[string]struct {
 		ctx  context.Context
 		r    *regexp.Regexp
 		p    gopium.Parser
-		a    gopium.Apply
+		a    func(io.Writer) gopium.Apply
 		sp   gopium.Persister
 		w    gopium.CategoryWriter
 		stg  gopium.Strategy
@@ -152,7 +157,7 @@ type Single struct {
 			ctx: context.Background(),
 			r:   regexp.MustCompile(`.*`),
 			p:   data.NewParser("single"),
-			a:   (&mocks.Apply{Err: errors.New("test-6")}).Apply,
+			a:   (&mocks.Apply{Err: errors.New("test-6")}).Report,
 			sp:  astutil.Package{},
 			w:   data.Writer{Writer: &mocks.Writer{}},
 			stg: np,
@@ -347,6 +352,186 @@ type (
 	d2 float64
 	d3 string
 )
+`),
+			},
+		},
+		"multi structs pkg should persist expected patch with patch persister": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`^TestAZ$`),
+			p:    data.NewParser("multi"),
+			a:    astutil.UFFN,
+			sp:   astutil.Patch{},
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			deep: true,
+			sts: map[string][]byte{
+				"tests_data_multi_file-1.go": []byte(`
+diff --git a/tests/data/multi/file-1.go b/tests/data/multi/file-1.go
+--- a/tests/data/multi/file-1.go
++++ b/tests/data/multi/file-1.go
+@@ -27,8 +27,8 @@
+ 
+ func scope() {
+ 	type TestAZ struct {
+-		a bool
+ 		D A
++		a bool
+ 		z bool
+ 	}
+ }
+`),
+			},
+		},
+		"generic structs pkg should visit generic structs with order optimal across instances": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("generic"),
+			a:   astutil.UFFN,
+			sp:  astutil.Package{},
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pck,
+			sts: map[string][]byte{
+				"tests_data_generic_file.go": []byte(`
+//go:build tests_data
+
+package generic
+
+type Node[T any] struct {
+	next *Node[T]
+	v    T
+	a    bool
+	b    bool
+}
+
+type Pair[K comparable, V any] struct {
+	k K
+	f bool
+	v V
+}
+
+type Unused[T any] struct {
+	v T
+	a bool
+}
+
+type Plain struct {
+	b int64
+	a bool
+	c bool
+}
+
+var (
+	i Node[int64]
+	b Node[bool]
+	p Pair[int8, int64]
+	q = Pair[string, bool]{}
+)
+`),
+			},
+		},
+		"generic structs pkg should visit generic structs without size dependent pads and notes": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("generic"),
+			a:   astutil.UFFN,
+			sp:  astutil.Package{},
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pckn,
+			sts: map[string][]byte{
+				"tests_data_generic_file.go": []byte(`
+//go:build tests_data
+
+package generic
+
+type Node[T any] struct {
+	next *Node[T]
+	v    T
+	a    bool
+	b    bool
+}
+
+type Pair[K comparable, V any] struct {
+	k K
+	f bool
+	v V
+}
+
+type Unused[T any] struct {
+	v T
+	a bool
+}
+
+type Plain struct {
+	b int64
+	a bool
+	c bool
+	_ [6]byte
+}
+
+var (
+	i Node[int64]
+	b Node[bool]
+	p Pair[int8, int64]
+	q = Pair[string, bool]{}
+)
+`),
+			},
+		},
+		"anonymous structs pkg should visit all anonymous structs and rewrite their literals": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("anonymous"),
+			a:    astutil.UFFN,
+			sp:   astutil.Package{},
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			deep: true,
+			bref: true,
+			sts: map[string][]byte{
+				"tests_data_anonymous_file.go": []byte(`
+//go:build tests_data
+
+package anonymous
+
+type Config struct {
+	name  string
+	inner struct {
+		b int64
+		a bool
+		c bool
+	}
+	flag bool
+}
+
+var cfg struct {
+	y []string
+	x int8
+	z int8
+}
+
+var rows = []struct {
+	value float64
+	ok    bool
+	done  bool
+}{
+	{ok: true, value: 1.5, done: false},
+	{ok: false, value: 2.5, done: true},
+}
+
+var index map[string]struct {
+	ptr  *Config
+	id   int32
+	seen bool
+}
+
+func scope() int64 {
+	local := struct {
+		b int64
+		a int8
+		c int8
+	}{a: 1, b: 2, c: 3}
+	return local.b
+}
 `),
 			},
 		},
@@ -397,7 +582,7 @@ type PatientObject struct {
 				apply:     tcase.a,
 				persister: tcase.sp,
 				writer:    tcase.w,
-			}.With(tcase.p, m, p, tcase.deep, tcase.bref)
+			}.With(tcase.p, m, p, io.Discard, tcase.deep, tcase.bref)
 			// exec
 			err := wast.Visit(tcase.ctx, tcase.r, tcase.stg)
 			// check
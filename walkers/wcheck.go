@@ -0,0 +1,198 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// ErrCheck defines error that is returned
+// by check walker when any visited struct
+// differs from strategy result
+var ErrCheck = errors.New("structs layouts differ from strategy results")
+
+// list of wcheck presets
+var (
+	checkstd = wcheck{
+		writer: fmtio.Stdout{},
+	}
+)
+
+// wcheck defines packages walker check implementation
+type wcheck struct {
+	writer  gopium.Writer     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser  gopium.TypeParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exposer gopium.Exposer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	warn    io.Writer         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [62]byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 64 bytes; - 🌺 gopium @1pkg
+
+// With erich wcheck walker with external visiting parameters
+// parser, exposer instances, warnings writer and additional visiting flags
+func (w wcheck) With(p gopium.TypeParser, exp gopium.Exposer, warn io.Writer, deep bool, bref bool) wcheck {
+	w.parser = p
+	w.exposer = exp
+	w.warn = warn
+	w.deep = deep
+	w.bref = bref
+	return w
+}
+
+// Visit wcheck implementation uses visit function helper
+// to go through all structs decls inside the package
+// and applies strategy to them to get results,
+// then compares results with original structs
+// and reports all differences to output
+// in compiler like format, if any difference
+// has been found it returns ErrCheck back
+func (w wcheck) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	// use parser to parse types pkg data
+	// we don't care about fset
+	pkg, loc, err := w.parser.ParseTypes(ctx)
+	if err != nil {
+		return err
+	}
+	// create govisit func
+	// using gopium.Visit helper
+	// and run it on pkg scope
+	ch := make(appliedCh)
+	gvisit := with(w.exposer, loc, w.bref, w.warn).
+		visit(regex, stg, ch, w.deep)
+	// prepare separate cancelation
+	// context for visiting
+	gctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// run visiting in separate goroutine
+	go gvisit(gctx, pkg.Scope())
+	// prepare differences storage
+	diffs := make([]applied, 0)
+	for applied := range ch {
+		// in case any error happened
+		// just return error back
+		// it auto cancels context
+		if applied.Err != nil {
+			return applied.Err
+		}
+		// collect only structs that
+		// differ from strategy results
+		if differ(applied.O, applied.R) {
+			diffs = append(diffs, applied)
+		}
+	}
+	// run sync write
+	// with collected differences
+	return w.write(gctx, diffs)
+}
+
+// write wcheck helps to format
+// collected differences and writer
+// to write result to output
+func (w wcheck) write(_ context.Context, diffs []applied) error {
+	// skip empty writes
+	if len(diffs) == 0 {
+		return nil
+	}
+	// sort differences by
+	// their locs and lines
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Loc != diffs[j].Loc {
+			return diffs[i].Loc < diffs[j].Loc
+		}
+		return line(diffs[i].ID) < line(diffs[j].ID)
+	})
+	// format all differences
+	// one by one in compiler like format
+	var buf bytes.Buffer
+	for _, diff := range diffs {
+		fmt.Fprintf(&buf, "%s:%d: %s\n", diff.Loc, line(diff.ID), report(diff.O, diff.R))
+	}
+	// generate writer
+	loc := filepath.Join(filepath.Dir(diffs[0].Loc), "gopium")
+	writer, err := w.writer.Generate(loc)
+	if err != nil {
+		return err
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := writer.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return ErrCheck
+}
+
+// differ checks if original struct
+// differs from result struct either
+// by size, align, ptr scan size or fields order
+func differ(o gopium.Struct, r gopium.Struct) bool {
+	// compare structs sizes aligns and ptrs
+	sizeo, aligno, ptro := collections.SizeAlignPtr(o)
+	sizer, alignr, ptrr := collections.SizeAlignPtr(r)
+	if sizeo != sizer || aligno != alignr || ptro != ptrr {
+		return true
+	}
+	// compare structs fields order
+	if len(o.Fields) != len(r.Fields) {
+		return true
+	}
+	for i := range o.Fields {
+		if o.Fields[i].Name != r.Fields[i].Name || o.Fields[i].Type != r.Fields[i].Type {
+			return true
+		}
+	}
+	return false
+}
+
+// report formats single difference
+// message for original and result structs
+func report(o gopium.Struct, r gopium.Struct) string {
+	sizeo, _, ptro := collections.SizeAlignPtr(o)
+	sizer, _, ptrr := collections.SizeAlignPtr(r)
+	switch {
+	case sizer < sizeo:
+		return fmt.Sprintf("struct %s can shrink from %d to %d bytes", o.Name, sizeo, sizer)
+	case sizer > sizeo:
+		return fmt.Sprintf("struct %s can grow from %d to %d bytes", o.Name, sizeo, sizer)
+	case ptrr != ptro:
+		return fmt.Sprintf("struct %s can change ptr scan size from %d to %d bytes", o.Name, ptro, ptrr)
+	default:
+		return fmt.Sprintf("struct %s can reorder its fields", o.Name)
+	}
+}
+
+// line extracts struct line
+// from provided struct id
+func line(id string) int {
+	// id is expected in `sum:line` format
+	// with optional generic instance `[args]` suffix
+	// or anonymous struct `:column` suffix
+	// so take everything after the first colon
+	if i := strings.Index(id, "["); i >= 0 {
+		id = id[:i]
+	}
+	parts := strings.Split(id, ":")
+	if len(parts) < 2 {
+		return 0
+	}
+	l, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0
+	}
+	return l
+}
@@ -0,0 +1,294 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestWcheck(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := strategies.Builder{}
+	np, err := b.Build(strategies.Ignore)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	pck, err := b.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	unpck, err := b.Build(strategies.Unpack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	pckopt, err := strategies.Builder{Curator: m}.Build(strategies.PackOpt)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		ctx  context.Context
+		r    *regexp.Regexp
+		p    gopium.TypeParser
+		w    gopium.Writer
+		stg  gopium.Strategy
+		deep bool
+		bref bool
+		sts  map[string][]byte
+		warn []string
+		err  error
+	}{
+		"empty pkg should visit nothing": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("empty"),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: np,
+			sts: map[string][]byte{},
+		},
+		"single struct pkg should report nothing on optimal struct": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pck,
+			sts: map[string][]byte{},
+		},
+		"single struct pkg should report reordered struct": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: unpck,
+			sts: map[string][]byte{
+				"tests_data_single_gopium": []byte(`
+tests/data/single/file.go:5: struct Single can reorder its fields
+`),
+			},
+			err: ErrCheck,
+		},
+		"single struct pkg should visit nothing on canceled context": {
+			ctx: cctx,
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pck,
+			sts: map[string][]byte{},
+			err: context.Canceled,
+		},
+		"single struct pkg should visit nothing on parser error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   mocks.Parser{Typeserr: errors.New("test-1")},
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pck,
+			sts: map[string][]byte{},
+			err: errors.New("test-1"),
+		},
+		"single struct pkg should visit nothing on strategy error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: &mocks.Strategy{Err: errors.New("test-2")},
+			sts: map[string][]byte{},
+			err: errors.New("test-2"),
+		},
+		"single struct pkg should visit nothing on writer error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			w:   data.Writer{Writer: (&mocks.Writer{Gerr: errors.New("test-3")})},
+			stg: unpck,
+			sts: map[string][]byte{},
+			err: errors.New("test-3"),
+		},
+		"single struct pkg should visit nothing on write error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			w: data.Writer{Writer: (&mocks.Writer{RWCs: map[string]*mocks.RWC{
+				"tests_data_single_gopium": {Werr: errors.New("test-4")},
+			}})},
+			stg: unpck,
+			sts: map[string][]byte{},
+			err: errors.New("test-4"),
+		},
+		"single struct pkg should visit nothing on close error": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("single"),
+			w: data.Writer{Writer: (&mocks.Writer{RWCs: map[string]*mocks.RWC{
+				"tests_data_single_gopium": {Cerr: errors.New("test-5")},
+			}})},
+			stg: unpck,
+			sts: map[string][]byte{},
+			err: errors.New("test-5"),
+		},
+		"pinned structs pkg should report only unpinned structs differences": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("pinned"),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pck,
+			sts: map[string][]byte{
+				"tests_data_pinned_gopium": []byte(`
+tests/data/pinned/file-1.go:28: struct Free can shrink from 24 to 16 bytes
+`),
+			},
+			warn: []string{
+				"tests/data/pinned/file-1.go:10:6: struct Inner is pinned by encoding/binary usage, its fields won't be changed",
+				"tests/data/pinned/file-1.go:16:6: struct Header is pinned by encoding/binary usage, its fields won't be changed",
+				"tests/data/pinned/file-2.go:10:6: struct Raw is pinned by unsafe.Pointer, reflect usage, its fields won't be changed",
+				"tests/data/pinned/file-2.go:20:6: struct Offset is pinned by unsafe.Offsetof usage, its fields won't be changed",
+				"tests/data/pinned/file-2.go:28:6: struct Row is pinned by reflect usage, its fields won't be changed",
+				"tests/data/pinned/file-2.go:38:6: struct Mirror is pinned by go:linkname usage, its fields won't be changed",
+			},
+			err: ErrCheck,
+		},
+		"zero tail struct pkg should report trailing zero size field padding": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("zerotail"),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pckopt,
+			sts: map[string][]byte{
+				"tests_data_zerotail_gopium": []byte(`
+tests/data/zerotail/file.go:7: struct Tail can shrink from 16 to 8 bytes
+`),
+			},
+			err: ErrCheck,
+		},
+		"generic structs pkg should report all expected instances differences": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("generic"),
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pck,
+			sts: map[string][]byte{
+				"tests_data_generic_gopium": []byte(`
+tests/data/generic/file.go:5: struct Node[bool] can change ptr scan size from 16 to 8 bytes
+tests/data/generic/file.go:5: struct Node[int64] can shrink from 32 to 24 bytes
+tests/data/generic/file.go:5: struct Node can shrink from 32 to 24 bytes
+tests/data/generic/file.go:12: struct Pair[int8, int64] can reorder its fields
+tests/data/generic/file.go:12: struct Pair[string, bool] can shrink from 32 to 24 bytes
+tests/data/generic/file.go:12: struct Pair can shrink from 32 to 24 bytes
+tests/data/generic/file.go:23: struct Plain can shrink from 24 to 16 bytes
+`),
+			},
+			err: ErrCheck,
+		},
+		"anonymous structs pkg should report all expected anonymous structs differences": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("anonymous"),
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			deep: true,
+			bref: true,
+			sts: map[string][]byte{
+				"tests_data_anonymous_gopium": []byte(`
+tests/data/anonymous/file.go:7: struct Config.inner can shrink from 24 to 16 bytes
+tests/data/anonymous/file.go:15: struct cfg can shrink from 40 to 32 bytes
+tests/data/anonymous/file.go:21: struct rows can shrink from 24 to 16 bytes
+tests/data/anonymous/file.go:30: struct index can shrink from 24 to 16 bytes
+tests/data/anonymous/file.go:37: struct scope.local can shrink from 24 to 16 bytes
+`),
+			},
+			err: ErrCheck,
+		},
+		"multi structs pkg should report all expected structs differences": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("multi"),
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			deep: true,
+			bref: true,
+			sts: map[string][]byte{
+				"tests_data_multi_gopium": []byte(`
+tests/data/multi/file-1.go:29: struct TestAZ can shrink from 24 to 16 bytes
+tests/data/multi/file-3.go:8: struct D can reorder its fields
+tests/data/multi/file-3.go:17: struct AZ can shrink from 40 to 32 bytes
+tests/data/multi/file-3.go:27: struct Zeze can reorder its fields
+`),
+			},
+			err: ErrCheck,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			var warn bytes.Buffer
+			wcheck := wcheck{
+				writer: tcase.w,
+			}.With(tcase.p, m, &warn, tcase.deep, tcase.bref)
+			// exec
+			err := wcheck.Visit(tcase.ctx, tcase.r, tcase.stg)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// pinned structs warnings
+			// order isn't deterministic
+			var warns []string
+			for _, line := range strings.Split(strings.ReplaceAll(warn.String(), tests.Gopium+"/", ""), "\n") {
+				if line != "" {
+					warns = append(warns, line)
+				}
+			}
+			sort.Strings(warns)
+			if !reflect.DeepEqual(warns, tcase.warn) {
+				t.Errorf("actual %v doesn't equal to expected %v", warns, tcase.warn)
+			}
+			// process checks only on success
+			if tcase.err == nil || tcase.err == ErrCheck {
+				w := (tcase.w.(data.Writer)).Writer.(*mocks.Writer)
+				for id, rwc := range w.RWCs {
+					// check all struct
+					// against bytes map
+					if st, ok := tcase.sts[id]; ok {
+						// read rwc to buffer
+						var buf bytes.Buffer
+						_, err := buf.ReadFrom(rwc)
+						if !reflect.DeepEqual(err, nil) {
+							t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+						}
+						// format actual and expected identically
+						// and purify absolute locations
+						actual := strings.Trim(strings.ReplaceAll(buf.String(), tests.Gopium+"/", ""), "\n")
+						expected := strings.Trim(string(st), "\n")
+						if !reflect.DeepEqual(actual, expected) {
+							t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+						}
+						delete(tcase.sts, id)
+					} else {
+						t.Errorf("actual %v doesn't equal to expected %v", id, "")
+					}
+				}
+				// check that map has been drained
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"regexp"
 	"strings"
@@ -77,6 +78,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 8,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -89,6 +92,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 8,
+					"Offset": 16,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -101,6 +106,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 8,
+					"Offset": 32,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -122,6 +129,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 8,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -134,6 +143,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 8,
+					"Offset": 16,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -146,6 +157,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 8,
+					"Offset": 32,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -256,6 +269,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 8,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -275,6 +290,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -287,6 +304,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 8,
+					"Pad": 7,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -299,6 +318,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 32,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -318,6 +339,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 16,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": true,
@@ -330,6 +353,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 16,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
@@ -342,6 +367,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 40,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 40,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
@@ -354,6 +381,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 80,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -373,6 +402,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -385,6 +416,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 8,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 8,
+					"Pad": 7,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -397,6 +430,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 16,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -418,6 +453,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 8,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -437,6 +474,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -449,6 +488,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 24,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -461,6 +502,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 25,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -480,6 +523,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 16,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": true,
@@ -492,6 +537,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 40,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 16,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
@@ -504,6 +551,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 56,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
@@ -516,6 +565,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 80,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -535,6 +586,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 8,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -547,6 +600,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 8,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -559,6 +614,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 9,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -595,6 +652,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 8,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -614,6 +673,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -626,6 +687,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 8,
+					"Pad": 7,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -638,6 +701,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 32,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -657,6 +722,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 16,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": true,
@@ -669,6 +736,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 16,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
@@ -681,6 +750,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 32,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 40,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
@@ -693,6 +764,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 72,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -714,6 +787,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 8,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -733,6 +808,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -745,6 +822,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 24,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -757,6 +836,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 1,
 					"Align": 1,
 					"Ptr": 0,
+					"Offset": 25,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": false,
@@ -776,6 +857,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 16,
 					"Align": 8,
 					"Ptr": 16,
+					"Offset": 0,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": false,
 					"Embedded": true,
@@ -788,6 +871,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 32,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 16,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
@@ -800,6 +885,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 48,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": true,
@@ -812,6 +899,8 @@ func TestWdiff(t *testing.T) {
 					"Size": 24,
 					"Align": 8,
 					"Ptr": 0,
+					"Offset": 72,
+					"Pad": 0,
 					"Tag": "",
 					"Exported": true,
 					"Embedded": false,
@@ -832,7 +921,7 @@ func TestWdiff(t *testing.T) {
 			wdiff := wdiff{
 				fmt:    tcase.fmt,
 				writer: tcase.w,
-			}.With(tcase.p, m, tcase.deep, tcase.bref)
+			}.With(tcase.p, m, io.Discard, tcase.deep, tcase.bref)
 			// exec
 			err := wdiff.Visit(tcase.ctx, tcase.r, tcase.stg)
 			// check
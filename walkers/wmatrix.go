@@ -0,0 +1,207 @@
+package walkers
+
+import (
+	"context"
+	"go/types"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+// list of wmatrix presets
+var (
+	samatrixfilemdt = wmatrix{
+		fmt:    fmtio.SizeAlignMatrixMdt,
+		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.MD},
+	}
+	samatrixfilehtml = wmatrix{
+		fmt:    fmtio.SizeAlignMatrixHtmlt,
+		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.HTML},
+	}
+	samatrixfilejson = wmatrix{
+		fmt:    fmtio.SizeAlignMatrixJsonb,
+		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.JSON},
+	}
+)
+
+// MavenOptions defines platforms mavens options
+// cpu caches lines sizes, cpu caches hierarchy
+// and interference sizes overrides that are
+// applied to maven of each walker platform
+type MavenOptions struct {
+	Caches       []int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Hierarchy    []typepkg.SysCache `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Destructive  int64              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Constructive int64              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// Maven creates maven for provided compiler and arch
+// with mavens options applied on top of it
+func (opts MavenOptions) Maven(compiler, arch string) (typepkg.MavenGoTypes, error) {
+	m, err := typepkg.NewMavenGoTypes(compiler, arch, opts.Caches...)
+	if err != nil {
+		return typepkg.MavenGoTypes{}, err
+	}
+	return m.Interference(opts.Destructive, opts.Constructive).Hierarchy(opts.Hierarchy...), nil
+}
+
+// wmatrix defines packages walker matrix implementation
+// that visits package with maven for each platform
+// supported by types sizes and formats results matrix
+type wmatrix struct {
+	writer    gopium.Writer         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser    gopium.TypeParser     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	opts      MavenOptions          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fmt       gopium.Matrix         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	warn      io.Writer             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	snames    []gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	platforms []string              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep      bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref      bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_         [22]byte              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; struct ptr scan size: 152 bytes; - 🌺 gopium @1pkg
+
+// With erich wmatrix walker with external visiting parameters
+// parser instance, mavens options, strategies names, warnings writer and additional visiting flags,
+// mavens options are applied to mavens for all platforms and strategies
+// are rebuilt by names for each platform maven
+func (w wmatrix) With(p gopium.TypeParser, opts MavenOptions, snames []gopium.StrategyName, warn io.Writer, deep bool, bref bool) wmatrix {
+	w.parser = p
+	w.opts = opts
+	w.snames = snames
+	w.warn = warn
+	w.platforms = typepkg.Platforms()
+	w.deep = deep
+	w.bref = bref
+	return w
+}
+
+// Visit wmatrix implementation uses visit function helper
+// to go through all structs decls inside the package
+// and applies strategy to them to get results
+// on each platform, then uses matrix formatter
+// to format results and writer to write them to output
+func (w wmatrix) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	// use parser to parse types pkg data
+	// we don't care about fset
+	pkg, loc, err := w.parser.ParseTypes(ctx)
+	if err != nil {
+		return err
+	}
+	// visit package on each platform
+	// and collect results separately
+	hos := make([]collections.Hierarchic, 0, len(w.platforms))
+	hrs := make([]collections.Hierarchic, 0, len(w.platforms))
+	for _, platform := range w.platforms {
+		// set up platform maven
+		compiler, arch := platform, ""
+		if i := strings.Index(platform, "/"); i >= 0 {
+			compiler, arch = platform[:i], platform[i+1:]
+		}
+		m, err := w.opts.Maven(compiler, arch)
+		if err != nil {
+			return err
+		}
+		// rebuild strategy for platform
+		// maven if strategies names are known
+		// otherwise use provided strategy
+		pstg := stg
+		if len(w.snames) > 0 {
+			if pstg, err = (strategies.Builder{Curator: m, Interference: w.opts.Destructive > 0}).Build(w.snames...); err != nil {
+				return err
+			}
+		}
+		ho, hr, err := w.collect(ctx, pkg, loc, regex, m, pstg)
+		if err != nil {
+			return err
+		}
+		hos = append(hos, ho)
+		hrs = append(hrs, hr)
+	}
+	// run sync write
+	// with collected results
+	return w.write(ctx, hos, hrs)
+}
+
+// collect wmatrix helps to visit types package
+// with platform exposer and strategy
+// and collect original and resulted structs
+func (w wmatrix) collect(
+	ctx context.Context,
+	pkg *types.Package,
+	loc gopium.Locator,
+	regex *regexp.Regexp,
+	exp gopium.Exposer,
+	stg gopium.Strategy,
+) (collections.Hierarchic, collections.Hierarchic, error) {
+	// create govisit func
+	// using gopium.Visit helper
+	// and run it on pkg scope
+	ch := make(appliedCh)
+	gvisit := with(exp, loc, w.bref, w.warn).
+		visit(regex, stg, ch, w.deep)
+	// prepare separate cancelation
+	// context for visiting
+	gctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// run visiting in separate goroutine
+	go gvisit(gctx, pkg.Scope())
+	// prepare struct storages
+	ho, hr := collections.NewHierarchic(""), collections.NewHierarchic("")
+	for applied := range ch {
+		// in case any error happened
+		// just return error back
+		// it auto cancels context
+		if applied.Err != nil {
+			return ho, hr, applied.Err
+		}
+		// push structs to storages
+		ho.Push(applied.ID, applied.Loc, applied.O)
+		hr.Push(applied.ID, applied.Loc, applied.R)
+	}
+	return ho, hr, ctx.Err()
+}
+
+// write wmatrix helps to apply formatter
+// to format platforms results and writer
+// to write result to output
+func (w wmatrix) write(_ context.Context, hos []collections.Hierarchic, hrs []collections.Hierarchic) error {
+	// skip empty writes
+	if len(hos) == 0 || hos[0].Len() == 0 {
+		return nil
+	}
+	// cast collections to categorized
+	os := make([]gopium.Categorized, 0, len(hos))
+	rs := make([]gopium.Categorized, 0, len(hrs))
+	for i := range hos {
+		os = append(os, hos[i])
+		rs = append(rs, hrs[i])
+	}
+	// apply formatter
+	buf, err := w.fmt(w.platforms, os, rs)
+	// in case any error happened
+	// in formatter return error back
+	if err != nil {
+		return err
+	}
+	// generate writer
+	loc := filepath.Join(hos[0].Rcat(), "gopium")
+	writer, err := w.writer.Generate(loc)
+	if err != nil {
+		return err
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := writer.Write(buf); err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,246 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestWmatrix(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := strategies.Builder{}
+	np, err := b.Build(strategies.Ignore)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	opts := MavenOptions{Caches: []int64{64, 64, 64}}
+	table := map[string]struct {
+		ctx       context.Context
+		p         gopium.TypeParser
+		w         gopium.Writer
+		platforms []string
+		snames    []gopium.StrategyName
+		stg       gopium.Strategy
+		sts       map[string][]byte
+		err       error
+	}{
+		"empty pkg should visit nothing": {
+			ctx:       context.Background(),
+			p:         data.NewParser("empty"),
+			w:         data.Writer{Writer: &mocks.Writer{}},
+			platforms: []string{"gc/amd64", "gccgo/386"},
+			stg:       np,
+			sts:       map[string][]byte{},
+		},
+		"arch pkg should visit all structs on all platforms with rebuilt strategies": {
+			ctx:       context.Background(),
+			p:         data.NewParser("arch"),
+			w:         data.Writer{Writer: &mocks.Writer{}},
+			platforms: []string{"gc/amd64", "gccgo/386"},
+			snames:    []gopium.StrategyName{strategies.Pack, strategies.SepSysT},
+			stg:       np,
+			sts: map[string][]byte{
+				"tests_data_arch_gopium": []byte(`
+| Struct Name | gc/amd64 | gccgo/386 |
+| :---: | :---: | :---: |
+| Common | 12/4/6/0 -> 16/4/10/0 | 12/4/6/0 -> 12/4/6/0 |
+| Variant | 24/8/4/0 -> 32/8/12/0 | 20/4/0/0 -> 24/4/4/0 |
+| Grouped | 32/8/4/0 -> 40/8/12/0 | 28/4/0/0 -> 32/4/4/0 |
+
+Each cell contains original -> current struct size/align/pad/ptr scan size in bytes.
+`),
+			},
+		},
+		"arch pkg should visit all structs on all platforms with provided strategy": {
+			ctx:       context.Background(),
+			p:         data.NewParser("arch"),
+			w:         data.Writer{Writer: &mocks.Writer{}},
+			platforms: []string{"gc/amd64", "gccgo/386"},
+			stg:       np,
+			sts: map[string][]byte{
+				"tests_data_arch_gopium": []byte(`
+| Struct Name | gc/amd64 | gccgo/386 |
+| :---: | :---: | :---: |
+| Common | 12/4/6/0 -> 12/4/6/0 | 12/4/6/0 -> 12/4/6/0 |
+| Variant | 24/8/4/0 -> 24/8/4/0 | 20/4/0/0 -> 20/4/0/0 |
+| Grouped | 32/8/4/0 -> 32/8/4/0 | 28/4/0/0 -> 28/4/0/0 |
+
+Each cell contains original -> current struct size/align/pad/ptr scan size in bytes.
+`),
+			},
+		},
+		"arch pkg should visit nothing on canceled context": {
+			ctx:       cctx,
+			p:         data.NewParser("arch"),
+			w:         data.Writer{Writer: &mocks.Writer{}},
+			platforms: []string{"gc/amd64", "gccgo/386"},
+			stg:       np,
+			sts:       map[string][]byte{},
+			err:       context.Canceled,
+		},
+		"arch pkg should visit nothing on type parser error": {
+			ctx:       context.Background(),
+			p:         mocks.Parser{Typeserr: errors.New("test-1")},
+			w:         data.Writer{Writer: &mocks.Writer{}},
+			platforms: []string{"gc/amd64", "gccgo/386"},
+			stg:       np,
+			sts:       map[string][]byte{},
+			err:       errors.New("test-1"),
+		},
+		"arch pkg should visit nothing on invalid platform": {
+			ctx:       context.Background(),
+			p:         data.NewParser("arch"),
+			w:         data.Writer{Writer: &mocks.Writer{}},
+			platforms: []string{"gc/amd64", "gc/alpha"},
+			stg:       np,
+			sts:       map[string][]byte{},
+			err:       errors.New(`unsuported compiler "gc" arch "alpha" combination`),
+		},
+		"arch pkg should visit nothing on strategy names error": {
+			ctx:       context.Background(),
+			p:         data.NewParser("arch"),
+			w:         data.Writer{Writer: &mocks.Writer{}},
+			platforms: []string{"gc/amd64", "gccgo/386"},
+			snames:    []gopium.StrategyName{"test"},
+			stg:       np,
+			sts:       map[string][]byte{},
+			err:       errors.New(`strategy "test" wasn't found`),
+		},
+		"arch pkg should visit nothing on strategy error": {
+			ctx:       context.Background(),
+			p:         data.NewParser("arch"),
+			w:         data.Writer{Writer: &mocks.Writer{}},
+			platforms: []string{"gc/amd64", "gccgo/386"},
+			stg:       &mocks.Strategy{Err: errors.New("test-2")},
+			sts:       map[string][]byte{},
+			err:       errors.New("test-2"),
+		},
+		"arch pkg should visit nothing on writer error": {
+			ctx:       context.Background(),
+			p:         data.NewParser("arch"),
+			w:         data.Writer{Writer: &mocks.Writer{Gerr: errors.New("test-3")}},
+			platforms: []string{"gc/amd64", "gccgo/386"},
+			stg:       np,
+			sts:       map[string][]byte{},
+			err:       errors.New("test-3"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			wmatrix := wmatrix{
+				fmt:    fmtio.SizeAlignMatrixMdt,
+				writer: tcase.w,
+			}.With(tcase.p, opts, tcase.snames, io.Discard, false, false)
+			wmatrix.platforms = tcase.platforms
+			// exec
+			err := wmatrix.Visit(tcase.ctx, regexp.MustCompile(`.*`), tcase.stg)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				w := (tcase.w.(data.Writer)).Writer.(*mocks.Writer)
+				for id, rwc := range w.RWCs {
+					// read rwc to buffer
+					var buf bytes.Buffer
+					_, err := buf.ReadFrom(rwc)
+					if !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					// check all results
+					// against bytes map
+					if st, ok := tcase.sts[id]; ok {
+						// format actual and expected identically
+						actual := strings.Trim(buf.String(), "\n")
+						expected := strings.Trim(string(st), "\n")
+						if !reflect.DeepEqual(actual, expected) {
+							t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+						}
+						delete(tcase.sts, id)
+					} else {
+						t.Errorf("actual %v %v doesn't equal to expected %v", id, buf.String(), "")
+					}
+				}
+				// check that map has been drained
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
+
+func TestMavenOptions(t *testing.T) {
+	// prepare
+	hierarchy := []typepkg.SysCache{
+		{Line: 32, Size: 32 << 10, Ways: 8},
+		{Line: 64, Size: 1 << 20, Ways: 16},
+	}
+	m386, err := typepkg.NewMavenGoTypes("gc", "386")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	mc386, err := typepkg.NewMavenGoTypes("gc", "386", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	table := map[string]struct {
+		opts     MavenOptions
+		compiler string
+		arch     string
+		maven    typepkg.MavenGoTypes
+		err      error
+	}{
+		"empty options should return typical arch maven": {
+			compiler: "gc",
+			arch:     "386",
+			maven:    m386,
+		},
+		"options should return maven with caches hierarchy and interference sizes applied": {
+			opts: MavenOptions{
+				Caches:       []int64{64, 64, 64},
+				Hierarchy:    hierarchy,
+				Destructive:  256,
+				Constructive: 32,
+			},
+			compiler: "gc",
+			arch:     "386",
+			maven:    mc386.Interference(256, 32).Hierarchy(hierarchy...),
+		},
+		"invalid compiler arch combination should return error": {
+			compiler: "gc",
+			arch:     "test",
+			err:      errors.New(`unsuported compiler "gc" arch "test" combination`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			maven, err := tcase.opts.Maven(tcase.compiler, tcase.arch)
+			// check
+			if !reflect.DeepEqual(maven, tcase.maven) {
+				t.Errorf("actual %v doesn't equal to expected %v", maven, tcase.maven)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package walkers
 
 import (
 	"context"
+	"io"
 	"path/filepath"
 	"regexp"
 
@@ -35,17 +36,19 @@ type wout struct {
 	writer  gopium.Writer     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	parser  gopium.TypeParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	exposer gopium.Exposer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	warn    io.Writer         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	fmt     gopium.Bytes      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	deep    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	bref    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [6]byte           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 56 bytes; - 🌺 gopium @1pkg
+	_       [54]byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
 
 // With erich wast walker with external visiting parameters
-// parser, exposer instances and additional visiting flags
-func (w wout) With(p gopium.TypeParser, exp gopium.Exposer, deep bool, bref bool) wout {
+// parser, exposer instances, warnings writer and additional visiting flags
+func (w wout) With(p gopium.TypeParser, exp gopium.Exposer, warn io.Writer, deep bool, bref bool) wout {
 	w.parser = p
 	w.exposer = exp
+	w.warn = warn
 	w.deep = deep
 	w.bref = bref
 	return w
@@ -67,7 +70,7 @@ func (w wout) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strate
 	// using gopium.Visit helper
 	// and run it on pkg scope
 	ch := make(appliedCh)
-	gvisit := with(w.exposer, loc, w.bref).
+	gvisit := with(w.exposer, loc, w.bref, w.warn).
 		visit(regex, stg, ch, w.deep)
 	// prepare separate cancelation
 	// context for visiting
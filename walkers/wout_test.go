@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"regexp"
 	"strings"
@@ -76,6 +77,8 @@ func TestWout(t *testing.T) {
 				"Size": 16,
 				"Align": 8,
 				"Ptr": 8,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
@@ -88,6 +91,8 @@ func TestWout(t *testing.T) {
 				"Size": 16,
 				"Align": 8,
 				"Ptr": 8,
+				"Offset": 16,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
@@ -100,6 +105,8 @@ func TestWout(t *testing.T) {
 				"Size": 16,
 				"Align": 8,
 				"Ptr": 8,
+				"Offset": 32,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
@@ -208,6 +215,8 @@ func TestWout(t *testing.T) {
 				"Size": 8,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -227,6 +236,8 @@ func TestWout(t *testing.T) {
 				"Size": 24,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
@@ -239,6 +250,8 @@ func TestWout(t *testing.T) {
 				"Size": 1,
 				"Align": 1,
 				"Ptr": 0,
+				"Offset": 24,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -251,6 +264,8 @@ func TestWout(t *testing.T) {
 				"Size": 1,
 				"Align": 1,
 				"Ptr": 0,
+				"Offset": 25,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -270,6 +285,8 @@ func TestWout(t *testing.T) {
 				"Size": 16,
 				"Align": 8,
 				"Ptr": 16,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": true,
@@ -282,6 +299,8 @@ func TestWout(t *testing.T) {
 				"Size": 40,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 16,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": true,
@@ -294,6 +313,8 @@ func TestWout(t *testing.T) {
 				"Size": 24,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 56,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": true,
@@ -306,6 +327,8 @@ func TestWout(t *testing.T) {
 				"Size": 24,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 80,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
@@ -325,6 +348,8 @@ func TestWout(t *testing.T) {
 				"Size": 8,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
@@ -337,6 +362,8 @@ func TestWout(t *testing.T) {
 				"Size": 1,
 				"Align": 1,
 				"Ptr": 0,
+				"Offset": 8,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -349,6 +376,8 @@ func TestWout(t *testing.T) {
 				"Size": 1,
 				"Align": 1,
 				"Ptr": 0,
+				"Offset": 9,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -383,6 +412,8 @@ func TestWout(t *testing.T) {
 				"Size": 8,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -402,6 +433,8 @@ func TestWout(t *testing.T) {
 				"Size": 24,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
@@ -414,6 +447,8 @@ func TestWout(t *testing.T) {
 				"Size": 1,
 				"Align": 1,
 				"Ptr": 0,
+				"Offset": 24,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -426,6 +461,8 @@ func TestWout(t *testing.T) {
 				"Size": 1,
 				"Align": 1,
 				"Ptr": 0,
+				"Offset": 25,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": false,
@@ -445,6 +482,8 @@ func TestWout(t *testing.T) {
 				"Size": 16,
 				"Align": 8,
 				"Ptr": 16,
+				"Offset": 0,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": false,
 				"Embedded": true,
@@ -457,6 +496,8 @@ func TestWout(t *testing.T) {
 				"Size": 32,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 16,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": true,
@@ -469,6 +510,8 @@ func TestWout(t *testing.T) {
 				"Size": 24,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 48,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": true,
@@ -481,6 +524,8 @@ func TestWout(t *testing.T) {
 				"Size": 24,
 				"Align": 8,
 				"Ptr": 0,
+				"Offset": 72,
+				"Pad": 0,
 				"Tag": "",
 				"Exported": true,
 				"Embedded": false,
@@ -500,7 +545,7 @@ func TestWout(t *testing.T) {
 			wout := wout{
 				fmt:    tcase.fmt,
 				writer: tcase.w,
-			}.With(tcase.p, m, tcase.deep, tcase.bref)
+			}.With(tcase.p, m, io.Discard, tcase.deep, tcase.bref)
 			// exec
 			err := wout.Visit(tcase.ctx, tcase.r, tcase.stg)
 			// check
@@ -0,0 +1,77 @@
+package walkers
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/1pkg/gopium/gopium"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// wpool defines packages walkers pool implementation
+// that expands multi packages parser to single packages parsers
+// and visits all packages concurrently with bounded number of workers
+type wpool struct {
+	expander gopium.Expander                            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	build    func(gopium.Parser) (gopium.Walker, error) `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	workers  int                                        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 24 bytes; - 🌺 gopium @1pkg
+
+// Visit wpool implementation expands multi packages parser
+// and builds single package walker for each expanded parser,
+// then runs all walkers concurrently limited by number of workers,
+// if any walker reported check difference it returns ErrCheck back
+// after all packages have been visited
+func (w wpool) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	// expand parser to single packages parsers
+	parsers, err := w.expander.Expand(ctx)
+	if err != nil {
+		return err
+	}
+	// build single package walker
+	// for each expanded parser
+	walkers := make([]gopium.Walker, 0, len(parsers))
+	for _, p := range parsers {
+		walker, err := w.build(p)
+		if err != nil {
+			return err
+		}
+		walkers = append(walkers, walker)
+	}
+	// create sync error group
+	// with cancelation context
+	// limited by number of workers
+	group, gctx := errgroup.WithContext(ctx)
+	if w.workers > 0 {
+		group.SetLimit(w.workers)
+	}
+	// go through all walkers
+	// and visit packages concurently
+	var checked atomic.Bool
+	for _, walker := range walkers {
+		walker := walker
+		group.Go(func() error {
+			err := walker.Visit(gctx, regex, stg)
+			// check difference shouldn't cancel
+			// visiting of other packages
+			// so just remember it
+			if errors.Is(err, ErrCheck) {
+				checked.Store(true)
+				return nil
+			}
+			return err
+		})
+	}
+	// wait until all packages
+	// have been visited
+	if err := group.Wait(); err != nil {
+		return err
+	}
+	if checked.Load() {
+		return ErrCheck
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package walkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestWpool(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	parsers := []gopium.Parser{
+		mocks.Parser{},
+		mocks.Parser{Typeserr: errors.New("test-1")},
+		mocks.Parser{Asterr: errors.New("test-2")},
+	}
+	table := map[string]struct {
+		ctx     context.Context
+		exp     gopium.Expander
+		build   func(gopium.Parser) (gopium.Walker, error)
+		workers int
+		visits  int32
+		err     error
+	}{
+		"expander error should be returned back": {
+			ctx: context.Background(),
+			exp: mocks.Expander{Err: errors.New("test-1")},
+			build: func(gopium.Parser) (gopium.Walker, error) {
+				return mocks.Walker{}, nil
+			},
+			err: errors.New("test-1"),
+		},
+		"canceled context should return context error": {
+			ctx: cctx,
+			exp: mocks.Expander{Parsers: parsers},
+			build: func(gopium.Parser) (gopium.Walker, error) {
+				return mocks.Walker{}, nil
+			},
+			err: context.Canceled,
+		},
+		"builder error should be returned back": {
+			ctx: context.Background(),
+			exp: mocks.Expander{Parsers: parsers},
+			build: func(gopium.Parser) (gopium.Walker, error) {
+				return nil, errors.New("test-2")
+			},
+			err: errors.New("test-2"),
+		},
+		"valid walkers should visit all packages": {
+			ctx: context.Background(),
+			exp: mocks.Expander{Parsers: parsers},
+			build: func(gopium.Parser) (gopium.Walker, error) {
+				return mocks.Walker{}, nil
+			},
+			workers: 2,
+			visits:  3,
+		},
+		"valid walkers should visit all packages with unlimited workers": {
+			ctx: context.Background(),
+			exp: mocks.Expander{Parsers: parsers},
+			build: func(gopium.Parser) (gopium.Walker, error) {
+				return mocks.Walker{}, nil
+			},
+			visits: 3,
+		},
+		"check walkers should visit all packages and return check error": {
+			ctx: context.Background(),
+			exp: mocks.Expander{Parsers: parsers},
+			build: func(p gopium.Parser) (gopium.Walker, error) {
+				if p.(mocks.Parser).Typeserr != nil {
+					return mocks.Walker{Err: ErrCheck}, nil
+				}
+				return mocks.Walker{}, nil
+			},
+			workers: 1,
+			visits:  3,
+			err:     ErrCheck,
+		},
+		"walker error should take precedence over check error": {
+			ctx: context.Background(),
+			exp: mocks.Expander{Parsers: parsers},
+			build: func(p gopium.Parser) (gopium.Walker, error) {
+				if p.(mocks.Parser).Typeserr != nil {
+					return mocks.Walker{Err: ErrCheck}, nil
+				}
+				if p.(mocks.Parser).Asterr != nil {
+					return mocks.Walker{Err: errors.New("test-3")}, nil
+				}
+				return mocks.Walker{}, nil
+			},
+			workers: 1,
+			visits:  3,
+			err:     errors.New("test-3"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			var visits int32
+			w := wpool{
+				expander: tcase.exp,
+				build: func(p gopium.Parser) (gopium.Walker, error) {
+					walker, err := tcase.build(p)
+					if err != nil {
+						return nil, err
+					}
+					return counter{walker: walker, visits: &visits}, nil
+				},
+				workers: tcase.workers,
+			}
+			// exec
+			err := w.Visit(tcase.ctx, regexp.MustCompile(`.*`), &mocks.Strategy{})
+			// check
+			if !reflect.DeepEqual(atomic.LoadInt32(&visits), tcase.visits) {
+				t.Errorf("actual %v doesn't equal to expected %v", visits, tcase.visits)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestBuilderExpander(t *testing.T) {
+	// prepare
+	b := Builder{
+		Parser: mocks.Expander{
+			Parsers: []gopium.Parser{mocks.Parser{}},
+		},
+		Exposer: mocks.Maven{},
+		Workers: 4,
+	}
+	table := map[string]struct {
+		name gopium.WalkerName
+		pool bool
+		err  error
+	}{
+		"`check` name should return walkers pool": {
+			name: Check,
+			pool: true,
+		},
+		"`ast_std` name should return walkers pool": {
+			name: AstStd,
+			pool: true,
+		},
+		"invalid name should return builder error": {
+			name: "test",
+			err:  fmt.Errorf(`walker "test" wasn't found`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			w, err := b.Build(tcase.name)
+			// check
+			_, pool := w.(wpool)
+			if !reflect.DeepEqual(pool, tcase.pool) {
+				t.Errorf("actual %v doesn't equal to expected %v", pool, tcase.pool)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+// counter defines walker wrapper
+// that counts number of visits
+type counter struct {
+	walker gopium.Walker
+	visits *int32
+}
+
+// Visit counter implementation
+func (c counter) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	atomic.AddInt32(c.visits, 1)
+	return c.walker.Visit(ctx, regex, stg)
+}